@@ -0,0 +1,459 @@
+// Code generated by go run ./scripts/genapi; DO NOT EDIT.
+
+package invgo
+
+import "github.com/tmstorm/invgo/endpoints"
+
+// API exposes every endpoint accessor of Client as an interface so it can be replaced in tests.
+// Use Client.API to get the API of a Client and invgomock.New for a fake.
+type API interface {
+	// BreakingNews manages the /breakingnews endpoint
+	// Get: Returns the requested Breaking News
+	// Post: Creates Breaking News
+	// Put: Modifies a set of Breaking News
+	// See https://releases.invgate.com/service-desk/api/#breakingnews
+	BreakingNews() endpoints.BreakingNewsAPI
+	// BreakingNewsAll gets all the Breaking News.
+	// See https://releases.invgate.com/service-desk/api/#breakingnewsall
+	BreakingNewsAll() endpoints.BreakingNewsAllAPI
+	// BreakingNewsStatus manages the updates of the breaking news.
+	// Get: Returns the updates of the requested Breaking News
+	// Post: Creates a new update to the given Breaking News
+	// See https://releases.invgate.com/service-desk/api/#breakingnewsstatus
+	BreakingNewsStatus() endpoints.BreakingNewsStatusAPI
+	// BreakingNewsAttributesStatus gets all the possible status for the Breaking News'
+	// and their descriptions.
+	// See https://releases.invgate.com/service-desk/api/#breakingnewsattributesstatus
+	BreakingNewsAttributesStatus() endpoints.AttributesAPI
+	// BreakingNewsAttributesType gets all the importance types of the Breaking News.
+	// See https://releases.invgate.com/service-desk/api/#breakingnewsattributestype
+	BreakingNewsAttributesType() endpoints.AttributesAPI
+	// Categories is used to get all categories for the current Invgate instance
+	// See https://releases.invgate.com/service-desk/api/#categories
+	Categories() endpoints.CategoriesAPI
+	// CustomFieldsByCategory gets the custom field definitions for the given category
+	// See https://releases.invgate.com/service-desk/api/#cffieldsbycategory
+	CustomFieldsByCategory() endpoints.CustomFieldsByCategoryAPI
+	// HelpDesks manages the help desks
+	// See https://releases.invgate.com/service-desk/api/#helpdesks
+	HelpDesks() endpoints.HelpDesksAPI
+	// Incident manages the /incident endpoint
+	// Get: Returns the information of the given request
+	// Post: Creates a request
+	// Put: Change attributes of a request
+	// See https://releases.invgate.com/service-desk/api/#incident
+	Incident() endpoints.IncidentAPI
+	// IncidentApproval returns an incidents approvals
+	// See https://releases.invgate.com/service-desk/api/#incidentapproval
+	IncidentApproval() endpoints.IncidentApprovalAPI
+	// IncidentApprovalAccept returns an incidents approval accept
+	// See https://releases.invgate.com/service-desk/api/#incidentapprovalaccept
+	IncidentApprovalAccept() endpoints.IncidentApprovalAcceptAPI
+	// IncidentApprovalAddVoter add voter to incident
+	// See https://releases.invgate.com/service-desk/api/#incidentapprovaladd_voter
+	IncidentApprovalAddVoter() endpoints.IncidentApprovalAddVoterAPI
+	// IncidentApprovalCancel returns an incidents approval cancel
+	// See https://releases.invgate.com/service-desk/api/#incidentapprovalcancel
+	IncidentApprovalCancel() endpoints.IncidentApprovalCancelAPI
+	// IncidentApprovalPossibleVoters returns an incidents approval possible voters
+	// See https://releases.invgate.com/service-desk/api/#incidentapprovalpossible_voters
+	IncidentApprovalPossibleVoters() endpoints.IncidentApprovalPossibleVotersAPI
+	// IncidentApprovalReject returns an incidents approval reject
+	// See https://releases.invgate.com/service-desk/api/#incidentapprovalcancel
+	IncidentApprovalReject() endpoints.IncidentApprovalRejectAPI
+	// IncidentApprovalStatus returns an incidents approval statuses
+	// See https://releases.invgate.com/service-desk/api/#incidentapprovalstatus
+	IncidentApprovalStatus() endpoints.IncidentApprovalStatusAPI
+	// IncidentApprovalType returns an incidents approval types
+	// See https://releases.invgate.com/service-desk/api/#incidentapprovaltype
+	IncidentApprovalType() endpoints.IncidentApprovalTypeAPI
+	// IncidentApprovalVoteStatus returns an incidents approval vote statues
+	// See https://releases.invgate.com/service-desk/api/#incidentapprovalvote_status
+	IncidentApprovalVoteStatus() endpoints.IncidentApprovalVoteStatusAPI
+	// IncidentAttachment returns an incidents attachment
+	// See https://releases.invgate.com/service-desk/api/#incidentattachment
+	IncidentAttachment() endpoints.IncidentAttachmentAPI
+	// IncidentAttributesPriority gets all the priority types usable for an incident or the one provided
+	// See https://releases.invgate.com/service-desk/api/#incidentattributespriority
+	IncidentAttributesPriority() endpoints.AttributesAPI
+	// IncidentAttributesSource gets all the source types usable for an incident or the one provided
+	// See https://releases.invgate.com/service-desk/api/#incidentattributesource
+	IncidentAttributesSource() endpoints.AttributesAPI
+	// IncidentAttributesStatus gets all the status types usable for an incident or the one provided
+	// See https://releases.invgate.com/service-desk/api/#incidentattributestype
+	IncidentAttributesStatus() endpoints.AttributesAPI
+	// IncidentAttributesType gets all the types usable for an incident or the one provided
+	// See https://releases.invgate.com/service-desk/api/#incidentattributestype
+	IncidentAttributesType() endpoints.AttributesAPI
+	// IncidentCancel manages canceling an incident
+	// See https://releases.invgate.com/service-desk/api/#incidentcancel
+	IncidentCancel() endpoints.IncidentCancelAPI
+	// IncidentCollaborator manages an incidents collaborators
+	// See https://releases.invgate.com/service-desk/api/#incidentcollaborator
+	IncidentCollaborator() endpoints.IncidentCollaboratorAPI
+	// IncidentComment manages an incidents comments
+	// See https://releases.invgate.com/service-desk/api/#incidentcomment
+	IncidentComment() endpoints.IncidentCommentAPI
+	// IncidentCustomApproval manages an incidents custom approvals
+	// See https://releases.invgate.com/service-desk/api/#incidentcustom_approval
+	IncidentCustomApproval() endpoints.IncidentCustomApprovalAPI
+	// IncidentExternalEntity manages an incidents custom approvals
+	// See https://releases.invgate.com/service-desk/api/#incidentexternal_entity
+	IncidentExternalEntity() endpoints.IncidentExternalEntityAPI
+	// IncidentLink manages incident links
+	// See https://releases.invgate.com/service-desk/api/#incidentlink
+	IncidentLink() endpoints.IncidentLinkAPI
+	// IncidentLinkedCIsCountersFrom manages incident links
+	// See https://releases.invgate.com/service-desk/api/#incidentlinked_ciscountersfrom
+	IncidentLinkedCIsCountersFrom() endpoints.IncidentLinkedCIsCountersFromAPI
+	// IncidentObserver manages an incidents observers
+	// See https://releases.invgate.com/service-desk/api/#incidentobserver
+	IncidentObserver() endpoints.IncidentObserverAPI
+	// IncidentReassign reassigns an incident
+	// See https://releases.invgate.com/service-desk/api/#incidentreassign
+	IncidentReassign() endpoints.IncidentReassignAPI
+	// IncidentReject rejects an incident
+	// See https://releases.invgate.com/service-desk/api/#incidentreject
+	IncidentReject() endpoints.IncidentRejectAPI
+	// IncidentReopen reopens an incident
+	// See https://releases.invgate.com/service-desk/api/#incidentreopen
+	IncidentReopen() endpoints.IncidentReopenAPI
+	// IncidentSolutionAccept accepts an incidents solution
+	// See https://releases.invgate.com/service-desk/api/#incidentsolutionaccept
+	IncidentSolutionAccept() endpoints.IncidentSolutionAcceptAPI
+	// IncidentSolutionReject rejects an incident solution
+	// See https://releases.invgate.com/service-desk/api/#incidentsolutionreject
+	IncidentSolutionReject() endpoints.IncidentSolutionRejectAPI
+	// IncidentSpontaneousApproval creates a spontaneous approval
+	// See https://releases.invgate.com/service-desk/api/#incidentspontaneous_approval
+	IncidentSpontaneousApproval() endpoints.IncidentSpontaneousApprovalAPI
+	// IncidentTasks get an incidents tasks
+	// See https://releases.invgate.com/service-desk/api/#incidenttasks
+	IncidentTasks() endpoints.IncidentTasksAPI
+	// IncidentWaitingForAgent manages an incidents waiting for
+	// See https://releases.invgate.com/service-desk/api/#incidentwaitingforagent
+	IncidentWaitingForAgent() endpoints.IncidentWaitingForAgentAPI
+	// IncidentWaitingForCustomer manages an incidents waiting for
+	// See https://releases.invgate.com/service-desk/api/#incidentwaitingforcustomer
+	IncidentWaitingForCustomer() endpoints.IncidentWaitingForCustomerAPI
+	// IncidentWaitingForDate manages an incidents waiting for
+	// See https://releases.invgate.com/service-desk/api/#incidentwaitingfordate
+	IncidentWaitingForDate() endpoints.IncidentWaitingForDateAPI
+	// IncidentWaitingForExternalEntity manages an incidents waiting for
+	// See https://releases.invgate.com/service-desk/api/#incidentwaitingforexternal_entity
+	IncidentWaitingForExternalEntity() endpoints.IncidentWaitingForExternalEntityAPI
+	// IncidentWaitingForIncident manages an incidents waiting for
+	// See https://releases.invgate.com/service-desk/api/#incidentwaitingforincident
+	IncidentWaitingForIncident() endpoints.IncidentWaitingForIncidentAPI
+	// Incidents is used to get Incidents from the Invgate API
+	// See https://releases.invgate.com/service-desk/api/#incidents
+	Incidents() endpoints.IncidentsAPI
+	// IncidentsByAgent gets incidents by the provided agent information
+	// See https://releases.invgate.com/service-desk/api/#incidentsbyagent
+	IncidentsByAgent() endpoints.IncidentsByAgentAPI
+	// IncidentsByCIs gets incidents by the provided CI
+	// See https://releases.invgate.com/service-desk/api/#incidentsbycis
+	IncidentsByCIs() endpoints.IncidentsByCIsAPI
+	// IncidentsByCustomer gets incidents by the provided customer
+	// See https://releases.invgate.com/service-desk/api/#incidentsbycis
+	IncidentsByCustomer() endpoints.IncidentsByCustomerAPI
+	// IncidentsByHelpDesk gets incidents by the provided helpdesk
+	// See https://releases.invgate.com/service-desk/api/#incidentsbyhelpdesk
+	IncidentsByHelpDesk() endpoints.IncidentsByHelpDeskAPI
+	// IncidentsBySentiment gets incidents by the provided sentiment
+	// See https://releases.invgate.com/service-desk/api/#incidentsbysentiment
+	IncidentsBySentiment() endpoints.IncidentsBySentimentAPI
+	// IncidentsByStatus gets incidents by the given set of status IDs
+	// See https://releases.invgate.com/service-desk/api/#incidentsbystatus
+	IncidentsByStatus() endpoints.IncidentsByStatusAPI
+	// IncidentsByView gets incidents by the provided view
+	// See https://releases.invgate.com/service-desk/api/#incidentsbyview
+	IncidentsByView() endpoints.IncidentsByViewAPI
+	// IncidentsDetailsByView gets incidents details by the provided view
+	// See https://releases.invgate.com/service-desk/api/#incidentsdetailsbyview
+	IncidentsDetailsByView() endpoints.IncidentsDetailsByViewAPI
+	// IncidentsLastHour gets incidents in the last hour
+	// See https://releases.invgate.com/service-desk/api/#incidentsdetailsbyview
+	IncidentsLastHour() endpoints.IncidentsLastHourAPI
+	// ServiceDeskVersion returns the current version of the Service Desk instance
+	// See https://releases.invgate.com/service-desk/api/#sdversion
+	ServiceDeskVersion() endpoints.ServiceDeskVersionAPI
+	// TimeTracking manages time tracking records in the Service Desk instance
+	// See https://releases.invgate.com/service-desk/api/#timetracking
+	TimeTracking() endpoints.TimeTrackingAPI
+	// TimeTrackingAttributesCategory manages time tracking records attributes by category in the Service Desk instance
+	// See https://releases.invgate.com/service-desk/api/#timetracking
+	TimeTrackingAttributesCategory() endpoints.TimeTrackingAttributesCategoryAPI
+	// Triggers returns user defined tiggers in the Service Desk instance
+	// See https://releases.invgate.com/service-desk/api/#triggers
+	Triggers() endpoints.TriggersAPI
+	// TriggersExecutions returns a list of each time a trigger was executed in the Service Desk instance
+	// See https://releases.invgate.com/service-desk/api/#triggers
+	TriggersExecutions() endpoints.TriggersExecutionsAPI
+	// User manages the /user endpoint
+	// Get: Returns the requested user
+	// Post: Creates a user
+	// Put: Modifies a user
+	// See https://releases.invgate.com/service-desk/api/#user
+	User() endpoints.UserAPI
+	// UserBy gets a user by username or email
+	// See https://releases.invgate.com/service-desk/api/#userby
+	UserBy() endpoints.UserByAPI
+	// UserConvert converts external user to internal user
+	// See https://releases.invgate.com/service-desk/api/#userconvert
+	UserConvert() endpoints.UserConvertAPI
+	// UserDisable disables a user
+	// See https://releases.invgate.com/service-desk/api/#userdisable
+	UserDisable() endpoints.UserDisableAPI
+	// UserEnable enables a user
+	// See https://releases.invgate.com/service-desk/api/#userenable
+	UserEnable() endpoints.UserEnableAPI
+	// UserPassword changes a users password
+	// See https://releases.invgate.com/service-desk/api/#userpassword
+	UserPassword() endpoints.UserPasswordAPI
+	// UserPasswordReset forces a user to do a password reset
+	// See https://releases.invgate.com/service-desk/api/#userpasswordreset
+	UserPasswordReset() endpoints.UserPasswordResetAPI
+	// UserToken creates a session token for a user
+	// See https://releases.invgate.com/service-desk/api/#usertoken
+	UserToken() endpoints.UserTokenAPI
+	// Users returns a list of each of the specified list or all users
+	// See https://releases.invgate.com/service-desk/api/#users
+	Users() endpoints.UsersAPI
+	// UsersBy returns a list of users matching the filter
+	// See https://releases.invgate.com/service-desk/api/#usersby
+	UsersBy() endpoints.UsersByAPI
+	// UsersGroups returns a users groups, companies, helpdesks, and locations
+	// See https://releases.invgate.com/service-desk/api/#usersgroups
+	UsersGroups() endpoints.UsersGroupsAPI
+	// WorkflowDeploy is used to deploy a workflow
+	// See https://releases.invgate.com/service-desk/api/#wfdeploy
+	WorkflowDeploy() endpoints.WorkflowDeployAPI
+	// WorkflowInitialFieldsByCategory is used to get the initial fields needed to create a workflow
+	// See https://releases.invgate.com/service-desk/api/#wfinitialfieldsbycategory
+	WorkflowInitialFieldsByCategory() endpoints.WorkflowInitialFieldsByCategoryAPI
+}
+
+// clientAPI implements API using a Client
+type clientAPI struct{ c *Client }
+
+// API returns the Client as an API
+func (c *Client) API() API {
+	return clientAPI{c}
+}
+
+func (a clientAPI) BreakingNews() endpoints.BreakingNewsAPI { return a.c.BreakingNews() }
+
+func (a clientAPI) BreakingNewsAll() endpoints.BreakingNewsAllAPI { return a.c.BreakingNewsAll() }
+
+func (a clientAPI) BreakingNewsStatus() endpoints.BreakingNewsStatusAPI {
+	return a.c.BreakingNewsStatus()
+}
+
+func (a clientAPI) BreakingNewsAttributesStatus() endpoints.AttributesAPI {
+	return a.c.BreakingNewsAttributesStatus()
+}
+
+func (a clientAPI) BreakingNewsAttributesType() endpoints.AttributesAPI {
+	return a.c.BreakingNewsAttributesType()
+}
+
+func (a clientAPI) Categories() endpoints.CategoriesAPI { return a.c.Categories() }
+
+func (a clientAPI) CustomFieldsByCategory() endpoints.CustomFieldsByCategoryAPI {
+	return a.c.CustomFieldsByCategory()
+}
+
+func (a clientAPI) HelpDesks() endpoints.HelpDesksAPI { return a.c.HelpDesks() }
+
+func (a clientAPI) Incident() endpoints.IncidentAPI { return a.c.Incident() }
+
+func (a clientAPI) IncidentApproval() endpoints.IncidentApprovalAPI { return a.c.IncidentApproval() }
+
+func (a clientAPI) IncidentApprovalAccept() endpoints.IncidentApprovalAcceptAPI {
+	return a.c.IncidentApprovalAccept()
+}
+
+func (a clientAPI) IncidentApprovalAddVoter() endpoints.IncidentApprovalAddVoterAPI {
+	return a.c.IncidentApprovalAddVoter()
+}
+
+func (a clientAPI) IncidentApprovalCancel() endpoints.IncidentApprovalCancelAPI {
+	return a.c.IncidentApprovalCancel()
+}
+
+func (a clientAPI) IncidentApprovalPossibleVoters() endpoints.IncidentApprovalPossibleVotersAPI {
+	return a.c.IncidentApprovalPossibleVoters()
+}
+
+func (a clientAPI) IncidentApprovalReject() endpoints.IncidentApprovalRejectAPI {
+	return a.c.IncidentApprovalReject()
+}
+
+func (a clientAPI) IncidentApprovalStatus() endpoints.IncidentApprovalStatusAPI {
+	return a.c.IncidentApprovalStatus()
+}
+
+func (a clientAPI) IncidentApprovalType() endpoints.IncidentApprovalTypeAPI {
+	return a.c.IncidentApprovalType()
+}
+
+func (a clientAPI) IncidentApprovalVoteStatus() endpoints.IncidentApprovalVoteStatusAPI {
+	return a.c.IncidentApprovalVoteStatus()
+}
+
+func (a clientAPI) IncidentAttachment() endpoints.IncidentAttachmentAPI {
+	return a.c.IncidentAttachment()
+}
+
+func (a clientAPI) IncidentAttributesPriority() endpoints.AttributesAPI {
+	return a.c.IncidentAttributesPriority()
+}
+
+func (a clientAPI) IncidentAttributesSource() endpoints.AttributesAPI {
+	return a.c.IncidentAttributesSource()
+}
+
+func (a clientAPI) IncidentAttributesStatus() endpoints.AttributesAPI {
+	return a.c.IncidentAttributesStatus()
+}
+
+func (a clientAPI) IncidentAttributesType() endpoints.AttributesAPI {
+	return a.c.IncidentAttributesType()
+}
+
+func (a clientAPI) IncidentCancel() endpoints.IncidentCancelAPI { return a.c.IncidentCancel() }
+
+func (a clientAPI) IncidentCollaborator() endpoints.IncidentCollaboratorAPI {
+	return a.c.IncidentCollaborator()
+}
+
+func (a clientAPI) IncidentComment() endpoints.IncidentCommentAPI { return a.c.IncidentComment() }
+
+func (a clientAPI) IncidentCustomApproval() endpoints.IncidentCustomApprovalAPI {
+	return a.c.IncidentCustomApproval()
+}
+
+func (a clientAPI) IncidentExternalEntity() endpoints.IncidentExternalEntityAPI {
+	return a.c.IncidentExternalEntity()
+}
+
+func (a clientAPI) IncidentLink() endpoints.IncidentLinkAPI { return a.c.IncidentLink() }
+
+func (a clientAPI) IncidentLinkedCIsCountersFrom() endpoints.IncidentLinkedCIsCountersFromAPI {
+	return a.c.IncidentLinkedCIsCountersFrom()
+}
+
+func (a clientAPI) IncidentObserver() endpoints.IncidentObserverAPI { return a.c.IncidentObserver() }
+
+func (a clientAPI) IncidentReassign() endpoints.IncidentReassignAPI { return a.c.IncidentReassign() }
+
+func (a clientAPI) IncidentReject() endpoints.IncidentRejectAPI { return a.c.IncidentReject() }
+
+func (a clientAPI) IncidentReopen() endpoints.IncidentReopenAPI { return a.c.IncidentReopen() }
+
+func (a clientAPI) IncidentSolutionAccept() endpoints.IncidentSolutionAcceptAPI {
+	return a.c.IncidentSolutionAccept()
+}
+
+func (a clientAPI) IncidentSolutionReject() endpoints.IncidentSolutionRejectAPI {
+	return a.c.IncidentSolutionReject()
+}
+
+func (a clientAPI) IncidentSpontaneousApproval() endpoints.IncidentSpontaneousApprovalAPI {
+	return a.c.IncidentSpontaneousApproval()
+}
+
+func (a clientAPI) IncidentTasks() endpoints.IncidentTasksAPI { return a.c.IncidentTasks() }
+
+func (a clientAPI) IncidentWaitingForAgent() endpoints.IncidentWaitingForAgentAPI {
+	return a.c.IncidentWaitingForAgent()
+}
+
+func (a clientAPI) IncidentWaitingForCustomer() endpoints.IncidentWaitingForCustomerAPI {
+	return a.c.IncidentWaitingForCustomer()
+}
+
+func (a clientAPI) IncidentWaitingForDate() endpoints.IncidentWaitingForDateAPI {
+	return a.c.IncidentWaitingForDate()
+}
+
+func (a clientAPI) IncidentWaitingForExternalEntity() endpoints.IncidentWaitingForExternalEntityAPI {
+	return a.c.IncidentWaitingForExternalEntity()
+}
+
+func (a clientAPI) IncidentWaitingForIncident() endpoints.IncidentWaitingForIncidentAPI {
+	return a.c.IncidentWaitingForIncident()
+}
+
+func (a clientAPI) Incidents() endpoints.IncidentsAPI { return a.c.Incidents() }
+
+func (a clientAPI) IncidentsByAgent() endpoints.IncidentsByAgentAPI { return a.c.IncidentsByAgent() }
+
+func (a clientAPI) IncidentsByCIs() endpoints.IncidentsByCIsAPI { return a.c.IncidentsByCIs() }
+
+func (a clientAPI) IncidentsByCustomer() endpoints.IncidentsByCustomerAPI {
+	return a.c.IncidentsByCustomer()
+}
+
+func (a clientAPI) IncidentsByHelpDesk() endpoints.IncidentsByHelpDeskAPI {
+	return a.c.IncidentsByHelpDesk()
+}
+
+func (a clientAPI) IncidentsBySentiment() endpoints.IncidentsBySentimentAPI {
+	return a.c.IncidentsBySentiment()
+}
+
+func (a clientAPI) IncidentsByStatus() endpoints.IncidentsByStatusAPI { return a.c.IncidentsByStatus() }
+
+func (a clientAPI) IncidentsByView() endpoints.IncidentsByViewAPI { return a.c.IncidentsByView() }
+
+func (a clientAPI) IncidentsDetailsByView() endpoints.IncidentsDetailsByViewAPI {
+	return a.c.IncidentsDetailsByView()
+}
+
+func (a clientAPI) IncidentsLastHour() endpoints.IncidentsLastHourAPI { return a.c.IncidentsLastHour() }
+
+func (a clientAPI) ServiceDeskVersion() endpoints.ServiceDeskVersionAPI {
+	return a.c.ServiceDeskVersion()
+}
+
+func (a clientAPI) TimeTracking() endpoints.TimeTrackingAPI { return a.c.TimeTracking() }
+
+func (a clientAPI) TimeTrackingAttributesCategory() endpoints.TimeTrackingAttributesCategoryAPI {
+	return a.c.TimeTrackingAttributesCategory()
+}
+
+func (a clientAPI) Triggers() endpoints.TriggersAPI { return a.c.Triggers() }
+
+func (a clientAPI) TriggersExecutions() endpoints.TriggersExecutionsAPI {
+	return a.c.TriggersExecutions()
+}
+
+func (a clientAPI) User() endpoints.UserAPI { return a.c.User() }
+
+func (a clientAPI) UserBy() endpoints.UserByAPI { return a.c.UserBy() }
+
+func (a clientAPI) UserConvert() endpoints.UserConvertAPI { return a.c.UserConvert() }
+
+func (a clientAPI) UserDisable() endpoints.UserDisableAPI { return a.c.UserDisable() }
+
+func (a clientAPI) UserEnable() endpoints.UserEnableAPI { return a.c.UserEnable() }
+
+func (a clientAPI) UserPassword() endpoints.UserPasswordAPI { return a.c.UserPassword() }
+
+func (a clientAPI) UserPasswordReset() endpoints.UserPasswordResetAPI { return a.c.UserPasswordReset() }
+
+func (a clientAPI) UserToken() endpoints.UserTokenAPI { return a.c.UserToken() }
+
+func (a clientAPI) Users() endpoints.UsersAPI { return a.c.Users() }
+
+func (a clientAPI) UsersBy() endpoints.UsersByAPI { return a.c.UsersBy() }
+
+func (a clientAPI) UsersGroups() endpoints.UsersGroupsAPI { return a.c.UsersGroups() }
+
+func (a clientAPI) WorkflowDeploy() endpoints.WorkflowDeployAPI { return a.c.WorkflowDeploy() }
+
+func (a clientAPI) WorkflowInitialFieldsByCategory() endpoints.WorkflowInitialFieldsByCategoryAPI {
+	return a.c.WorkflowInitialFieldsByCategory()
+}
@@ -0,0 +1,67 @@
+package invgo_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/endpoints"
+	"github.com/tmstorm/invgo/internal/apigen"
+	"github.com/tmstorm/invgo/invgomock"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+func TestGeneratedAPIUpToDate(t *testing.T) {
+	files, err := apigen.Generate(".")
+	assert.NoError(t, err)
+
+	for path, want := range files {
+		got, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, string(want), string(got), "%s is out of date, run go generate ./...", path)
+	}
+}
+
+// incidentTitle is an example of code that depends on invgo.API
+func incidentTitle(api invgo.API, id int) (string, error) {
+	incs, err := api.Incident().Get(endpoints.IncidentGetParams{ID: id})
+	if err != nil || len(incs) == 0 {
+		return "", err
+	}
+	return incs[0].Title, nil
+}
+
+func TestClientAPI(t *testing.T) {
+	a := assert.New(t)
+
+	server := newRoutedServer(t, map[string]any{
+		"/incident": endpoints.Incident{ID: 1, Title: "Printer on fire"},
+	})
+	defer server.Close()
+
+	c := newTestClient(t, server, scopes.IncidentGet)
+	title, err := incidentTitle(c.API(), 1)
+	a.NoError(err)
+	a.Equal("Printer on fire", title)
+}
+
+func TestMockAPI(t *testing.T) {
+	a := assert.New(t)
+
+	api := invgomock.New()
+	_, err := incidentTitle(api, 1)
+	a.True(errors.Is(err, invgomock.ErrNotImplemented))
+
+	api.IncidentMock.GetFunc = func(p endpoints.IncidentGetParams) ([]endpoints.Incident, error) {
+		return []endpoints.Incident{{ID: p.ID, Title: "Printer on fire"}}, nil
+	}
+	title, err := incidentTitle(api, 2)
+	a.NoError(err)
+	a.Equal("Printer on fire", title)
+
+	calls := api.IncidentMock.Calls()
+	a.Len(calls, 2)
+	a.Equal(invgomock.Call{Method: "Get", Args: []any{endpoints.IncidentGetParams{ID: 2}}}, calls[1])
+}
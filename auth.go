@@ -0,0 +1,149 @@
+package invgo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/tmstorm/invgo/scopes"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+type (
+	// Auth is used by New to authenticate every request made to the Invgate instance.
+	// If Invgate.Auth is nil ClientCredentials is used.
+	Auth interface {
+		// Transport returns a RoundTripper that authenticates each request before sending it with base
+		Transport(ctx context.Context, cfg *Invgate, base http.RoundTripper) (http.RoundTripper, error)
+	}
+
+	// TokenAuth is implemented by an Auth that authenticates with OAuth2 tokens.
+	// New uses the TokenSource directly so the same tokens are used for requests and
+	// for Invgate.ValidateScopes.
+	TokenAuth interface {
+		Auth
+		TokenSource(ctx context.Context, cfg *Invgate) (oauth2.TokenSource, error)
+	}
+
+	// ClientCredentials authenticates using the OAuth2 client credentials flow with the
+	// ClientID, ClientSecret, TokenURL and Scopes of the Invgate config.
+	// Tokens are refreshed when they expire, or before they expire when Invgate.TokenCache is set.
+	ClientCredentials struct{}
+
+	// StaticToken authenticates every request with the same bearer token.
+	// This is mostly useful in tests or when the token is managed outside of Invgo.
+	StaticToken struct {
+		Token string
+	}
+
+	// BasicAuth authenticates every request with HTTP basic auth for Invgate API users.
+	// If Password is empty it is loaded from PasswordSource.
+	BasicAuth struct {
+		Username       string
+		Password       string
+		PasswordSource SecretSource
+	}
+
+	// TokenSourceAuth authenticates using tokens from a custom oauth2.TokenSource.
+	// Tokens are reused until they expire.
+	TokenSourceAuth struct {
+		Source oauth2.TokenSource
+	}
+
+	// basicAuthTransport sets basic auth on every request
+	basicAuthTransport struct {
+		username string
+		password string
+		base     http.RoundTripper
+	}
+)
+
+// TokenSource returns a token source for the client credentials in cfg
+func (ClientCredentials) TokenSource(ctx context.Context, cfg *Invgate) (oauth2.TokenSource, error) {
+	secret, err := clientSecret(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	cred := &clientcredentials.Config{
+		ClientID:     cfg.ClientID,
+		ClientSecret: secret,
+		TokenURL:     cfg.TokenURL,
+		Scopes:       scopes.CreateScopes(cfg.Scopes),
+	}
+
+	if cfg.TokenCache == nil {
+		return cred.TokenSource(ctx), nil
+	}
+	src := tokenSourceFunc(func() (*oauth2.Token, error) { return cred.Token(ctx) })
+	return newCachingTokenSource(cfg, src), nil
+}
+
+// Transport returns a RoundTripper authenticating with client credentials
+func (a ClientCredentials) Transport(ctx context.Context, cfg *Invgate, base http.RoundTripper) (http.RoundTripper, error) {
+	return tokenTransport(ctx, a, cfg, base)
+}
+
+// TokenSource returns a token source that always returns the static token
+func (a StaticToken) TokenSource(_ context.Context, _ *Invgate) (oauth2.TokenSource, error) {
+	if a.Token == "" {
+		return nil, errors.New("static token can not be empty")
+	}
+	return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: a.Token, TokenType: "Bearer"}), nil
+}
+
+// Transport returns a RoundTripper authenticating with the static token
+func (a StaticToken) Transport(ctx context.Context, cfg *Invgate, base http.RoundTripper) (http.RoundTripper, error) {
+	return tokenTransport(ctx, a, cfg, base)
+}
+
+// TokenSource returns Source wrapped so tokens are reused until they expire
+func (a TokenSourceAuth) TokenSource(_ context.Context, _ *Invgate) (oauth2.TokenSource, error) {
+	if a.Source == nil {
+		return nil, errors.New("no token source was provided")
+	}
+	return oauth2.ReuseTokenSource(nil, a.Source), nil
+}
+
+// Transport returns a RoundTripper authenticating with tokens from Source
+func (a TokenSourceAuth) Transport(ctx context.Context, cfg *Invgate, base http.RoundTripper) (http.RoundTripper, error) {
+	return tokenTransport(ctx, a, cfg, base)
+}
+
+// Transport returns a RoundTripper that sets basic auth on every request
+func (a BasicAuth) Transport(_ context.Context, _ *Invgate, base http.RoundTripper) (http.RoundTripper, error) {
+	if a.Username == "" {
+		return nil, errors.New("basic auth username can not be empty")
+	}
+
+	password := a.Password
+	if password == "" && a.PasswordSource != nil {
+		p, err := a.PasswordSource.Secret()
+		if err != nil {
+			return nil, err
+		}
+		password = p
+	}
+	if password == "" {
+		return nil, errors.New("basic auth password can not be empty")
+	}
+
+	return &basicAuthTransport{username: a.Username, password: password, base: base}, nil
+}
+
+// RoundTrip sets basic auth on a copy of req and sends it with the base transport
+func (t *basicAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	r.SetBasicAuth(t.username, t.password)
+	return t.base.RoundTrip(r)
+}
+
+// tokenTransport returns an oauth2.Transport using the token source of a
+func tokenTransport(ctx context.Context, a TokenAuth, cfg *Invgate, base http.RoundTripper) (http.RoundTripper, error) {
+	ts, err := a.TokenSource(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &oauth2.Transport{Source: ts, Base: base}, nil
+}
@@ -0,0 +1,100 @@
+package invgo_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/scopes"
+	"golang.org/x/oauth2"
+)
+
+// newAuthServer returns a server that responds to /sd.version with the Authorization header it received as the version
+func newAuthServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/oauth/token":
+			w.Write([]byte(`{"access_token":"issued","token_type":"bearer","expires_in":3600}`))
+		case invgo.InvgateAPIPath + "/sd.version":
+			w.Write([]byte(`{"version":"` + r.Header.Get("Authorization") + `"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+// countingTokenSource counts how many tokens it has returned
+type countingTokenSource struct{ calls atomic.Int32 }
+
+func (s *countingTokenSource) Token() (*oauth2.Token, error) {
+	s.calls.Add(1)
+	return &oauth2.Token{AccessToken: "custom", TokenType: "Bearer"}, nil
+}
+
+func TestAuth(t *testing.T) {
+	server := newAuthServer(t)
+	defer server.Close()
+
+	custom := &countingTokenSource{}
+
+	tests := []struct {
+		name     string
+		auth     invgo.Auth
+		expected string
+	}{
+		{name: "default", auth: nil, expected: "Bearer issued"},
+		{name: "client credentials", auth: invgo.ClientCredentials{}, expected: "Bearer issued"},
+		{name: "static token", auth: invgo.StaticToken{Token: "static"}, expected: "Bearer static"},
+		{name: "basic auth", auth: invgo.BasicAuth{Username: "user", Password: "pass"}, expected: "Basic dXNlcjpwYXNz"},
+		{name: "token source", auth: invgo.TokenSourceAuth{Source: custom}, expected: "Bearer custom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			cfg := newInstanceConfig(server, scopes.ServiceDeskVersionGet)
+			cfg.Auth = tt.auth
+
+			c, err := invgo.New(cfg)
+			a.NoError(err)
+
+			for range 2 {
+				v, err := c.ServiceDeskVersion().Get()
+				a.NoError(err)
+				a.Equal(tt.expected, v)
+			}
+		})
+	}
+
+	// tokens without an expiry are reused
+	assert.Equal(t, int32(1), custom.calls.Load())
+}
+
+func TestAuthErrors(t *testing.T) {
+	a := assert.New(t)
+	server := newAuthServer(t)
+	defer server.Close()
+
+	for _, auth := range []invgo.Auth{
+		invgo.StaticToken{},
+		invgo.BasicAuth{Username: "user"},
+		invgo.BasicAuth{Password: "pass"},
+		invgo.BasicAuth{Username: "user", PasswordSource: invgo.EnvSecret("INVGO_TEST_UNSET_SECRET")},
+		invgo.TokenSourceAuth{},
+	} {
+		cfg := newInstanceConfig(server, scopes.ServiceDeskVersionGet)
+		cfg.Auth = auth
+		_, err := invgo.New(cfg)
+		a.Error(err, "%T", auth)
+	}
+
+	cfg := newInstanceConfig(server, scopes.ServiceDeskVersionGet)
+	cfg.Auth = invgo.BasicAuth{Username: "user", Password: "pass"}
+	cfg.ValidateScopes = true
+	_, err := invgo.New(cfg)
+	a.Error(err)
+}
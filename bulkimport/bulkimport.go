@@ -0,0 +1,436 @@
+/*
+Package bulkimport imports incidents and users from CSV or JSONL files into Invgate.
+
+Records are read with ReadCSV or ReadJSONL and their fields are mapped onto
+endpoints.IncidentPostParams or endpoints.UserPostParams by the params `url` tag names.
+An Importer creates them with bounded concurrency and can also add comments and time tracking
+to each imported incident.
+
+	f, err := os.Open("tickets.csv")
+	if err != nil {
+		return err
+	}
+	recs, err := bulkimport.ReadCSV(f, "ticket_id")
+	if err != nil {
+		return err
+	}
+
+	im := bulkimport.NewImporter(client, bulkimport.Options{
+		Results: "tickets.results.jsonl",
+		Retry:   "tickets.retry.jsonl",
+	})
+	sum, err := im.Incidents(ctx, recs, bulkimport.IncidentJob{
+		Mapping:  bulkimport.Mapping{"subject": "title", "body": "description", "customer": "customer_id"},
+		Defaults: endpoints.IncidentPostParams{TypeID: 1, PriorityID: 2, CreatorID: importerID},
+	})
+	log.Printf("imported %d, skipped %d, failed %d", sum.Imported, sum.Skipped, sum.Failed)
+
+Every step of a record is appended to the Results file as a Result. When the import is run again
+records that are done are skipped and records that were interrupted continue from the last step
+so incidents are not created twice. Records that fail are written to the Retry file which can be
+read with ReadJSONL and imported again. They keep their ID so the same Results file can be used.
+*/
+package bulkimport
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/endpoints"
+)
+
+// DefaultConcurrency is the amount of records imported at once when Options.Concurrency is not set
+var DefaultConcurrency = 4
+
+type (
+	// Options is used to configure an Importer
+	Options struct {
+		// Concurrency is the max amount of records imported at once. If 0 DefaultConcurrency is used.
+		Concurrency int
+		// Results is the path of the file the progress of each record is appended to.
+		// Records that are done in it are skipped. If empty progress is only kept in memory.
+		Results string
+		// Retry is the path of the file records that fail are written to. It is truncated on
+		// every import. If empty failed records are only returned as errors.
+		Retry string
+	}
+
+	// IncidentJob describes how records are imported as incidents
+	IncidentJob struct {
+		// Mapping maps record fields to IncidentPostParams fields
+		Mapping Mapping
+		// Defaults are the params used before the record fields are mapped
+		Defaults endpoints.IncidentPostParams
+		// Prepare is called after the record is mapped and can change the params
+		Prepare func(Record, *endpoints.IncidentPostParams) error
+		// Comments returns the comments added to the incident in order. RequestID is set by the Importer.
+		Comments func(Record) ([]endpoints.IncidentCommentPostParams, error)
+		// TimeTracking returns the time logged on the incident in order. RequestID is set by the Importer.
+		TimeTracking func(Record) ([]endpoints.TimeTrackingPostParams, error)
+	}
+
+	// UserJob describes how records are imported as users
+	UserJob struct {
+		// Mapping maps record fields to UserPostParams fields
+		Mapping Mapping
+		// Defaults are the params used before the record fields are mapped
+		Defaults endpoints.UserPostParams
+		// Prepare is called after the record is mapped and can change the params
+		Prepare func(Record, *endpoints.UserPostParams) error
+	}
+
+	// Result is the progress of a record
+	Result struct {
+		ID        string `json:"id"`
+		RequestID int    `json:"request_id,omitempty"`
+		UserID    int    `json:"user_id,omitempty"`
+		// Comments is the amount of comments added to the incident
+		Comments int `json:"comments,omitempty"`
+		// TimeTracking is the amount of time tracking entries added to the incident
+		TimeTracking int  `json:"time_tracking,omitempty"`
+		Done         bool `json:"done"`
+	}
+
+	// Summary is the amount of records imported, skipped because they were already done and failed
+	Summary struct {
+		Imported int
+		Skipped  int
+		Failed   int
+	}
+
+	// RecordError is returned when a record fails to import
+	RecordError struct {
+		Record Record
+		Err    error
+	}
+
+	// Importer imports records into Invgate. Create it with NewImporter.
+	//
+	// Requires scopes: IncidentPost, IncidentCommentPost and TimeTrackingPost for incidents
+	// and UserPost for users
+	Importer struct {
+		client *invgo.Client
+		opts   Options
+
+		mu      sync.Mutex
+		results map[string]Result
+		out     *os.File
+		retry   *os.File
+	}
+)
+
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("unable to import record %s (line %d): %v", e.Record.ID, e.Record.Line, e.Err)
+}
+
+func (e *RecordError) Unwrap() error { return e.Err }
+
+// NewImporter creates an Importer for client
+func NewImporter(client *invgo.Client, opts Options) *Importer {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultConcurrency
+	}
+	return &Importer{client: client, opts: opts, results: map[string]Result{}}
+}
+
+// ReadResults reads the results appended by an Importer keyed by record ID.
+// The last result of each record is kept.
+func ReadResults(r io.Reader) (map[string]Result, error) {
+	results := map[string]Result{}
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		if len(s.Bytes()) == 0 {
+			continue
+		}
+		var res Result
+		if err := json.Unmarshal(s.Bytes(), &res); err != nil {
+			return nil, fmt.Errorf("invalid result: %w", err)
+		}
+		results[res.ID] = res
+	}
+	return results, s.Err()
+}
+
+// Results returns the progress of every record known to the Importer keyed by record ID
+func (im *Importer) Results() map[string]Result {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	results := make(map[string]Result, len(im.results))
+	for id, res := range im.results {
+		results[id] = res
+	}
+	return results
+}
+
+// Incidents creates an incident for each record and adds its comments and time tracking.
+// Records that fail do not stop the others and the errors are returned joined as RecordErrors.
+func (im *Importer) Incidents(ctx context.Context, recs []Record, job IncidentJob) (Summary, error) {
+	return im.run(ctx, recs, func(rec Record, res *Result) error {
+		if res.RequestID == 0 {
+			p := job.Defaults
+			if err := Decode(rec, job.Mapping, &p); err != nil {
+				return err
+			}
+			if job.Prepare != nil {
+				if err := job.Prepare(rec, &p); err != nil {
+					return err
+				}
+			}
+
+			resp, err := im.client.Incident().Post(p)
+			if err != nil {
+				return err
+			}
+			id, err := strconv.Atoi(resp.RequestID)
+			if err != nil {
+				return fmt.Errorf("invalid request id %q: %w", resp.RequestID, err)
+			}
+			res.RequestID = id
+			if err := im.save(*res); err != nil {
+				return err
+			}
+		}
+
+		if job.Comments != nil {
+			comments, err := job.Comments(rec)
+			if err != nil {
+				return err
+			}
+			for _, c := range comments[min(res.Comments, len(comments)):] {
+				c.RequestID = res.RequestID
+				resp, err := im.client.IncidentComment().Post(c)
+				if err != nil {
+					return err
+				}
+				if resp.Status == "ERROR" {
+					return fmt.Errorf("unable to add comment to incident %d: %s", res.RequestID, resp.Error)
+				}
+				res.Comments++
+				if err := im.save(*res); err != nil {
+					return err
+				}
+			}
+		}
+
+		if job.TimeTracking != nil {
+			entries, err := job.TimeTracking(rec)
+			if err != nil {
+				return err
+			}
+			for _, t := range entries[min(res.TimeTracking, len(entries)):] {
+				t.RequestID = res.RequestID
+				resp, err := im.client.TimeTracking().Post(t)
+				if err != nil {
+					return err
+				}
+				if resp.Status == "ERROR" {
+					return fmt.Errorf("unable to log time on incident %d", res.RequestID)
+				}
+				res.TimeTracking++
+				if err := im.save(*res); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Users creates a user for each record. The user id is saved as soon as the user is created
+// so running the import again does not create it twice.
+// Records that fail do not stop the others and the errors are returned joined as RecordErrors.
+func (im *Importer) Users(ctx context.Context, recs []Record, job UserJob) (Summary, error) {
+	return im.run(ctx, recs, func(rec Record, res *Result) error {
+		if res.UserID != 0 {
+			return nil
+		}
+
+		p := job.Defaults
+		if err := Decode(rec, job.Mapping, &p); err != nil {
+			return err
+		}
+		if job.Prepare != nil {
+			if err := job.Prepare(rec, &p); err != nil {
+				return err
+			}
+		}
+
+		u, err := im.client.User().Post(p)
+		if err != nil {
+			return err
+		}
+		res.UserID = u.ID
+		return im.save(*res)
+	})
+}
+
+// run imports recs with imp using Options.Concurrency workers. imp updates res after
+// every step it completes. The record is saved as done if imp returns no error.
+func (im *Importer) run(ctx context.Context, recs []Record, imp func(rec Record, res *Result) error) (Summary, error) {
+	if err := im.open(); err != nil {
+		return Summary{}, err
+	}
+	defer im.close()
+
+	var (
+		sum  Summary
+		errs []error
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+	)
+	queue := make(chan Record)
+	for range im.opts.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for rec := range queue {
+				im.mu.Lock()
+				res, ok := im.results[rec.ID]
+				im.mu.Unlock()
+
+				if ok && res.Done {
+					mu.Lock()
+					sum.Skipped++
+					mu.Unlock()
+					continue
+				}
+				res.ID = rec.ID
+
+				err := imp(rec, &res)
+				if err == nil {
+					res.Done = true
+					err = im.save(res)
+				}
+
+				mu.Lock()
+				if err != nil {
+					sum.Failed++
+					errs = append(errs, &RecordError{Record: rec, Err: err})
+					if rerr := im.writeRetry(rec); rerr != nil {
+						errs = append(errs, rerr)
+					}
+				} else {
+					sum.Imported++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	seen := map[string]bool{}
+feed:
+	for _, rec := range recs {
+		if seen[rec.ID] {
+			mu.Lock()
+			errs = append(errs, &RecordError{Record: rec, Err: errors.New("duplicate record id")})
+			sum.Failed++
+			if rerr := im.writeRetry(rec); rerr != nil {
+				errs = append(errs, rerr)
+			}
+			mu.Unlock()
+			continue
+		}
+		seen[rec.ID] = true
+
+		select {
+		case queue <- rec:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return sum, errors.Join(errs...)
+}
+
+// open loads the results file and opens the results and retry files for writing
+func (im *Importer) open() error {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	if im.opts.Results != "" {
+		f, err := os.Open(im.opts.Results)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			return err
+		default:
+			results, err := ReadResults(f)
+			f.Close()
+			if err != nil {
+				return fmt.Errorf("%s: %w", im.opts.Results, err)
+			}
+			for id, res := range results {
+				im.results[id] = res
+			}
+		}
+
+		im.out, err = os.OpenFile(im.opts.Results, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return err
+		}
+	}
+
+	if im.opts.Retry != "" {
+		f, err := os.OpenFile(im.opts.Retry, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+		if err != nil {
+			im.closeFiles()
+			return err
+		}
+		im.retry = f
+	}
+	return nil
+}
+
+func (im *Importer) close() {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+	im.closeFiles()
+}
+
+func (im *Importer) closeFiles() {
+	if im.out != nil {
+		im.out.Close()
+		im.out = nil
+	}
+	if im.retry != nil {
+		im.retry.Close()
+		im.retry = nil
+	}
+}
+
+// save stores res and appends it to the results file
+func (im *Importer) save(res Result) error {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	im.results[res.ID] = res
+	if im.out == nil {
+		return nil
+	}
+	return json.NewEncoder(im.out).Encode(res)
+}
+
+func (im *Importer) writeRetry(rec Record) error {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	if im.retry == nil {
+		return nil
+	}
+	return WriteJSONL(im.retry, rec)
+}
@@ -0,0 +1,260 @@
+package bulkimport_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmstorm/invgo/bulkimport"
+	"github.com/tmstorm/invgo/endpoints"
+	"github.com/tmstorm/invgo/invgotest"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+const ticketsCSV = `ticket_id,subject,body,customer,related,notes
+T1,Printer on fire,It is very hot,%[1]d,"1, 2",first;second
+T2,Broken mouse,,%[1]d,,
+T3,No customer,,not-a-number,,
+`
+
+func TestReadCSVAndDecode(t *testing.T) {
+	a := assert.New(t)
+
+	recs, err := bulkimport.ReadCSV(strings.NewReader(strings.ReplaceAll(ticketsCSV, "%[1]d", "7")), "ticket_id")
+	if !a.NoError(err) || !a.Len(recs, 3) {
+		return
+	}
+	a.Equal("T1", recs[0].ID)
+	a.Equal(2, recs[0].Line)
+	a.Equal("Printer on fire", recs[0].Fields["subject"])
+
+	p := endpoints.IncidentPostParams{TypeID: 1, Title: "default"}
+	err = bulkimport.Decode(recs[0], bulkimport.Mapping{
+		"subject":  "title",
+		"body":     "description",
+		"customer": "customer_id",
+		"related":  "related_to",
+	}, &p)
+	a.NoError(err)
+	a.Equal("Printer on fire", p.Title)
+	a.Equal("It is very hot", p.Description)
+	a.Equal(7, p.CustomerID)
+	a.Equal([]int{1, 2}, p.RelatedTo)
+	a.Equal(1, p.TypeID)
+
+	a.Error(bulkimport.Decode(recs[2], bulkimport.Mapping{"customer": "customer_id"}, &p))
+	a.Error(bulkimport.Decode(recs[0], bulkimport.Mapping{"subject": "nope"}, &p))
+
+	_, err = bulkimport.ReadCSV(strings.NewReader("ticket_id,a\n,b\n"), "ticket_id")
+	a.Error(err)
+}
+
+func TestReadJSONL(t *testing.T) {
+	a := assert.New(t)
+
+	in := `{"email":"jane@example.com","name":"Jane","lastname":"Doe","is_external":true,"groups":[1,2],"phone":null}
+
+{"email":"john@example.com","name":"John","lastname":"Doe","id":12}
+`
+	recs, err := bulkimport.ReadJSONL(strings.NewReader(in), "email")
+	if !a.NoError(err) || !a.Len(recs, 2) {
+		return
+	}
+	a.Equal("jane@example.com", recs[0].ID)
+	a.Equal("true", recs[0].Fields["is_external"])
+	a.Equal("1,2", recs[0].Fields["groups"])
+	a.NotContains(recs[0].Fields, "phone")
+	a.Equal(3, recs[1].Line)
+	a.Equal("12", recs[1].Fields["id"])
+
+	var buf bytes.Buffer
+	a.NoError(bulkimport.WriteJSONL(&buf, recs...))
+	again, err := bulkimport.ReadJSONL(&buf, "email")
+	a.NoError(err)
+	a.Equal(recs[1].Fields, again[1].Fields)
+
+	// records keep their ID when read back without an ID field
+	byLine, err := bulkimport.ReadJSONL(strings.NewReader(in), "")
+	a.NoError(err)
+	buf.Reset()
+	a.NoError(bulkimport.WriteJSONL(&buf, byLine[1]))
+	again, err = bulkimport.ReadJSONL(&buf, "")
+	if a.NoError(err) && a.Len(again, 1) {
+		a.Equal("3", again[0].ID)
+		a.Equal(byLine[1].Fields, again[0].Fields)
+	}
+
+	_, err = bulkimport.ReadJSONL(strings.NewReader("[1]\n"), "")
+	a.Error(err)
+}
+
+func TestImportIncidents(t *testing.T) {
+	a := assert.New(t)
+
+	srv := invgotest.NewServer(t)
+	customer := srv.AddUser(endpoints.UserGetResponse{Email: "jane@example.com"})
+	c := srv.Client(t, scopes.IncidentPost, scopes.IncidentCommentPost, scopes.TimeTrackingPost)
+
+	recs, err := bulkimport.ReadCSV(strings.NewReader(strings.ReplaceAll(ticketsCSV, "%[1]d", strconv.Itoa(customer))), "ticket_id")
+	if !a.NoError(err) {
+		return
+	}
+
+	dir := t.TempDir()
+	opts := bulkimport.Options{
+		Concurrency: 2,
+		Results:     filepath.Join(dir, "results.jsonl"),
+		Retry:       filepath.Join(dir, "retry.jsonl"),
+	}
+	job := bulkimport.IncidentJob{
+		Mapping:  bulkimport.Mapping{"subject": "title", "body": "description", "customer": "customer_id"},
+		Defaults: endpoints.IncidentPostParams{TypeID: 1, PriorityID: 1, CreatorID: customer},
+		Comments: func(rec bulkimport.Record) ([]endpoints.IncidentCommentPostParams, error) {
+			var cs []endpoints.IncidentCommentPostParams
+			for n := range strings.SplitSeq(rec.Fields["notes"], ";") {
+				if n != "" {
+					cs = append(cs, endpoints.IncidentCommentPostParams{AuthorID: customer, Comment: n})
+				}
+			}
+			return cs, nil
+		},
+		TimeTracking: func(rec bulkimport.Record) ([]endpoints.TimeTrackingPostParams, error) {
+			return []endpoints.TimeTrackingPostParams{{UserID: customer, From: 1700000000, To: 1700003600}}, nil
+		},
+	}
+
+	sum, err := bulkimport.NewImporter(c, opts).Incidents(context.Background(), recs, job)
+	a.Error(err)
+	a.Equal(bulkimport.Summary{Imported: 2, Failed: 1}, sum)
+
+	f, err := os.Open(opts.Results)
+	if !a.NoError(err) {
+		return
+	}
+	defer f.Close()
+	results, err := bulkimport.ReadResults(f)
+	a.NoError(err)
+	t1 := results["T1"]
+	a.True(t1.Done)
+	a.Equal(2, t1.Comments)
+	a.Equal(1, t1.TimeTracking)
+	inc, ok := srv.Incident(t1.RequestID)
+	a.True(ok)
+	a.Equal("Printer on fire", inc.Title)
+	a.Len(srv.Comments(t1.RequestID), 2)
+	a.NotContains(results, "T3")
+
+	retry, err := os.ReadFile(opts.Retry)
+	a.NoError(err)
+	failed, err := bulkimport.ReadJSONL(bytes.NewReader(retry), "ticket_id")
+	a.NoError(err)
+	if a.Len(failed, 1) {
+		a.Equal("T3", failed[0].ID)
+	}
+
+	// running again skips the records that are done
+	sum, err = bulkimport.NewImporter(c, opts).Incidents(context.Background(), recs[:2], job)
+	a.NoError(err)
+	a.Equal(bulkimport.Summary{Skipped: 2}, sum)
+	a.Len(srv.Comments(t1.RequestID), 2)
+}
+
+func TestImportResume(t *testing.T) {
+	a := assert.New(t)
+
+	srv := invgotest.NewServer(t)
+	customer := srv.AddUser(endpoints.UserGetResponse{Email: "jane@example.com"})
+	inc := srv.AddIncident(endpoints.Incident{Title: "Already created", UserID: customer})
+	c := srv.Client(t, scopes.IncidentPost, scopes.IncidentCommentPost)
+
+	// the incident was created and the first comment added before the import stopped
+	dir := t.TempDir()
+	results := filepath.Join(dir, "results.jsonl")
+	a.NoError(os.WriteFile(results, []byte(`{"id":"1","request_id":`+strconv.Itoa(inc)+`,"comments":1,"done":false}`+"\n"), 0o600))
+
+	recs := []bulkimport.Record{{ID: "1", Line: 1, Fields: map[string]string{"subject": "Already created"}}}
+	sum, err := bulkimport.NewImporter(c, bulkimport.Options{Results: results}).Incidents(context.Background(), recs, bulkimport.IncidentJob{
+		Mapping: bulkimport.Mapping{"subject": "title"},
+		Comments: func(bulkimport.Record) ([]endpoints.IncidentCommentPostParams, error) {
+			return []endpoints.IncidentCommentPostParams{
+				{AuthorID: customer, Comment: "first"},
+				{AuthorID: customer, Comment: "second"},
+			}, nil
+		},
+	})
+	a.NoError(err)
+	a.Equal(bulkimport.Summary{Imported: 1}, sum)
+
+	comments := srv.Comments(inc)
+	if a.Len(comments, 1) {
+		a.Equal("second", comments[0].Message)
+	}
+}
+
+func TestImportUsers(t *testing.T) {
+	a := assert.New(t)
+
+	srv := invgotest.NewServer(t)
+	c := srv.Client(t, scopes.UserPost)
+
+	recs, err := bulkimport.ReadJSONL(strings.NewReader(`{"mail":"jane@example.com","first":"Jane","last":"Doe","dept":"Sales"}
+{"mail":"jane@example.com","first":"Jane","last":"Doe"}
+{"mail":"john@example.com","first":"John"}
+`), "mail")
+	if !a.NoError(err) {
+		return
+	}
+
+	retry := filepath.Join(t.TempDir(), "retry.jsonl")
+	im := bulkimport.NewImporter(c, bulkimport.Options{Retry: retry})
+	sum, err := im.Users(context.Background(), recs, bulkimport.UserJob{
+		Mapping: bulkimport.Mapping{"mail": "email", "first": "name", "last": "lastname", "dept": "department"},
+	})
+	a.Error(err)
+	a.Equal(bulkimport.Summary{Imported: 1, Failed: 2}, sum)
+
+	res := im.Results()["jane@example.com"]
+	u, ok := srv.User(res.UserID)
+	if a.True(ok) {
+		a.Equal("Sales", u.Department)
+	}
+
+	// the duplicate is written to the retry file with the record that failed
+	b, err := os.ReadFile(retry)
+	a.NoError(err)
+	failed, err := bulkimport.ReadJSONL(bytes.NewReader(b), "mail")
+	a.NoError(err)
+	if a.Len(failed, 2) {
+		a.ElementsMatch([]string{"jane@example.com", "john@example.com"}, []string{failed[0].ID, failed[1].ID})
+	}
+}
+
+func TestImportUsersResume(t *testing.T) {
+	a := assert.New(t)
+
+	srv := invgotest.NewServer(t)
+	id := srv.AddUser(endpoints.UserGetResponse{Email: "jane@example.com"})
+	c := srv.Client(t, scopes.UserPost)
+
+	// the user was created before the import stopped
+	results := filepath.Join(t.TempDir(), "results.jsonl")
+	a.NoError(os.WriteFile(results, []byte(`{"id":"jane@example.com","user_id":`+strconv.Itoa(id)+`,"done":false}`+"\n"), 0o600))
+
+	recs := []bulkimport.Record{{ID: "jane@example.com", Line: 1, Fields: map[string]string{"mail": "jane@example.com", "first": "Jane", "last": "Doe"}}}
+	im := bulkimport.NewImporter(c, bulkimport.Options{Results: results})
+	sum, err := im.Users(context.Background(), recs, bulkimport.UserJob{
+		Mapping: bulkimport.Mapping{"mail": "email", "first": "name", "last": "lastname"},
+	})
+	a.NoError(err)
+	a.Equal(bulkimport.Summary{Imported: 1}, sum)
+	a.Equal(id, im.Results()["jane@example.com"].UserID)
+
+	for _, r := range srv.Requests() {
+		a.NotEqual("/user", r.Path)
+	}
+}
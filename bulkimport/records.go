@@ -0,0 +1,276 @@
+package bulkimport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// IDKey is the key WriteJSONL stores the ID of each record under. ReadJSONL uses it as the ID
+// instead of idField so records read back from a Retry file keep the ID they were imported with.
+const IDKey = "_bulkimport_id"
+
+type (
+	// Record is a row read from a CSV or JSONL source
+	Record struct {
+		// ID identifies the record in the results and retry files
+		ID string
+		// Line is the line of the source the record was read from
+		Line int
+		// Fields maps the source column or JSON key to its value
+		Fields map[string]string
+	}
+
+	// Mapping maps a source field to the `url` tag name of a params field.
+	// Source fields that are not in the Mapping are ignored.
+	Mapping map[string]string
+)
+
+// ReadCSV reads records from CSV with a header row. idField is the column used as the ID of
+// each record. If it is empty the line number is used.
+func ReadCSV(r io.Reader, idField string) ([]Record, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read csv header: %w", err)
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+
+	var recs []Record
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := cr.FieldPos(0)
+		rec := Record{Line: line, Fields: map[string]string{}}
+		for i, v := range row {
+			rec.Fields[header[i]] = v
+		}
+		if err := rec.setID(idField); err != nil {
+			return nil, err
+		}
+		recs = append(recs, rec)
+	}
+	return recs, nil
+}
+
+// ReadJSONL reads records from JSON objects separated by new lines. Numbers and bools are
+// converted to strings, arrays are joined with commas and null values are skipped.
+// idField is the key used as the ID of each record. If it is empty the line number is used.
+// Records written by WriteJSONL keep the ID stored under IDKey.
+func ReadJSONL(r io.Reader, idField string) ([]Record, error) {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+
+	var recs []Record
+	for line := 1; s.Scan(); line++ {
+		b := bytes.TrimSpace(s.Bytes())
+		if len(b) == 0 {
+			continue
+		}
+
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(b, &obj); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		rec := Record{Line: line, Fields: map[string]string{}}
+		for k, raw := range obj {
+			v, ok, err := jsonString(raw)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s: %w", line, k, err)
+			}
+			if ok {
+				rec.Fields[k] = v
+			}
+		}
+		if id, ok := rec.Fields[IDKey]; ok {
+			delete(rec.Fields, IDKey)
+			rec.ID = id
+		} else if err := rec.setID(idField); err != nil {
+			return nil, err
+		}
+		recs = append(recs, rec)
+	}
+	return recs, s.Err()
+}
+
+// WriteJSONL writes the fields of recs as JSON objects separated by new lines so they can be
+// read again with ReadJSONL. The ID of each record is written under IDKey.
+func WriteJSONL(w io.Writer, recs ...Record) error {
+	enc := json.NewEncoder(w)
+	for _, rec := range recs {
+		obj := make(map[string]string, len(rec.Fields)+1)
+		for k, v := range rec.Fields {
+			obj[k] = v
+		}
+		if rec.ID != "" {
+			obj[IDKey] = rec.ID
+		}
+		if err := enc.Encode(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Decode sets the fields of the params struct pointed to by dst from the fields of rec using m.
+// Params fields are found by their `url` tag including fields of embedded structs.
+// Slices are read from comma separated values. Empty values are skipped so defaults set on dst are kept.
+func Decode(rec Record, m Mapping, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("decode expects a pointer to a struct got %T", dst)
+	}
+
+	fields := map[string]reflect.Value{}
+	urlFields(rv.Elem(), fields)
+
+	for src, name := range m {
+		s := strings.TrimSpace(rec.Fields[src])
+		if s == "" {
+			continue
+		}
+		f, ok := fields[name]
+		if !ok {
+			return fmt.Errorf("%s is mapped to unknown field %s", src, name)
+		}
+		if err := setField(f, s); err != nil {
+			return fmt.Errorf("%s: %w", src, err)
+		}
+	}
+	return nil
+}
+
+func (r *Record) setID(idField string) error {
+	if idField == "" {
+		r.ID = strconv.Itoa(r.Line)
+		return nil
+	}
+	r.ID = strings.TrimSpace(r.Fields[idField])
+	if r.ID == "" {
+		return fmt.Errorf("line %d: %s is empty", r.Line, idField)
+	}
+	return nil
+}
+
+// jsonString returns raw as a string. false is returned for null.
+func jsonString(raw json.RawMessage) (string, bool, error) {
+	var v any
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return "", false, err
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return "", false, nil
+	case string:
+		return v, true, nil
+	case json.Number:
+		return v.String(), true, nil
+	case bool:
+		return strconv.FormatBool(v), true, nil
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, e := range v {
+			b, err := json.Marshal(e)
+			if err != nil {
+				return "", false, err
+			}
+			s, _, err := jsonString(b)
+			if err != nil {
+				return "", false, err
+			}
+			parts = append(parts, s)
+		}
+		return strings.Join(parts, ","), true, nil
+	}
+	return string(raw), true, nil
+}
+
+// urlFields adds the settable fields of v to fields keyed by their `url` tag name
+func urlFields(v reflect.Value, fields map[string]reflect.Value) {
+	t := v.Type()
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		tag := sf.Tag.Get("url")
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct && tag == "" {
+			urlFields(v.Field(i), fields)
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name = strings.TrimSpace(name); name != "" && name != "-" {
+			fields[name] = v.Field(i)
+		}
+	}
+}
+
+func setField(v reflect.Value, s string) error {
+	if v.Kind() != reflect.Slice {
+		return setValue(v, s)
+	}
+
+	parts := strings.Split(s, ",")
+	slice := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+	for i, p := range parts {
+		if err := setValue(slice.Index(i), strings.TrimSpace(p)); err != nil {
+			return err
+		}
+	}
+	v.Set(slice)
+	return nil
+}
+
+func setValue(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetUint(i)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,316 @@
+/*
+Package cassette records HTTP traffic between Invgo and an Invgate instance and replays it.
+
+A Recorder is an http.RoundTripper that is set as the Transport of the Invgate config.
+In ModeRecord requests are sent to the instance and each request and response is saved
+to the cassette file with secrets, tokens and configured fields redacted.
+In ModeReplay the recorded responses are served back without making any requests.
+
+	rec, err := cassette.New("testdata/cassettes/incidents.json", cassette.ModeAuto)
+	if err != nil {
+		return err
+	}
+	defer rec.Save()
+
+	client, err := invgo.New(&invgo.Invgate{
+		BaseURL:   "https://staging.example.com",
+		Transport: rec,
+		...
+	})
+
+In tests ForTest can be used instead. It replays the cassette unless INVGO_CASSETTE=record is set.
+*/
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Mode defines if a Recorder records or replays interactions
+type Mode int
+
+const (
+	// ModeAuto replays the cassette if it exists and records it otherwise
+	ModeAuto Mode = iota
+	// ModeRecord sends requests to the instance and records them
+	ModeRecord
+	// ModeReplay serves recorded responses and never sends requests
+	ModeReplay
+)
+
+// EnvMode is the environment variable read by ForTest. If it is set to "record" cassettes are recorded.
+const EnvMode = "INVGO_CASSETTE"
+
+// ErrNoInteraction is returned in replay mode when no recorded interaction matches a request
+var ErrNoInteraction = errors.New("no recorded interaction matches the request")
+
+type (
+	// Cassette is the file format used to store interactions
+	Cassette struct {
+		Interactions []Interaction `json:"interactions"`
+	}
+
+	// Interaction is a recorded request and its response
+	Interaction struct {
+		Request  Request  `json:"request"`
+		Response Response `json:"response"`
+	}
+
+	// Request is a recorded request
+	Request struct {
+		Method string `json:"method"`
+		// URL is the path and query of the request. The host is not recorded so a cassette
+		// can be replayed against any base URL.
+		URL     string      `json:"url"`
+		Headers http.Header `json:"headers,omitempty"`
+		Body    string      `json:"body,omitempty"`
+	}
+
+	// Response is a recorded response
+	Response struct {
+		Status  int         `json:"status"`
+		Headers http.Header `json:"headers,omitempty"`
+		Body    string      `json:"body,omitempty"`
+	}
+
+	// MatcherFunc reports if a recorded request matches a request being replayed.
+	// The request being replayed has already been redacted.
+	MatcherFunc func(recorded, req Request) bool
+
+	// Recorder records and replays interactions. Create it with New or ForTest.
+	Recorder struct {
+		// Base is used to send requests when recording. If nil http.DefaultTransport is used.
+		Base http.RoundTripper
+		// Redactor removes secrets from recorded interactions. It defaults to DefaultRedactor.
+		Redactor *Redactor
+		// Match is used to find the recorded interaction for a request. It defaults to DefaultMatcher.
+		Match MatcherFunc
+
+		path     string
+		mode     Mode
+		mu       sync.Mutex
+		cassette Cassette
+		used     []bool
+	}
+)
+
+// New creates a Recorder for the cassette at path.
+// In ModeReplay, or ModeAuto if the file exists, the cassette is loaded from path.
+func New(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode}
+
+	if mode == ModeAuto {
+		r.mode = ModeRecord
+		if _, err := os.Stat(path); err == nil {
+			r.mode = ModeReplay
+		}
+	}
+
+	if r.mode == ModeReplay {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read cassette: %w", err)
+		}
+		if err := json.Unmarshal(b, &r.cassette); err != nil {
+			return nil, fmt.Errorf("unable to parse cassette %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// ForTest creates a Recorder for testdata/cassettes/<name>.json.
+// The cassette is replayed unless the INVGO_CASSETTE environment variable is set to "record".
+// Recorded cassettes are saved when the test finishes.
+func ForTest(t testing.TB, name string) *Recorder {
+	t.Helper()
+
+	mode := ModeReplay
+	if os.Getenv(EnvMode) == "record" {
+		mode = ModeRecord
+	}
+
+	r, err := New(filepath.Join("testdata", "cassettes", name+".json"), mode)
+	if err != nil {
+		t.Fatalf("unable to load cassette: %s", err)
+	}
+	t.Cleanup(func() {
+		if err := r.Save(); err != nil {
+			t.Errorf("unable to save cassette: %s", err)
+		}
+	})
+	return r
+}
+
+// Mode returns the mode the Recorder is running in. ModeAuto is resolved when the Recorder is created.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Interactions returns the interactions recorded or loaded so far
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction{}, r.cassette.Interactions...)
+}
+
+// RoundTrip records or replays req depending on the mode of the Recorder
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == ModeReplay {
+		return r.replay(req)
+	}
+	return r.record(req)
+}
+
+// Save writes the recorded interactions to the cassette file.
+// It does nothing when replaying.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(b, '\n'), 0o644)
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	base := r.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	redactor := r.redactor()
+	i := Interaction{
+		Request: redactor.request(Request{
+			Method:  req.Method,
+			URL:     req.URL.RequestURI(),
+			Headers: req.Header.Clone(),
+			Body:    reqBody,
+		}),
+		Response: redactor.response(Response{
+			Status:  resp.StatusCode,
+			Headers: resp.Header.Clone(),
+			Body:    respBody,
+		}),
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, i)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// replay returns the first unused recorded interaction matching req
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	redacted := r.redactor().request(Request{
+		Method:  req.Method,
+		URL:     req.URL.RequestURI(),
+		Headers: req.Header.Clone(),
+		Body:    body,
+	})
+
+	match := r.Match
+	if match == nil {
+		match = DefaultMatcher
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for idx, i := range r.cassette.Interactions {
+		if r.used[idx] || !match(i.Request, redacted) {
+			continue
+		}
+		r.used[idx] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", i.Response.Status, http.StatusText(i.Response.Status)),
+			StatusCode:    i.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        i.Response.Headers.Clone(),
+			Body:          io.NopCloser(strings.NewReader(i.Response.Body)),
+			ContentLength: int64(len(i.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, redacted.URL)
+}
+
+func (r *Recorder) redactor() *Redactor {
+	if r.Redactor == nil {
+		return DefaultRedactor()
+	}
+	return r.Redactor
+}
+
+// DefaultMatcher matches requests with the same method, path, query and body.
+// Query parameters are compared regardless of their order and the host is ignored.
+func DefaultMatcher(recorded, req Request) bool {
+	if recorded.Method != req.Method || recorded.Body != req.Body {
+		return false
+	}
+	ru, err := url.Parse(recorded.URL)
+	if err != nil {
+		return false
+	}
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return false
+	}
+	return ru.Path == u.Path && ru.Query().Encode() == u.Query().Encode()
+}
+
+// readBody reads and replaces body so it can be read again
+func readBody(body *io.ReadCloser) (string, error) {
+	if *body == nil || *body == http.NoBody {
+		return "", nil
+	}
+	b, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return "", err
+	}
+	*body = io.NopCloser(bytes.NewReader(b))
+	return string(b), nil
+}
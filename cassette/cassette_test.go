@@ -0,0 +1,105 @@
+package cassette_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/cassette"
+	"github.com/tmstorm/invgo/endpoints"
+	"github.com/tmstorm/invgo/invgotest"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+func TestRecordReplay(t *testing.T) {
+	a := assert.New(t)
+	path := filepath.Join(t.TempDir(), "cassettes", "incident.json")
+
+	srv := invgotest.NewServer(t)
+	srv.ClientSecret = "superSecret"
+	id := srv.AddIncident(endpoints.Incident{Title: "Printer on fire"})
+
+	rec, err := cassette.New(path, cassette.ModeAuto)
+	a.NoError(err)
+	a.Equal(cassette.ModeRecord, rec.Mode())
+
+	cfg := srv.Config(scopes.IncidentGet)
+	cfg.Transport = rec
+	c, err := invgo.New(cfg)
+	a.NoError(err)
+
+	incs, err := c.Incident().Get(endpoints.IncidentGetParams{ID: id})
+	a.NoError(err)
+	a.Len(incs, 1)
+	a.NoError(rec.Save())
+
+	// the token request and the incident request are recorded without secrets
+	a.Len(rec.Interactions(), 2)
+	b, err := os.ReadFile(path)
+	a.NoError(err)
+	a.NotContains(string(b), "superSecret")
+	a.NotContains(string(b), "invgotest-1")
+	a.Contains(string(b), "Printer on fire")
+	a.NotContains(string(b), srv.URL)
+
+	srv.Close()
+
+	rec, err = cassette.New(path, cassette.ModeAuto)
+	a.NoError(err)
+	a.Equal(cassette.ModeReplay, rec.Mode())
+
+	// the cassette is replayed against another base URL
+	cfg.Transport = rec
+	cfg.BaseURL = "https://invgate.example.com"
+	cfg.TokenURL = cfg.BaseURL + invgotest.TokenPath
+	c, err = invgo.New(cfg)
+	a.NoError(err)
+
+	replayed, err := c.Incident().Get(endpoints.IncidentGetParams{ID: id})
+	a.NoError(err)
+	a.Equal(incs, replayed)
+
+	// each interaction is only replayed once
+	_, err = c.Incident().Get(endpoints.IncidentGetParams{ID: id})
+	a.ErrorIs(err, cassette.ErrNoInteraction)
+}
+
+func TestRedactor(t *testing.T) {
+	a := assert.New(t)
+	path := filepath.Join(t.TempDir(), "users.json")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc")
+		w.Write([]byte(`[{"id":1700000000001,"email":"jane@example.com","profile":{"phone":"555-0100"}}]`))
+	}))
+	defer server.Close()
+
+	rec, err := cassette.New(path, cassette.ModeRecord)
+	a.NoError(err)
+	rec.Redactor = cassette.DefaultRedactor()
+	rec.Redactor.Fields = append(rec.Redactor.Fields, "email", "phone")
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/users?password=hunter2&ids[0]=1", nil)
+	a.NoError(err)
+	req.Header.Set("Authorization", "Bearer token")
+
+	resp, err := rec.RoundTrip(req)
+	a.NoError(err)
+	resp.Body.Close()
+
+	i := rec.Interactions()[0]
+	a.Equal(cassette.Redacted, i.Request.Headers.Get("Authorization"))
+	a.Equal(cassette.Redacted, i.Response.Headers.Get("Set-Cookie"))
+	a.Contains(i.Request.URL, "password="+cassette.Redacted)
+	a.JSONEq(`[{"id":1700000000001,"email":"REDACTED","profile":{"phone":"REDACTED"}}]`, i.Response.Body)
+}
+
+func TestReplayMissingCassette(t *testing.T) {
+	_, err := cassette.New(filepath.Join(t.TempDir(), "missing.json"), cassette.ModeReplay)
+	assert.Error(t, err)
+}
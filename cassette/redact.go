@@ -0,0 +1,122 @@
+package cassette
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Redacted replaces every redacted value
+const Redacted = "REDACTED"
+
+// Redactor removes secrets from recorded interactions.
+// Names are matched case-insensitively.
+type Redactor struct {
+	// Headers are request and response headers to redact
+	Headers []string
+	// Params are query and form parameters to redact
+	Params []string
+	// Fields are JSON object keys to redact at any depth of a request or response body
+	Fields []string
+}
+
+// DefaultRedactor returns a Redactor for the credentials and tokens used by Invgate.
+// Fields can be appended to redact other values e.g. personal data.
+func DefaultRedactor() *Redactor {
+	return &Redactor{
+		Headers: []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"},
+		Params:  []string{"client_id", "client_secret", "access_token", "password", "token"},
+		Fields:  []string{"access_token", "refresh_token", "id_token", "client_secret", "password", "token"},
+	}
+}
+
+func (r *Redactor) request(req Request) Request {
+	req.Headers = r.headers(req.Headers)
+	if u, err := url.Parse(req.URL); err == nil {
+		u.RawQuery = r.params(u.Query()).Encode()
+		req.URL = u.String()
+	}
+	req.Body = r.body(req.Headers, req.Body)
+	return req
+}
+
+func (r *Redactor) response(resp Response) Response {
+	resp.Headers = r.headers(resp.Headers)
+	resp.Body = r.body(resp.Headers, resp.Body)
+	return resp
+}
+
+func (r *Redactor) headers(h http.Header) http.Header {
+	for _, name := range r.Headers {
+		if _, ok := h[http.CanonicalHeaderKey(name)]; ok {
+			h.Set(name, Redacted)
+		}
+	}
+	return h
+}
+
+func (r *Redactor) params(q url.Values) url.Values {
+	for key := range q {
+		if contains(r.Params, key) {
+			q[key] = []string{Redacted}
+		}
+	}
+	return q
+}
+
+// body redacts form encoded and JSON bodies. Other bodies are returned unchanged.
+func (r *Redactor) body(h http.Header, body string) string {
+	if body == "" {
+		return body
+	}
+
+	if strings.HasPrefix(h.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		q, err := url.ParseQuery(body)
+		if err != nil {
+			return body
+		}
+		return r.params(q).Encode()
+	}
+
+	// numbers are kept as json.Number so large IDs are not reformatted
+	var v any
+	dec := json.NewDecoder(strings.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return body
+	}
+	b, err := json.Marshal(r.fields(v))
+	if err != nil {
+		return body
+	}
+	return string(b)
+}
+
+// fields redacts the configured keys of every object in v
+func (r *Redactor) fields(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, child := range val {
+			if contains(r.Fields, k) {
+				val[k] = Redacted
+			} else {
+				val[k] = r.fields(child)
+			}
+		}
+	case []any:
+		for i, child := range val {
+			val[i] = r.fields(child)
+		}
+	}
+	return v
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,322 @@
+package invgo
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tmstorm/invgo/endpoints"
+)
+
+// DefaultCatalogTTL is used by NewCatalog when a ttl of 0 is provided
+var DefaultCatalogTTL = 15 * time.Minute
+
+// ErrCatalogNotFound is returned when an ID or name can not be resolved by a Catalog
+var ErrCatalogNotFound = errors.New("not found in catalog")
+
+type (
+	// Catalog loads and caches the incident attributes, categories and help desks for an
+	// Invgate instance so IDs returned by the API can be resolved to their names and back.
+	//
+	// The cache is refreshed the first time it is used and again once its TTL has expired.
+	// All tables are loaded concurrently on refresh.
+	//
+	// Requires scopes: IncidentAttributesStatusGet, IncidentAttributesPriorityGet,
+	// IncidentAttributesTypeGet, IncidentAttributesSourceGet, CategoriesGet, HelpDesksGet
+	Catalog struct {
+		client *Client
+		ttl    time.Duration
+
+		// refreshMu ensures only one refresh runs at a time when the TTL expires
+		refreshMu sync.Mutex
+		mu        sync.RWMutex
+		// tables are keyed by the kind of attribute they hold e.g. status or help desk
+		tables map[string]*lookup
+	}
+
+	// lookup holds a single attribute table indexed in both directions
+	lookup struct {
+		byID   map[int]string
+		byName map[string]int
+		// loadedAt is zero until the table has loaded successfully
+		loadedAt time.Time
+	}
+)
+
+// catalogKinds are the tables loaded by a Catalog
+var catalogKinds = []string{"status", "priority", "type", "source", "category", "help desk"}
+
+// NewCatalog creates a Catalog for the provided client. Nothing is loaded until the
+// Catalog is first used or Refresh is called.
+// If ttl is 0 DefaultCatalogTTL is used.
+func NewCatalog(client *Client, ttl time.Duration) *Catalog {
+	if ttl <= 0 {
+		ttl = DefaultCatalogTTL
+	}
+	tables := make(map[string]*lookup, len(catalogKinds))
+	for _, kind := range catalogKinds {
+		tables[kind] = &lookup{}
+	}
+	return &Catalog{
+		client: client,
+		ttl:    ttl,
+		tables: tables,
+	}
+}
+
+// Refresh reloads every table in the Catalog from Invgate regardless of the TTL.
+// Tables that load successfully are stored even if another table fails. A table that
+// fails to load is loaded again the next time it is used.
+func (c *Catalog) Refresh() error {
+	return joinCatalogErrors(catalogKinds, c.refresh(catalogKinds...))
+}
+
+// refresh loads the tables of kinds concurrently and returns the error of each table that failed
+func (c *Catalog) refresh(kinds ...string) map[string]error {
+	type result struct {
+		kind  string
+		table lookup
+		err   error
+	}
+
+	results := make(chan result, len(kinds))
+	var wg sync.WaitGroup
+	for _, kind := range kinds {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			t, err := c.load(kind)
+			results <- result{kind: kind, table: t, err: err}
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	errs := map[string]error{}
+	for r := range results {
+		if r.err != nil {
+			errs[r.kind] = fmt.Errorf("unable to load %s catalog: %w", r.kind, r.err)
+			continue
+		}
+		r.table.loadedAt = time.Now()
+		*c.tables[r.kind] = r.table
+	}
+	return errs
+}
+
+// joinCatalogErrors joins the errors of failed in the order of kinds
+func joinCatalogErrors(kinds []string, failed map[string]error) error {
+	var errs []error
+	for _, kind := range kinds {
+		if err, ok := failed[kind]; ok {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *Catalog) load(kind string) (lookup, error) {
+	switch kind {
+	case "status":
+		return loadAttributes(c.client.IncidentAttributesStatus())
+	case "priority":
+		return loadAttributes(c.client.IncidentAttributesPriority())
+	case "type":
+		return loadAttributes(c.client.IncidentAttributesType())
+	case "source":
+		return loadAttributes(c.client.IncidentAttributesSource())
+	case "category":
+		return c.loadCategories()
+	case "help desk":
+		return c.loadHelpDesks()
+	}
+	return lookup{}, fmt.Errorf("unknown catalog %s", kind)
+}
+
+// StatusName returns the name of the incident status with the given ID
+func (c *Catalog) StatusName(id int) (string, error) {
+	return c.name("status", id)
+}
+
+// StatusID returns the ID of the incident status with the given name
+func (c *Catalog) StatusID(name string) (int, error) {
+	return c.id("status", name)
+}
+
+// PriorityName returns the name of the incident priority with the given ID
+func (c *Catalog) PriorityName(id int) (string, error) {
+	return c.name("priority", id)
+}
+
+// PriorityID returns the ID of the incident priority with the given name
+func (c *Catalog) PriorityID(name string) (int, error) {
+	return c.id("priority", name)
+}
+
+// TypeName returns the name of the incident type with the given ID
+func (c *Catalog) TypeName(id int) (string, error) {
+	return c.name("type", id)
+}
+
+// TypeID returns the ID of the incident type with the given name
+func (c *Catalog) TypeID(name string) (int, error) {
+	return c.id("type", name)
+}
+
+// SourceName returns the name of the incident source with the given ID
+func (c *Catalog) SourceName(id int) (string, error) {
+	return c.name("source", id)
+}
+
+// SourceID returns the ID of the incident source with the given name
+func (c *Catalog) SourceID(name string) (int, error) {
+	return c.id("source", name)
+}
+
+// CategoryName returns the name of the category with the given ID
+func (c *Catalog) CategoryName(id int) (string, error) {
+	return c.name("category", id)
+}
+
+// CategoryID returns the ID of the category with the given name
+func (c *Catalog) CategoryID(name string) (int, error) {
+	return c.id("category", name)
+}
+
+// HelpDeskName returns the name of the help desk with the given ID
+func (c *Catalog) HelpDeskName(id int) (string, error) {
+	return c.name("help desk", id)
+}
+
+// HelpDeskID returns the ID of the help desk with the given name
+func (c *Catalog) HelpDeskID(name string) (int, error) {
+	return c.id("help desk", name)
+}
+
+// name resolves id in the table of kind refreshing the table first if it has expired
+func (c *Catalog) name(kind string, id int) (string, error) {
+	if err := c.refreshIfExpired(kind); err != nil {
+		return "", err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	n, ok := c.tables[kind].byID[id]
+	if !ok {
+		return "", fmt.Errorf("%s id %d %w", kind, id, ErrCatalogNotFound)
+	}
+	return n, nil
+}
+
+// id resolves name in the table of kind refreshing the table first if it has expired.
+// Names are matched case insensitively.
+func (c *Catalog) id(kind string, name string) (int, error) {
+	if err := c.refreshIfExpired(kind); err != nil {
+		return 0, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	id, ok := c.tables[kind].byName[normalizeName(name)]
+	if !ok {
+		return 0, fmt.Errorf("%s %q %w", kind, name, ErrCatalogNotFound)
+	}
+	return id, nil
+}
+
+// refreshIfExpired loads the tables of kinds that have never loaded or whose TTL has expired
+func (c *Catalog) refreshIfExpired(kinds ...string) error {
+	if len(c.expired(kinds)) == 0 {
+		return nil
+	}
+
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	// another caller may have refreshed while this one was waiting
+	expired := c.expired(kinds)
+	if len(expired) == 0 {
+		return nil
+	}
+	return joinCatalogErrors(expired, c.refresh(expired...))
+}
+
+// expired returns the kinds whose table has never loaded or whose TTL has expired
+func (c *Catalog) expired(kinds []string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var expired []string
+	for _, kind := range kinds {
+		loadedAt := c.tables[kind].loadedAt
+		if loadedAt.IsZero() || time.Since(loadedAt) > c.ttl {
+			expired = append(expired, kind)
+		}
+	}
+	return expired
+}
+
+func (c *Catalog) loadCategories() (lookup, error) {
+	cats, err := c.client.Categories().Get(endpoints.CategoriesGetParams{})
+	if err != nil {
+		return lookup{}, err
+	}
+
+	t := newLookup()
+	for _, cat := range cats {
+		// NOTE: Invgate returns category IDs as strings
+		id, err := strconv.Atoi(cat.ID)
+		if err != nil {
+			return lookup{}, fmt.Errorf("invalid category id %q: %w", cat.ID, err)
+		}
+		t.add(id, cat.Name)
+	}
+	return t, nil
+}
+
+func (c *Catalog) loadHelpDesks() (lookup, error) {
+	desks, err := c.client.HelpDesks().Get(endpoints.HelpDeskGetParams{})
+	if err != nil {
+		return lookup{}, err
+	}
+
+	t := newLookup()
+	for _, d := range desks {
+		t.add(d.ID, d.Name)
+	}
+	return t, nil
+}
+
+func loadAttributes(m *endpoints.AttributesMethods) (lookup, error) {
+	attrs, err := m.Get(endpoints.AttributesGetParams{})
+	if err != nil {
+		return lookup{}, err
+	}
+
+	t := newLookup()
+	for _, a := range attrs {
+		t.add(a.ID, a.Name)
+	}
+	return t, nil
+}
+
+func newLookup() lookup {
+	return lookup{
+		byID:   map[int]string{},
+		byName: map[string]int{},
+	}
+}
+
+func (l lookup) add(id int, name string) {
+	l.byID[id] = name
+	l.byName[normalizeName(name)] = id
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -0,0 +1,104 @@
+package invgo_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/endpoints"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+var catalogScopes = []scopes.ScopeType{
+	scopes.IncidentAttributesStatusGet,
+	scopes.IncidentAttributesPriorityGet,
+	scopes.IncidentAttributesTypeGet,
+	scopes.IncidentAttributesSourceGet,
+	scopes.CategoriesGet,
+	scopes.HelpDesksGet,
+}
+
+var catalogRoutes = map[string]any{
+	"/incident.attributes.status":   []endpoints.AttributesResponse{{ID: 1, Name: "New"}, {ID: 5, Name: "Solved"}},
+	"/incident.attributes.priority": []endpoints.AttributesResponse{{ID: 3, Name: "High"}},
+	"/incident.attributes.type":     []endpoints.AttributesResponse{{ID: 2, Name: "Incident"}},
+	"/incident.attributes.source":   []endpoints.AttributesResponse{{ID: 4, Name: "Email"}},
+	"/categories":                   []endpoints.CategoriesGetResponse{{ID: "10", Name: "Hardware"}},
+	"/helpdesks":                    []endpoints.HelpDesksGetResponse{{ID: 7, Name: "Service Desk"}},
+}
+
+func TestCatalog(t *testing.T) {
+	a := assert.New(t)
+
+	server := newRoutedServer(t, catalogRoutes)
+	defer server.Close()
+
+	cat := invgo.NewCatalog(newTestClient(t, server, catalogScopes...), 0)
+
+	name, err := cat.StatusName(5)
+	a.NoError(err)
+	a.Equal("Solved", name)
+
+	id, err := cat.PriorityID("high")
+	a.NoError(err)
+	a.Equal(3, id)
+
+	name, err = cat.TypeName(2)
+	a.NoError(err)
+	a.Equal("Incident", name)
+
+	id, err = cat.SourceID("Email")
+	a.NoError(err)
+	a.Equal(4, id)
+
+	name, err = cat.CategoryName(10)
+	a.NoError(err)
+	a.Equal("Hardware", name)
+
+	id, err = cat.HelpDeskID("Service Desk")
+	a.NoError(err)
+	a.Equal(7, id)
+
+	_, err = cat.StatusName(99)
+	a.ErrorIs(err, invgo.ErrCatalogNotFound)
+}
+
+func TestCatalogRefreshFailed(t *testing.T) {
+	a := assert.New(t)
+
+	var categoriesDown atomic.Bool
+	categoriesDown.Store(true)
+	routes := newRoutedServer(t, catalogRoutes)
+	defer routes.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/categories" && categoriesDown.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		routes.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	cat := invgo.NewCatalog(newTestClient(t, server, catalogScopes...), 0)
+
+	err := cat.Refresh()
+	a.ErrorContains(err, "category")
+
+	// tables that loaded are still usable
+	name, err := cat.StatusName(1)
+	a.NoError(err)
+	a.Equal("New", name)
+
+	// the failed table is loaded again instead of being cached until the TTL expires
+	_, err = cat.CategoryName(10)
+	a.Error(err)
+	a.NotErrorIs(err, invgo.ErrCatalogNotFound)
+
+	categoriesDown.Store(false)
+	name, err = cat.CategoryName(10)
+	a.NoError(err)
+	a.Equal("Hardware", name)
+}
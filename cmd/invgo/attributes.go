@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/endpoints"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+// attributeCommand returns a command listing the values of an attribute endpoint
+func attributeCommand(name, summary string, scope scopes.ScopeType, methods func(api invgo.API) endpoints.AttributesAPI) command {
+	return command{
+		Name:    name,
+		Summary: summary,
+		Scopes:  []scopes.ScopeType{scope},
+		Columns: []string{"id", "name", "parent_id"},
+		Setup: func(fs *flag.FlagSet) func(api invgo.API) (any, error) {
+			var p endpoints.AttributesGetParams
+			fs.IntVar(&p.ID, "id", 0, "only return the attribute with this ID")
+			return func(api invgo.API) (any, error) {
+				return methods(api).Get(p)
+			}
+		},
+	}
+}
+
+var attributesGroup = group{
+	Name:    "attributes",
+	Summary: "List incident and breaking news attributes",
+	Commands: []command{
+		attributeCommand("incident-status", "List incident statuses", scopes.IncidentAttributesStatusGet,
+			func(api invgo.API) endpoints.AttributesAPI { return api.IncidentAttributesStatus() }),
+		attributeCommand("incident-priority", "List incident priorities", scopes.IncidentAttributesPriorityGet,
+			func(api invgo.API) endpoints.AttributesAPI { return api.IncidentAttributesPriority() }),
+		attributeCommand("incident-type", "List incident types", scopes.IncidentAttributesTypeGet,
+			func(api invgo.API) endpoints.AttributesAPI { return api.IncidentAttributesType() }),
+		attributeCommand("incident-source", "List incident sources", scopes.IncidentAttributesSourceGet,
+			func(api invgo.API) endpoints.AttributesAPI { return api.IncidentAttributesSource() }),
+		attributeCommand("news-status", "List breaking news statuses", scopes.BreakingNewsAttributesStatus,
+			func(api invgo.API) endpoints.AttributesAPI { return api.BreakingNewsAttributesStatus() }),
+		attributeCommand("news-type", "List breaking news types", scopes.BreakingNewsAttributesType,
+			func(api invgo.API) endpoints.AttributesAPI { return api.BreakingNewsAttributesType() }),
+	},
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"html/template"
+
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/endpoints"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+var breakingNewsGroup = group{
+	Name:    "news",
+	Summary: "List, post and update breaking news",
+	Commands: []command{
+		{
+			Name:    "list",
+			Summary: "List all breaking news",
+			Scopes:  []scopes.ScopeType{scopes.BreakingNewsAll},
+			Columns: []string{"id", "title", "type_id", "status_id", "created_at"},
+			Setup: func(fs *flag.FlagSet) func(api invgo.API) (any, error) {
+				return func(api invgo.API) (any, error) {
+					return api.BreakingNewsAll().Get()
+				}
+			},
+		},
+		{
+			Name:    "post",
+			Summary: "Post breaking news",
+			Scopes:  []scopes.ScopeType{scopes.BreakingNewsPost},
+			Setup: func(fs *flag.FlagSet) func(api invgo.API) (any, error) {
+				var p endpoints.BreakingNewsPostParams
+				fs.StringVar(&p.Title, "title", "", "title (required)")
+				body := fs.String("body", "", "body, may contain HTML (required)")
+				fs.IntVar(&p.TypeID, "type", 0, "type ID (required)")
+				fs.IntVar(&p.CreatorID, "creator", 0, "creator user ID")
+				return func(api invgo.API) (any, error) {
+					if err := required(fs, "title", "body", "type"); err != nil {
+						return nil, err
+					}
+					p.Body = template.HTML(*body)
+					return api.BreakingNews().Post(p)
+				}
+			},
+		},
+		{
+			Name:    "status",
+			Summary: "Add a status update to breaking news",
+			Scopes:  []scopes.ScopeType{scopes.BreakingNewsStatusPost},
+			Setup: func(fs *flag.FlagSet) func(api invgo.API) (any, error) {
+				var p endpoints.BreakingNewsStatusPostParams
+				fs.IntVar(&p.ID, "id", 0, "breaking news ID (required)")
+				fs.StringVar(&p.Body, "body", "", "update (required)")
+				fs.IntVar(&p.CreatorID, "creator", 0, "creator user ID")
+				fs.BoolVar(&p.IsSolutions, "solution", false, "mark the breaking news as solved")
+				return func(api invgo.API) (any, error) {
+					if err := required(fs, "id", "body"); err != nil {
+						return nil, err
+					}
+					return api.BreakingNewsStatus().Post(p)
+				}
+			},
+		},
+	},
+}
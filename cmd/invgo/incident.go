@@ -0,0 +1,193 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/endpoints"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+var incidentColumns = []string{"id", "title", "status_id", "priority_id", "user_id", "assigned_group_id", "assigned_id", "created_at"}
+
+// waitFor maps the -on values of incident wait-for to their scopes
+var waitFor = map[string]scopes.ScopeType{
+	"agent":    scopes.IncidentWaitingForAgentPost,
+	"customer": scopes.IncidentWaitingForCustomerPost,
+	"date":     scopes.IncidentWaitingForDatePost,
+	"external": scopes.IncidentWaitingForExternalEntityPost,
+	"incident": scopes.IncidentWaitingForIncidentPost,
+}
+
+var incidentGroup = group{
+	Name:    "incident",
+	Summary: "Get, create and update incidents",
+	Commands: []command{
+		{
+			Name:    "get",
+			Summary: "Get an incident",
+			Scopes:  []scopes.ScopeType{scopes.IncidentGet},
+			Columns: incidentColumns,
+			Setup: func(fs *flag.FlagSet) func(api invgo.API) (any, error) {
+				id := fs.Int("id", 0, "incident ID (required)")
+				comments := fs.Bool("comments", false, "include comments, use with -o json")
+				return func(api invgo.API) (any, error) {
+					if err := required(fs, "id"); err != nil {
+						return nil, err
+					}
+					return api.Incident().Get(endpoints.IncidentGetParams{ID: *id, Comments: *comments})
+				}
+			},
+		},
+		{
+			Name:    "create",
+			Summary: "Create an incident",
+			Scopes:  []scopes.ScopeType{scopes.IncidentPost},
+			Setup: func(fs *flag.FlagSet) func(api invgo.API) (any, error) {
+				var p endpoints.IncidentPostParams
+				fs.StringVar(&p.Title, "title", "", "title (required)")
+				fs.IntVar(&p.TypeID, "type", 0, "type ID (required)")
+				fs.IntVar(&p.PriorityID, "priority", 0, "priority ID (required)")
+				fs.IntVar(&p.CustomerID, "customer", 0, "customer user ID (required)")
+				fs.IntVar(&p.CreatorID, "creator", 0, "creator user ID, defaults to the customer")
+				fs.IntVar(&p.CategoryID, "category", 0, "category ID")
+				fs.IntVar(&p.SourceID, "source", 0, "source ID")
+				fs.StringVar(&p.Description, "description", "", "description")
+				return func(api invgo.API) (any, error) {
+					if err := required(fs, "title", "type", "priority", "customer"); err != nil {
+						return nil, err
+					}
+					if p.CreatorID == 0 {
+						p.CreatorID = p.CustomerID
+					}
+					return api.Incident().Post(p)
+				}
+			},
+		},
+		{
+			Name:    "comment",
+			Summary: "Add a comment to an incident",
+			Scopes:  []scopes.ScopeType{scopes.IncidentCommentPost},
+			Setup: func(fs *flag.FlagSet) func(api invgo.API) (any, error) {
+				var p endpoints.IncidentCommentPostParams
+				fs.IntVar(&p.RequestID, "id", 0, "incident ID (required)")
+				fs.IntVar(&p.AuthorID, "author", 0, "author user ID (required)")
+				fs.StringVar(&p.Comment, "message", "", "comment (required)")
+				fs.BoolVar(&p.IsSolution, "solution", false, "mark the comment as the solution")
+				internal := fs.Bool("internal", false, "hide the comment from the customer")
+				return func(api invgo.API) (any, error) {
+					if err := required(fs, "id", "author", "message"); err != nil {
+						return nil, err
+					}
+					p.CustomerVisible = !*internal
+					return api.IncidentComment().Post(p)
+				}
+			},
+		},
+		{
+			Name:    "reassign",
+			Summary: "Reassign an incident to a group or agent",
+			Scopes:  []scopes.ScopeType{scopes.IncidentReassignPost},
+			Setup: func(fs *flag.FlagSet) func(api invgo.API) (any, error) {
+				var p endpoints.IncidentReassignPostParams
+				fs.IntVar(&p.RequestID, "id", 0, "incident ID (required)")
+				fs.IntVar(&p.AuthorID, "author", 0, "author user ID (required)")
+				fs.IntVar(&p.GroupID, "group", 0, "help desk ID (required)")
+				fs.IntVar(&p.AgentID, "agent", 0, "agent user ID")
+				return func(api invgo.API) (any, error) {
+					if err := required(fs, "id", "author", "group"); err != nil {
+						return nil, err
+					}
+					return api.IncidentReassign().Post(p)
+				}
+			},
+		},
+		{
+			Name:    "cancel",
+			Summary: "Cancel an incident",
+			Scopes:  []scopes.ScopeType{scopes.IncidentCancelPost},
+			Setup: func(fs *flag.FlagSet) func(api invgo.API) (any, error) {
+				var p endpoints.IncidentCancelPostParams
+				fs.IntVar(&p.RequestID, "id", 0, "incident ID (required)")
+				fs.IntVar(&p.AuthorID, "author", 0, "author user ID (required)")
+				fs.StringVar(&p.Comment, "message", "", "reason for cancelling")
+				return func(api invgo.API) (any, error) {
+					if err := required(fs, "id", "author"); err != nil {
+						return nil, err
+					}
+					return api.IncidentCancel().Post(p)
+				}
+			},
+		},
+		{
+			Name:    "reopen",
+			Summary: "Reopen an incident",
+			Scopes:  []scopes.ScopeType{scopes.IncidentReopenPut},
+			Setup: func(fs *flag.FlagSet) func(api invgo.API) (any, error) {
+				var p endpoints.IncidentReopenPutParams
+				fs.IntVar(&p.RequestID, "id", 0, "incident ID (required)")
+				fs.IntVar(&p.AuthorID, "author", 0, "author user ID")
+				return func(api invgo.API) (any, error) {
+					if err := required(fs, "id"); err != nil {
+						return nil, err
+					}
+					return api.IncidentReopen().Put(p)
+				}
+			},
+		},
+		{
+			Name:    "wait-for",
+			Summary: "Set an incident as waiting for an agent, customer, date, external entity or incident",
+			FlagScopes: func(fs *flag.FlagSet) ([]scopes.ScopeType, error) {
+				on := fs.Lookup("on").Value.String()
+				scp, ok := waitFor[on]
+				if !ok {
+					return nil, fmt.Errorf("-on must be one of agent, customer, date, external or incident, got %q", on)
+				}
+				return []scopes.ScopeType{scp}, nil
+			},
+			Setup: func(fs *flag.FlagSet) func(api invgo.API) (any, error) {
+				id := fs.Int("id", 0, "incident ID (required)")
+				on := fs.String("on", "", "agent, customer, date, external or incident (required)")
+				until := fs.String("until", "", "RFC 3339 date to wait until, used with -on date")
+				entity := fs.Int("entity", 0, "external entity link ID, used with -on external")
+				return func(api invgo.API) (any, error) {
+					if err := required(fs, "id", "on"); err != nil {
+						return nil, err
+					}
+					switch *on {
+					case "agent":
+						return api.IncidentWaitingForAgent().Post(endpoints.IncidentWaitingForAgentPostParams{RequestID: *id})
+					case "customer":
+						return api.IncidentWaitingForCustomer().Post(endpoints.IncidentWaitingForCustomerPostParams{RequestID: *id})
+					case "date":
+						if err := required(fs, "until"); err != nil {
+							return nil, err
+						}
+						t, err := time.Parse(time.RFC3339, *until)
+						if err != nil {
+							return nil, fmt.Errorf("invalid -until: %w", err)
+						}
+						return api.IncidentWaitingForDate().Post(endpoints.IncidentWaitingForDatePostParams{
+							RequestID: *id,
+							Timestamp: strconv.FormatInt(t.Unix(), 10),
+						})
+					case "external":
+						if err := required(fs, "entity"); err != nil {
+							return nil, err
+						}
+						return api.IncidentWaitingForExternalEntity().Post(endpoints.IncidentWaitingForExternalEntityPostParams{
+							RequestID:    *id,
+							EntityLinkID: *entity,
+						})
+					default:
+						return api.IncidentWaitingForIncident().Post(endpoints.IncidentWaitingForIncidentPostParams{RequestID: *id})
+					}
+				}
+			},
+		},
+	},
+}
@@ -0,0 +1,221 @@
+/*
+Command invgo calls the Invgate API from the command line.
+
+Usage:
+
+	invgo [-config file] [-o table|json|csv] <group> <command> [flags]
+
+Config is loaded from $XDG_CONFIG_HOME/invgo/config.yaml if it exists, then the file given
+with -config or INVGO_CONFIG and finally the INVGO_ environment variables read by invgo.FromEnv.
+Only the scopes required by the command are requested.
+
+Run invgo help to list every command.
+*/
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+type (
+	// command is a subcommand of a group e.g. incident get
+	command struct {
+		Name    string
+		Summary string
+		// Scopes are requested when creating the client
+		Scopes []scopes.ScopeType
+		// FlagScopes is used instead of Scopes when the scopes depend on the parsed flags
+		FlagScopes func(fs *flag.FlagSet) ([]scopes.ScopeType, error)
+		// Columns are shown by the table and csv output. If empty every field is shown.
+		Columns []string
+		// Setup registers the command flags and returns the function that runs the command
+		Setup func(fs *flag.FlagSet) func(api invgo.API) (any, error)
+	}
+
+	// group is a set of commands for a resource e.g. incident
+	group struct {
+		Name     string
+		Summary  string
+		Commands []command
+	}
+
+	// app runs commands. Its fields are replaced in tests.
+	app struct {
+		stdout io.Writer
+		stderr io.Writer
+		// newAPI creates the API used by commands from the loaded config
+		newAPI func(cfg *invgo.Invgate) (invgo.API, error)
+	}
+)
+
+// errUsage is returned when the usage has already been printed
+var errUsage = errors.New("usage")
+
+var groups = []group{
+	incidentGroup,
+	userGroup,
+	breakingNewsGroup,
+	timeTrackingGroup,
+	attributesGroup,
+}
+
+func main() {
+	a := &app{
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		newAPI: func(cfg *invgo.Invgate) (invgo.API, error) {
+			c, err := invgo.New(cfg)
+			if err != nil {
+				return nil, err
+			}
+			return c.API(), nil
+		},
+	}
+	if err := a.run(os.Args[1:]); err != nil {
+		if !errors.Is(err, errUsage) {
+			fmt.Fprintf(a.stderr, "invgo: %s\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// run parses args and runs the command
+func (a *app) run(args []string) error {
+	global := flag.NewFlagSet("invgo", flag.ContinueOnError)
+	global.SetOutput(a.stderr)
+	configPath := global.String("config", os.Getenv(invgo.EnvPrefix+"CONFIG"), "config file (JSON or YAML)")
+	output := global.String("o", "table", "output format: table, json or csv")
+	global.Usage = a.usage
+	if err := global.Parse(args); err != nil {
+		return errUsage
+	}
+
+	args = global.Args()
+	if len(args) == 0 || args[0] == "help" {
+		a.usage()
+		return errUsage
+	}
+
+	g, ok := findGroup(args[0])
+	if !ok {
+		a.usage()
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+	if len(args) < 2 {
+		a.groupUsage(g)
+		return errUsage
+	}
+	cmd, ok := g.find(args[1])
+	if !ok {
+		a.groupUsage(g)
+		return fmt.Errorf("unknown %s command %q", g.Name, args[1])
+	}
+
+	fs := flag.NewFlagSet("invgo "+g.Name+" "+cmd.Name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.StringVar(configPath, "config", *configPath, "config file (JSON or YAML)")
+	fs.StringVar(output, "o", *output, "output format: table, json or csv")
+	exec := cmd.Setup(fs)
+	if err := fs.Parse(args[2:]); err != nil {
+		return errUsage
+	}
+
+	w, err := newWriter(*output)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		return err
+	}
+	cfg.Scopes = cmd.Scopes
+	if cmd.FlagScopes != nil {
+		if cfg.Scopes, err = cmd.FlagScopes(fs); err != nil {
+			return err
+		}
+	}
+
+	api, err := a.newAPI(cfg)
+	if err != nil {
+		return err
+	}
+	result, err := exec(api)
+	if err != nil {
+		return err
+	}
+	return w(a.stdout, cmd.Columns, result)
+}
+
+// loadConfig loads the default config file if it exists, then path and the environment
+func loadConfig(path string) (*invgo.Invgate, error) {
+	var sources []invgo.ConfigSource
+	if dir, err := os.UserConfigDir(); err == nil {
+		def := filepath.Join(dir, "invgo", "config.yaml")
+		if _, err := os.Stat(def); err == nil {
+			sources = append(sources, invgo.FromFile(def))
+		}
+	}
+	if path != "" {
+		sources = append(sources, invgo.FromFile(path))
+	}
+	sources = append(sources, invgo.FromEnv())
+	return invgo.LoadConfig(sources...)
+}
+
+func findGroup(name string) (group, bool) {
+	for _, g := range groups {
+		if g.Name == name {
+			return g, true
+		}
+	}
+	return group{}, false
+}
+
+func (g group) find(name string) (command, bool) {
+	for _, c := range g.Commands {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+func (a *app) usage() {
+	fmt.Fprintln(a.stderr, "Usage: invgo [-config file] [-o table|json|csv] <group> <command> [flags]")
+	fmt.Fprintln(a.stderr, "\nGroups:")
+	for _, g := range groups {
+		fmt.Fprintf(a.stderr, "  %-14s %s\n", g.Name, g.Summary)
+	}
+	fmt.Fprintln(a.stderr, "\nRun invgo <group> to list its commands and invgo <group> <command> -h for its flags.")
+}
+
+func (a *app) groupUsage(g group) {
+	fmt.Fprintf(a.stderr, "Usage: invgo %s <command> [flags]\n\nCommands:\n", g.Name)
+	cmds := append([]command{}, g.Commands...)
+	sort.Slice(cmds, func(i, j int) bool { return cmds[i].Name < cmds[j].Name })
+	for _, c := range cmds {
+		fmt.Fprintf(a.stderr, "  %-16s %s\n", c.Name, c.Summary)
+	}
+}
+
+// required returns an error naming the first flag in names that was not set
+func required(fs *flag.FlagSet, names ...string) error {
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	for _, n := range names {
+		if !set[n] {
+			return fmt.Errorf("-%s is required", n)
+		}
+	}
+	return nil
+}
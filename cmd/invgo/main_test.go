@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/endpoints"
+	"github.com/tmstorm/invgo/invgomock"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+// newTestApp returns an app using api and the config it was created with
+func newTestApp(t *testing.T, api *invgomock.API) (*app, *bytes.Buffer, *invgo.Invgate) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("INVGO_CONFIG", "")
+
+	var out bytes.Buffer
+	cfg := &invgo.Invgate{}
+	a := &app{
+		stdout: &out,
+		stderr: &bytes.Buffer{},
+		newAPI: func(c *invgo.Invgate) (invgo.API, error) {
+			*cfg = *c
+			return api, nil
+		},
+	}
+	return a, &out, cfg
+}
+
+func TestRunIncidentGet(t *testing.T) {
+	a := assert.New(t)
+
+	api := invgomock.New()
+	api.IncidentMock.GetFunc = func(p endpoints.IncidentGetParams) ([]endpoints.Incident, error) {
+		return []endpoints.Incident{{ID: p.ID, Title: "Printer on fire", StatusID: 1}}, nil
+	}
+
+	app, out, cfg := newTestApp(t, api)
+	a.NoError(app.run([]string{"incident", "get", "-id", "7"}))
+	a.Equal([]scopes.ScopeType{scopes.IncidentGet}, cfg.Scopes)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	a.Len(lines, 2)
+	a.True(strings.HasPrefix(lines[0], "ID"))
+	a.Contains(lines[1], "Printer on fire")
+
+	out.Reset()
+	a.NoError(app.run([]string{"-o", "csv", "incident", "get", "-id", "7"}))
+	a.Equal("id,title,status_id,priority_id,user_id,assigned_group_id,assigned_id,created_at\n"+
+		"7,Printer on fire,1,,,,,\n", out.String())
+
+	out.Reset()
+	a.NoError(app.run([]string{"incident", "get", "-id", "7", "-o", "json"}))
+	a.Contains(out.String(), `"title": "Printer on fire"`)
+}
+
+func TestRunRequiredFlags(t *testing.T) {
+	a := assert.New(t)
+
+	api := invgomock.New()
+	app, _, _ := newTestApp(t, api)
+	a.EqualError(app.run([]string{"incident", "create", "-title", "Printer on fire"}), "-type is required")
+	a.EqualError(app.run([]string{"user", "find"}), "-email or -username is required")
+	a.EqualError(app.run([]string{"incident", "wait-for", "-id", "1", "-on", "nobody"}),
+		`-on must be one of agent, customer, date, external or incident, got "nobody"`)
+	a.EqualError(app.run([]string{"-o", "xml", "user", "get", "-id", "1"}), `unknown output format "xml"`)
+	a.ErrorIs(app.run([]string{"incident"}), errUsage)
+	a.EqualError(app.run([]string{"nothing"}), `unknown command "nothing"`)
+	a.Empty(api.IncidentMock.Calls())
+}
+
+func TestRunWaitFor(t *testing.T) {
+	a := assert.New(t)
+
+	api := invgomock.New()
+	var got endpoints.IncidentWaitingForDatePostParams
+	api.IncidentWaitingForDateMock.PostFunc = func(p endpoints.IncidentWaitingForDatePostParams) (endpoints.IncidentWaitingForDatePostResponse, error) {
+		got = p
+		return endpoints.IncidentWaitingForDatePostResponse{Status: "OK"}, nil
+	}
+
+	app, out, cfg := newTestApp(t, api)
+	a.NoError(app.run([]string{"-o", "json", "incident", "wait-for", "-id", "3", "-on", "date", "-until", "2025-01-02T00:00:00Z"}))
+	a.Equal([]scopes.ScopeType{scopes.IncidentWaitingForDatePost}, cfg.Scopes)
+	a.Equal(endpoints.IncidentWaitingForDatePostParams{RequestID: 3, Timestamp: "1735776000"}, got)
+	a.Contains(out.String(), `"status": "OK"`)
+}
+
+func TestRunAttributes(t *testing.T) {
+	a := assert.New(t)
+
+	api := invgomock.New()
+	api.IncidentAttributesPriorityMock.GetFunc = func(p endpoints.AttributesGetParams) ([]endpoints.AttributesResponse, error) {
+		return []endpoints.AttributesResponse{{ID: 1, Name: "Low"}, {ID: 2, Name: "High"}}, nil
+	}
+
+	app, out, cfg := newTestApp(t, api)
+	a.NoError(app.run([]string{"-o", "csv", "attributes", "incident-priority"}))
+	a.Equal([]scopes.ScopeType{scopes.IncidentAttributesPriorityGet}, cfg.Scopes)
+	a.Equal("id,name,parent_id\n1,Low,\n2,High,\n", out.String())
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// writer writes the result of a command
+type writer func(w io.Writer, columns []string, v any) error
+
+func newWriter(format string) (writer, error) {
+	switch format {
+	case "table":
+		return writeTable, nil
+	case "json":
+		return writeJSON, nil
+	case "csv":
+		return writeCSV, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+func writeJSON(w io.Writer, _ []string, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func writeTable(w io.Writer, columns []string, v any) error {
+	header, rows, err := toRows(columns, v)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.ToUpper(strings.Join(header, "\t")))
+	for _, r := range rows {
+		fmt.Fprintln(tw, strings.Join(r, "\t"))
+	}
+	return tw.Flush()
+}
+
+func writeCSV(w io.Writer, columns []string, v any) error {
+	header, rows, err := toRows(columns, v)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// toRows converts v to rows using its JSON fields. A slice is one row per element and
+// anything else is a single row. If columns is empty every field found is used.
+func toRows(columns []string, v any) ([]string, [][]string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var decoded any
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&decoded); err != nil {
+		return nil, nil, err
+	}
+
+	var records []map[string]any
+	items, ok := decoded.([]any)
+	if !ok {
+		items = []any{decoded}
+	}
+	for _, item := range items {
+		if m, ok := item.(map[string]any); ok {
+			records = append(records, m)
+		} else {
+			records = append(records, map[string]any{"value": item})
+		}
+	}
+
+	if len(columns) == 0 {
+		seen := map[string]bool{}
+		for _, r := range records {
+			for k := range r {
+				if !seen[k] {
+					seen[k] = true
+					columns = append(columns, k)
+				}
+			}
+		}
+		sort.Strings(columns)
+	}
+
+	rows := make([][]string, 0, len(records))
+	for _, r := range records {
+		row := make([]string, len(columns))
+		for i, c := range columns {
+			row[i] = cell(r[c])
+		}
+		rows = append(rows, row)
+	}
+	return columns, rows, nil
+}
+
+// cell formats a field value. Nested values are written as JSON.
+func cell(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	case bool:
+		return fmt.Sprint(val)
+	default:
+		b, _ := json.Marshal(val)
+		return string(b)
+	}
+}
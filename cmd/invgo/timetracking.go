@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/endpoints"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+var timeTrackingGroup = group{
+	Name:    "time",
+	Summary: "List, log and delete time tracking",
+	Commands: []command{
+		{
+			Name:    "list",
+			Summary: "List time logged on an incident or in a period",
+			Scopes:  []scopes.ScopeType{scopes.TimeTrackingGet},
+			Columns: []string{"timetracking_id", "incident", "user_id", "from", "to", "total", "comment"},
+			Setup: func(fs *flag.FlagSet) func(api invgo.API) (any, error) {
+				var p endpoints.TimeTrackingGetParams
+				fs.IntVar(&p.RequestID, "incident", 0, "incident ID")
+				fs.StringVar(&p.From, "from", "", "start of the period in ISO 8601")
+				fs.StringVar(&p.To, "to", "", "end of the period in ISO 8601, defaults to now")
+				return func(api invgo.API) (any, error) {
+					if p.RequestID == 0 && p.From == "" {
+						return nil, errors.New("-incident or -from is required")
+					}
+					return api.TimeTracking().Get(p)
+				}
+			},
+		},
+		{
+			Name:    "log",
+			Summary: "Log time on an incident",
+			Scopes:  []scopes.ScopeType{scopes.TimeTrackingPost},
+			Setup: func(fs *flag.FlagSet) func(api invgo.API) (any, error) {
+				var p endpoints.TimeTrackingPostParams
+				fs.IntVar(&p.RequestID, "incident", 0, "incident ID (required)")
+				fs.IntVar(&p.UserID, "user", 0, "user ID (required)")
+				duration := fs.Duration("duration", 0, "time spent e.g. 1h30m (required)")
+				fs.IntVar(&p.CategoryID, "category", 0, "time tracking category ID")
+				fs.StringVar(&p.Comment, "comment", "", "comment")
+				return func(api invgo.API) (any, error) {
+					if err := required(fs, "incident", "user", "duration"); err != nil {
+						return nil, err
+					}
+					if *duration <= 0 {
+						return nil, fmt.Errorf("-duration must be positive, got %s", *duration)
+					}
+					// the time is logged as ending now
+					now := time.Now()
+					p.To = int(now.Unix())
+					p.From = int(now.Add(-*duration).Unix())
+					return api.TimeTracking().Post(p)
+				}
+			},
+		},
+		{
+			Name:    "delete",
+			Summary: "Delete logged time",
+			Scopes:  []scopes.ScopeType{scopes.TimeTrackingDelete},
+			Setup: func(fs *flag.FlagSet) func(api invgo.API) (any, error) {
+				var p endpoints.TimeTrackingDeleteParams
+				fs.IntVar(&p.TimetrackingID, "id", 0, "time tracking ID (required)")
+				fs.IntVar(&p.RequestID, "incident", 0, "incident ID (required)")
+				fs.IntVar(&p.UserID, "user", 0, "user ID (required)")
+				return func(api invgo.API) (any, error) {
+					if err := required(fs, "id", "incident", "user"); err != nil {
+						return nil, err
+					}
+					return api.TimeTracking().Delete(p)
+				}
+			},
+		},
+	},
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"errors"
+	"flag"
+
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/endpoints"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+var userColumns = []string{"id", "username", "email", "name", "lastname", "is_disabled"}
+
+var userGroup = group{
+	Name:    "user",
+	Summary: "Find, enable and disable users",
+	Commands: []command{
+		{
+			Name:    "get",
+			Summary: "Get a user",
+			Scopes:  []scopes.ScopeType{scopes.UserGet},
+			Columns: userColumns,
+			Setup: func(fs *flag.FlagSet) func(api invgo.API) (any, error) {
+				var p endpoints.UserGetParams
+				fs.IntVar(&p.ID, "id", 0, "user ID (required)")
+				fs.BoolVar(&p.IncludeDisabled, "include-disabled", false, "return the user if it is disabled")
+				return func(api invgo.API) (any, error) {
+					if err := required(fs, "id"); err != nil {
+						return nil, err
+					}
+					return api.User().Get(p)
+				}
+			},
+		},
+		{
+			Name:    "find",
+			Summary: "Find a user by email or username",
+			Scopes:  []scopes.ScopeType{scopes.UserByGet},
+			Columns: userColumns,
+			Setup: func(fs *flag.FlagSet) func(api invgo.API) (any, error) {
+				var p endpoints.UserByGetParams
+				fs.StringVar(&p.Email, "email", "", "email")
+				fs.StringVar(&p.Username, "username", "", "username")
+				return func(api invgo.API) (any, error) {
+					if p.Email == "" && p.Username == "" {
+						return nil, errors.New("-email or -username is required")
+					}
+					return api.UserBy().Get(p)
+				}
+			},
+		},
+		{
+			Name:    "enable",
+			Summary: "Enable a user",
+			Scopes:  []scopes.ScopeType{scopes.UserEnablePut},
+			Setup: func(fs *flag.FlagSet) func(api invgo.API) (any, error) {
+				var p endpoints.UserEnablePutParams
+				fs.IntVar(&p.ID, "id", 0, "user ID (required)")
+				return func(api invgo.API) (any, error) {
+					if err := required(fs, "id"); err != nil {
+						return nil, err
+					}
+					return api.UserEnable().Put(p)
+				}
+			},
+		},
+		{
+			Name:    "disable",
+			Summary: "Disable a user",
+			Scopes:  []scopes.ScopeType{scopes.UserDisablePut},
+			Setup: func(fs *flag.FlagSet) func(api invgo.API) (any, error) {
+				var p endpoints.UserDisablePutParams
+				fs.IntVar(&p.ID, "id", 0, "user ID (required)")
+				return func(api invgo.API) (any, error) {
+					if err := required(fs, "id"); err != nil {
+						return nil, err
+					}
+					return api.UserDisable().Put(p)
+				}
+			},
+		},
+		{
+			Name:    "reset-password",
+			Summary: "Send a user a password reset email",
+			Scopes:  []scopes.ScopeType{scopes.UserPasswordResetPost},
+			Setup: func(fs *flag.FlagSet) func(api invgo.API) (any, error) {
+				id := fs.Int("id", 0, "user ID (required)")
+				newUser := fs.Bool("new-user", false, "send the new user email instead")
+				return func(api invgo.API) (any, error) {
+					if err := required(fs, "id"); err != nil {
+						return nil, err
+					}
+					p := endpoints.UserPasswordResetPostParams{ID: *id, Type: "RESET_PASSWORD"}
+					if *newUser {
+						p.Type = "NEW_USER"
+					}
+					return api.UserPasswordReset().Post(p)
+				}
+			},
+		},
+	},
+}
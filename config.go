@@ -0,0 +1,299 @@
+package invgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/tmstorm/invgo/scopes"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix is the prefix of the environment variables read by FromEnv
+var EnvPrefix = "INVGO_"
+
+type (
+	// ConfigSource is a single layer of configuration used by LoadConfig.
+	// Load should only set the fields of cfg that the source defines so earlier layers are kept.
+	ConfigSource interface {
+		Load(cfg *Invgate) error
+	}
+
+	// ConfigSourceFunc allows a function to be used as a ConfigSource
+	ConfigSourceFunc func(cfg *Invgate) error
+
+	// fileConfig is the format of a config file and the values read from the environment.
+	// Bools are pointers so a layer can explicitly set them to false.
+	fileConfig struct {
+		BaseURL             string   `json:"base_url" yaml:"base_url"`
+		TokenURL            string   `json:"token_url" yaml:"token_url"`
+		ClientID            string   `json:"client_id" yaml:"client_id"`
+		ClientSecret        string   `json:"client_secret" yaml:"client_secret"`
+		ClientSecretFile    string   `json:"client_secret_file" yaml:"client_secret_file"`
+		ClientSecretEnv     string   `json:"client_secret_env" yaml:"client_secret_env"`
+		ClientSecretCommand []string `json:"client_secret_command" yaml:"client_secret_command"`
+		AllowHTTP           *bool    `json:"allow_http" yaml:"allow_http"`
+		Scopes              []string `json:"scopes" yaml:"scopes"`
+		ValidateScopes      *bool    `json:"validate_scopes" yaml:"validate_scopes"`
+		StrictScopes        *bool    `json:"strict_scopes" yaml:"strict_scopes"`
+		DryRun              *bool    `json:"dry_run" yaml:"dry_run"`
+	}
+)
+
+// Load calls f(cfg)
+func (f ConfigSourceFunc) Load(cfg *Invgate) error { return f(cfg) }
+
+/*
+LoadConfig builds an Invgate config by applying each source in order.
+Later sources override the values set by earlier ones, so defaults should be listed first.
+If no sources are provided FromEnv is used.
+
+Example:
+
+	cfg, err := invgo.LoadConfig(
+		invgo.FromFile("invgo.yaml"),
+		invgo.FromEnv(),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	client, err := invgo.New(cfg)
+*/
+func LoadConfig(sources ...ConfigSource) (*Invgate, error) {
+	if len(sources) == 0 {
+		sources = []ConfigSource{FromEnv()}
+	}
+
+	cfg := &Invgate{}
+	for _, s := range sources {
+		if err := s.Load(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+/*
+FromFile loads a JSON or YAML config file. The format is chosen by the file extension
+and JSON is used if it is not .yaml or .yml.
+
+Scopes may be listed as scopes, bundles or patterns, see scopes.Resolve.
+Unknown keys are an error.
+A relative client_secret_file is resolved from the directory of the config file.
+
+Example:
+
+	base_url: https://invgate-instance.com
+	token_url: https://invgate-instance.com/oauth/token
+	client_id: client_id
+	client_secret_file: /run/secrets/invgate
+	scopes:
+	  - incident.read
+	  - api.v1.incident:post
+*/
+func FromFile(path string) ConfigSource {
+	return ConfigSourceFunc(func(cfg *Invgate) error {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("unable to read config file: %w", err)
+		}
+
+		// unknown keys are rejected so a misspelled option is not silently ignored
+		var fc fileConfig
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml":
+			dec := yaml.NewDecoder(bytes.NewReader(b))
+			dec.KnownFields(true)
+			if err = dec.Decode(&fc); errors.Is(err, io.EOF) {
+				err = nil
+			}
+		default:
+			dec := json.NewDecoder(bytes.NewReader(b))
+			dec.DisallowUnknownFields()
+			err = dec.Decode(&fc)
+		}
+		if err != nil {
+			return fmt.Errorf("unable to parse config file %s: %w", path, err)
+		}
+
+		if fc.ClientSecretFile != "" && !filepath.IsAbs(fc.ClientSecretFile) {
+			fc.ClientSecretFile = filepath.Join(filepath.Dir(path), fc.ClientSecretFile)
+		}
+
+		if err := fc.apply(cfg); err != nil {
+			return fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+/*
+FromEnv loads the config from environment variables prefixed with EnvPrefix.
+
+	INVGO_BASE_URL
+	INVGO_TOKEN_URL
+	INVGO_CLIENT_ID
+	INVGO_CLIENT_SECRET
+	INVGO_CLIENT_SECRET_FILE: path of a file containing the client secret
+	INVGO_CLIENT_SECRET_ENV: name of another environment variable containing the client secret
+	INVGO_CLIENT_SECRET_COMMAND: command printing the client secret. It is split on whitespace and
+		quoting is not supported, use a JSON array such as ["pass", "show", "invgate secret"]
+		for arguments containing spaces.
+	INVGO_ALLOW_HTTP
+	INVGO_SCOPES: comma or space separated scopes, bundles or patterns, see scopes.Resolve
+	INVGO_VALIDATE_SCOPES
+	INVGO_STRICT_SCOPES
+	INVGO_DRY_RUN
+
+Unset or empty variables are ignored.
+*/
+func FromEnv() ConfigSource {
+	return ConfigSourceFunc(func(cfg *Invgate) error {
+		env := func(name string) string {
+			return strings.TrimSpace(os.Getenv(EnvPrefix + name))
+		}
+
+		var errs []error
+		envBool := func(name string) *bool {
+			v := env(name)
+			if v == "" {
+				return nil
+			}
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s%s: %w", EnvPrefix, name, err))
+				return nil
+			}
+			return &b
+		}
+
+		fc := fileConfig{
+			BaseURL:          env("BASE_URL"),
+			TokenURL:         env("TOKEN_URL"),
+			ClientID:         env("CLIENT_ID"),
+			ClientSecret:     env("CLIENT_SECRET"),
+			ClientSecretFile: env("CLIENT_SECRET_FILE"),
+			ClientSecretEnv:  env("CLIENT_SECRET_ENV"),
+			AllowHTTP:        envBool("ALLOW_HTTP"),
+			Scopes: strings.FieldsFunc(env("SCOPES"), func(r rune) bool {
+				return r == ',' || r == ' ' || r == '\n' || r == '\t'
+			}),
+			ValidateScopes: envBool("VALIDATE_SCOPES"),
+			StrictScopes:   envBool("STRICT_SCOPES"),
+			DryRun:         envBool("DRY_RUN"),
+		}
+		if cmd := env("CLIENT_SECRET_COMMAND"); strings.HasPrefix(cmd, "[") {
+			if err := json.Unmarshal([]byte(cmd), &fc.ClientSecretCommand); err != nil {
+				errs = append(errs, fmt.Errorf("%sCLIENT_SECRET_COMMAND: %w", EnvPrefix, err))
+			}
+		} else {
+			fc.ClientSecretCommand = strings.Fields(cmd)
+		}
+		if err := errors.Join(errs...); err != nil {
+			return err
+		}
+
+		if err := fc.apply(cfg); err != nil {
+			return fmt.Errorf("invalid environment config: %w", err)
+		}
+		return nil
+	})
+}
+
+// FromValues overrides the config with every non empty field in v
+func FromValues(v Invgate) ConfigSource {
+	return ConfigSourceFunc(func(cfg *Invgate) error {
+		setString(&cfg.BaseURL, v.BaseURL)
+		setString(&cfg.TokenURL, v.TokenURL)
+		setString(&cfg.ClientID, v.ClientID)
+		if v.ClientSecret != "" || v.ClientSecretSource != nil {
+			cfg.ClientSecret = v.ClientSecret
+			cfg.ClientSecretSource = v.ClientSecretSource
+		}
+		if v.AllowHTTP {
+			cfg.AllowHTTP = true
+		}
+		if len(v.Scopes) > 0 {
+			cfg.Scopes = v.Scopes
+		}
+		if v.ValidateScopes {
+			cfg.ValidateScopes = true
+		}
+		if v.StrictScopes {
+			cfg.StrictScopes = true
+		}
+		if v.DryRun {
+			cfg.DryRun = true
+		}
+		return nil
+	})
+}
+
+// apply sets every field defined in fc on cfg
+func (fc fileConfig) apply(cfg *Invgate) error {
+	setString(&cfg.BaseURL, fc.BaseURL)
+	setString(&cfg.TokenURL, fc.TokenURL)
+	setString(&cfg.ClientID, fc.ClientID)
+
+	var src SecretSource
+	set := 0
+	if fc.ClientSecretFile != "" {
+		src = FileSecret(fc.ClientSecretFile)
+		set++
+	}
+	if fc.ClientSecretEnv != "" {
+		src = EnvSecret(fc.ClientSecretEnv)
+		set++
+	}
+	if len(fc.ClientSecretCommand) > 0 {
+		src = CommandSecret(fc.ClientSecretCommand)
+		set++
+	}
+	if fc.ClientSecret != "" {
+		set++
+	}
+	switch {
+	case set > 1:
+		return errors.New("only one of client_secret, client_secret_file, client_secret_env or client_secret_command can be set")
+	case fc.ClientSecret != "":
+		cfg.ClientSecret = fc.ClientSecret
+		cfg.ClientSecretSource = nil
+	case src != nil:
+		cfg.ClientSecret = ""
+		cfg.ClientSecretSource = src
+	}
+
+	if fc.AllowHTTP != nil {
+		cfg.AllowHTTP = *fc.AllowHTTP
+	}
+	if fc.ValidateScopes != nil {
+		cfg.ValidateScopes = *fc.ValidateScopes
+	}
+	if fc.StrictScopes != nil {
+		cfg.StrictScopes = *fc.StrictScopes
+	}
+	if fc.DryRun != nil {
+		cfg.DryRun = *fc.DryRun
+	}
+
+	if len(fc.Scopes) > 0 {
+		scps, err := scopes.Resolve(fc.Scopes...)
+		if err != nil {
+			return err
+		}
+		cfg.Scopes = scps
+	}
+	return nil
+}
+
+func setString(dst *string, v string) {
+	if v != "" {
+		*dst = v
+	}
+}
@@ -0,0 +1,167 @@
+package invgo_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+func TestLoadConfigLayers(t *testing.T) {
+	a := assert.New(t)
+	dir := t.TempDir()
+
+	a.NoError(os.WriteFile(filepath.Join(dir, "secret"), []byte("fileSecret\n"), 0o600))
+	a.NoError(os.WriteFile(filepath.Join(dir, "invgo.yaml"), []byte(`
+base_url: https://file.invgate-instance.com
+token_url: https://file.invgate-instance.com/oauth/token
+client_id: fileClient
+client_secret_file: secret
+allow_http: true
+scopes:
+  - incident.read
+  - api.v1.incident:post
+`), 0o600))
+	a.NoError(os.WriteFile(filepath.Join(dir, "override.json"), []byte(`{"client_id":"jsonClient","allow_http":false}`), 0o600))
+
+	t.Setenv("INVGO_BASE_URL", "https://env.invgate-instance.com")
+	t.Setenv("INVGO_VALIDATE_SCOPES", "true")
+
+	cfg, err := invgo.LoadConfig(
+		invgo.FromFile(filepath.Join(dir, "invgo.yaml")),
+		invgo.FromFile(filepath.Join(dir, "override.json")),
+		invgo.FromEnv(),
+	)
+	a.NoError(err)
+
+	a.Equal("https://env.invgate-instance.com", cfg.BaseURL)
+	a.Equal("https://file.invgate-instance.com/oauth/token", cfg.TokenURL)
+	a.Equal("jsonClient", cfg.ClientID)
+	a.False(cfg.AllowHTTP)
+	a.True(cfg.ValidateScopes)
+	a.Contains(cfg.Scopes, scopes.IncidentGet)
+	a.Contains(cfg.Scopes, scopes.IncidentPost)
+
+	a.Empty(cfg.ClientSecret)
+	a.Equal(invgo.FileSecret(filepath.Join(dir, "secret")), cfg.ClientSecretSource)
+	secret, err := cfg.ClientSecretSource.Secret()
+	a.NoError(err)
+	a.Equal("fileSecret", secret)
+
+	cfg, err = invgo.LoadConfig(
+		invgo.FromValues(invgo.Invgate{ClientID: "default", Scopes: []scopes.ScopeType{scopes.UsersGet}}),
+		invgo.FromValues(invgo.Invgate{ClientSecret: "valueSecret"}),
+	)
+	a.NoError(err)
+	a.Equal("default", cfg.ClientID)
+	a.Equal("valueSecret", cfg.ClientSecret)
+	a.Equal([]scopes.ScopeType{scopes.UsersGet}, cfg.Scopes)
+}
+
+func TestLoadConfigEnv(t *testing.T) {
+	a := assert.New(t)
+
+	t.Setenv("INVGO_CLIENT_ID", "envClient")
+	t.Setenv("INVGO_CLIENT_SECRET_ENV", "INVGO_TEST_SECRET")
+	t.Setenv("INVGO_TEST_SECRET", "envSecret")
+	t.Setenv("INVGO_SCOPES", "api.v1.incident:get, users*:get")
+	t.Setenv("INVGO_DRY_RUN", "true")
+
+	cfg, err := invgo.LoadConfig()
+	a.NoError(err)
+	a.Equal("envClient", cfg.ClientID)
+	a.True(cfg.DryRun)
+	a.Contains(cfg.Scopes, scopes.IncidentGet)
+	a.Contains(cfg.Scopes, scopes.UsersGet)
+
+	secret, err := cfg.ClientSecretSource.Secret()
+	a.NoError(err)
+	a.Equal("envSecret", secret)
+
+	t.Setenv("INVGO_SCOPES", "api.v1.incident:gte")
+	_, err = invgo.LoadConfig()
+	a.ErrorIs(err, scopes.ErrUnknownScope)
+
+	t.Setenv("INVGO_SCOPES", "")
+	t.Setenv("INVGO_ALLOW_HTTP", "maybe")
+	_, err = invgo.LoadConfig()
+	a.Error(err)
+
+	t.Setenv("INVGO_ALLOW_HTTP", "")
+	t.Setenv("INVGO_CLIENT_SECRET", "plain")
+	_, err = invgo.LoadConfig()
+	a.Error(err, "only one secret option can be set")
+
+	// quoted arguments are passed as a JSON array
+	t.Setenv("INVGO_CLIENT_SECRET", "")
+	t.Setenv("INVGO_CLIENT_SECRET_ENV", "")
+	t.Setenv("INVGO_CLIENT_SECRET_COMMAND", `["echo", "command secret"]`)
+	cfg, err = invgo.LoadConfig()
+	a.NoError(err)
+	a.Equal(invgo.CommandSecret{"echo", "command secret"}, cfg.ClientSecretSource)
+
+	t.Setenv("INVGO_CLIENT_SECRET_COMMAND", `["echo"`)
+	_, err = invgo.LoadConfig()
+	a.Error(err)
+}
+
+func TestFromFileUnknownKeys(t *testing.T) {
+	a := assert.New(t)
+	dir := t.TempDir()
+
+	a.NoError(os.WriteFile(filepath.Join(dir, "invgo.yaml"), []byte("client_id: yamlClient\nvalidate_scope: true\n"), 0o600))
+	a.NoError(os.WriteFile(filepath.Join(dir, "invgo.json"), []byte(`{"client_id":"jsonClient","validate_scope":true}`), 0o600))
+	a.NoError(os.WriteFile(filepath.Join(dir, "empty.yaml"), nil, 0o600))
+
+	_, err := invgo.LoadConfig(invgo.FromFile(filepath.Join(dir, "invgo.yaml")))
+	a.ErrorContains(err, "validate_scope")
+
+	_, err = invgo.LoadConfig(invgo.FromFile(filepath.Join(dir, "invgo.json")))
+	a.ErrorContains(err, "validate_scope")
+
+	_, err = invgo.LoadConfig(invgo.FromFile(filepath.Join(dir, "empty.yaml")))
+	a.NoError(err)
+}
+
+func TestSecretSources(t *testing.T) {
+	a := assert.New(t)
+
+	_, err := invgo.FileSecret(filepath.Join(t.TempDir(), "missing")).Secret()
+	a.Error(err)
+
+	_, err = invgo.EnvSecret("INVGO_TEST_UNSET_SECRET").Secret()
+	a.Error(err)
+
+	s, err := invgo.CommandSecret{"echo", "commandSecret"}.Secret()
+	a.NoError(err)
+	a.Equal("commandSecret", s)
+
+	_, err = invgo.CommandSecret{}.Secret()
+	a.Error(err)
+}
+
+func TestInvgoClientSecretSource(t *testing.T) {
+	a := assert.New(t)
+
+	server := newTokenServer(t, string(scopes.IncidentGet))
+	defer server.Close()
+
+	cfg := &invgo.Invgate{
+		BaseURL:            server.URL,
+		TokenURL:           server.URL + "/oauth/token",
+		ClientID:           "12345",
+		ClientSecretSource: invgo.EnvSecret("INVGO_TEST_UNSET_SECRET"),
+		AllowHTTP:          true,
+		Scopes:             []scopes.ScopeType{scopes.IncidentGet},
+	}
+
+	_, err := invgo.New(cfg)
+	a.Error(err)
+
+	t.Setenv("INVGO_TEST_UNSET_SECRET", "clientSecret")
+	_, err = invgo.New(cfg)
+	a.NoError(err)
+}
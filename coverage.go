@@ -1,77 +1,8 @@
 package invgo
 
-// ImplementedEndpoints when adding a new Invgate endpoint
-// this must be updated so that the coverage script knows which
-// endpoints Invgo supports calling.
-var ImplementedEndpoints = map[string][]string{
-	"/breakingnews":                        {"POST", "PUT", "GET"},
-	"/breakingnews.all":                    {"GET"},
-	"/breakingnews.attributes.status":      {"GET"},
-	"/breakingnews.attributes.type":        {"GET"},
-	"/breakingnews.status":                 {"POST", "GET"},
-	"/categories":                          {"GET"},
-	"/helpdesks":                           {"GET"},
-	"/incident":                            {"POST", "PUT", "GET"},
-	"/incident.approval":                   {"GET"},
-	"/incident.approval.accept":            {"PUT"},
-	"/incident.approval.add_voter":         {"POST"},
-	"/incident.approval.cancel":            {"PUT"},
-	"/incident.approval.possible_voters":   {"GET"},
-	"/incident.approval.reject":            {"PUT"},
-	"/incident.approval.status":            {"GET"},
-	"/incident.approval.type":              {"GET"},
-	"/incident.approval.vote_status":       {"GET"},
-	"/incident.attachment":                 {"GET"},
-	"/incident.attributes.priority":        {"GET"},
-	"/incident.attributes.source":          {"GET"},
-	"/incident.attributes.status":          {"GET"},
-	"/incident.attributes.type":            {"GET"},
-	"/incident.cancel":                     {"POST"},
-	"/incident.collaborator":               {"POST", "GET"},
-	"/incident.comment":                    {"POST", "GET"},
-	"/incident.custom_approval":            {"POST", "GET"},
-	"/incident.external_entity":            {"POST", "GET"},
-	"/incident.link":                       {"POST", "GET"},
-	"/incident.linked_cis.counters.from":   {"GET"},
-	"/incident.observer":                   {"POST", "GET"},
-	"/incident.reassign":                   {"POST"},
-	"/incident.reject":                     {"POST"},
-	"/incident.reopen":                     {"PUT"},
-	"/incident.solution.accept":            {"PUT"},
-	"/incident.solution.reject":            {"PUT"},
-	"/incident.spontaneous_approval":       {"POST"},
-	"/incident.tasks":                      {"GET"},
-	"/incident.waitingfor.agent":           {"POST"},
-	"/incident.waitingfor.customer":        {"POST"},
-	"/incident.waitingfor.date":            {"POST"},
-	"/incident.waitingfor.external_entity": {"POST"},
-	"/incident.waitingfor.incident":        {"POST"},
-	"/incidents":                           {"GET"},
-	"/incidents.by.agent":                  {"GET"},
-	"/incidents.by.cis":                    {"GET"},
-	"/incidents.by.customer":               {"GET"},
-	"/incidents.by.helpdesk":               {"GET"},
-	"/incidents.by.sentiment":              {"GET"},
-	"/incidents.by.status":                 {"GET"},
-	"/incidents.by.view":                   {"GET"},
-	"/incidents.details.by.view":           {"GET"},
-	"/incidents.last.hour":                 {"GET"},
-	"/sd.version":                          {"GET"},
-	"/timetracking":                        {"GET", "POST", "DELETE"},
-	"/timetracking.attributes.category":    {"GET"},
-	"/triggers":                            {"GET"},
-	"/triggers.executions":                 {"GET"},
-	"/user":                                {"GET", "POST", "PUT", "DELETE"},
-	"/user.by":                             {"GET"},
-	"/user.convert":                        {"POST"},
-	"/user.disable":                        {"PUT"},
-	"/user.enable":                         {"PUT"},
-	"/user.password":                       {"PUT"},
-	"/user.password.reset":                 {"POST"},
-	"/user.token":                          {"POST"},
-	"/users":                               {"GET"},
-	"/users.by":                            {"GET"},
-	"/users.groups":                        {"GET"},
-	"/wf.deploy":                           {"PUT"},
-	"/wf.initialfields.by.category":        {"GET"},
-}
+import "github.com/tmstorm/invgo/scopes"
+
+// ImplementedEndpoints lists every endpoint Invgo supports calling with its methods
+// so that the coverage script knows which endpoints have been implemented.
+// It is built from scopes.Registrations, when adding a new Invgate endpoint register it there.
+var ImplementedEndpoints = scopes.Implemented()
@@ -1,10 +1,13 @@
 package invgo
 
+//go:generate go run ./scripts/genapi
+
 /*
 All endpoints must be implemented here to be available in the Invgo public API
 
 To implement a new endpoint add its methods to invgo/endpoints/endpoint_name.go.
-Then add it as a method to the Invgo Client using newPublicMethod.
+Then add it as a method to the Invgo Client using newPublicMethod, register
+its scopes in invgo/scopes/registry.go and run go generate to update the API interfaces.
 
 Example:
 	func (c *Client) BreakingNews() *endpoints.BreakingNewsMethods {
@@ -17,7 +20,6 @@ import (
 
 	"github.com/tmstorm/invgo/endpoints"
 	"github.com/tmstorm/invgo/internal/methods"
-	"github.com/tmstorm/invgo/scopes"
 )
 
 // newPublicMethod should be used when adding a new enpoint to the Invgo public API
@@ -35,6 +37,7 @@ func newPublicMethod[T any](c *Client, endpoint string) *T {
 					HTTPClient:    c.HTTPClient,
 					CurrentScopes: c.CurrentScopes,
 					APIURL:        c.APIURL,
+					Plan:          c.Plan,
 				},
 				Endpoint: ep,
 			}
@@ -73,17 +76,13 @@ func (c *Client) BreakingNewsStatus() *endpoints.BreakingNewsStatusMethods {
 // and their descriptions.
 // See https://releases.invgate.com/service-desk/api/#breakingnewsattributesstatus
 func (c *Client) BreakingNewsAttributesStatus() *endpoints.AttributesMethods {
-	m := newPublicMethod[endpoints.AttributesMethods](c, "/breakingnews.attributes.status")
-	m.RequiredScope = scopes.BreakingNewsAttributesStatus
-	return m
+	return newPublicMethod[endpoints.AttributesMethods](c, "/breakingnews.attributes.status")
 }
 
 // BreakingNewsAttributesType gets all the importance types of the Breaking News.
 // See https://releases.invgate.com/service-desk/api/#breakingnewsattributestype
 func (c *Client) BreakingNewsAttributesType() *endpoints.AttributesMethods {
-	m := newPublicMethod[endpoints.AttributesMethods](c, "/breakingnews.attributes.type")
-	m.RequiredScope = scopes.BreakingNewsAttributesType
-	return m
+	return newPublicMethod[endpoints.AttributesMethods](c, "/breakingnews.attributes.type")
 }
 
 // Categories is used to get all categories for the current Invgate instance
@@ -92,6 +91,12 @@ func (c *Client) Categories() *endpoints.CategoriesMethods {
 	return newPublicMethod[endpoints.CategoriesMethods](c, "/categories")
 }
 
+// CustomFieldsByCategory gets the custom field definitions for the given category
+// See https://releases.invgate.com/service-desk/api/#cffieldsbycategory
+func (c *Client) CustomFieldsByCategory() *endpoints.CustomFieldsByCategoryMethods {
+	return newPublicMethod[endpoints.CustomFieldsByCategoryMethods](c, "/cf.fields.by.category")
+}
+
 // HelpDesks manages the help desks
 // See https://releases.invgate.com/service-desk/api/#helpdesks
 func (c *Client) HelpDesks() *endpoints.HelpDesksMethods {
@@ -170,33 +175,25 @@ func (c *Client) IncidentAttachment() *endpoints.IncidentAttachmentMethods {
 // IncidentAttributesPriority gets all the priority types usable for an incident or the one provided
 // See https://releases.invgate.com/service-desk/api/#incidentattributespriority
 func (c *Client) IncidentAttributesPriority() *endpoints.AttributesMethods {
-	m := newPublicMethod[endpoints.AttributesMethods](c, "/incident.attributes.priority")
-	m.RequiredScope = scopes.IncidentAttributesPriorityGet
-	return m
+	return newPublicMethod[endpoints.AttributesMethods](c, "/incident.attributes.priority")
 }
 
 // IncidentAttributesSource gets all the source types usable for an incident or the one provided
 // See https://releases.invgate.com/service-desk/api/#incidentattributesource
 func (c *Client) IncidentAttributesSource() *endpoints.AttributesMethods {
-	m := newPublicMethod[endpoints.AttributesMethods](c, "/incident.attributes.source")
-	m.RequiredScope = scopes.IncidentAttributesSourceGet
-	return m
+	return newPublicMethod[endpoints.AttributesMethods](c, "/incident.attributes.source")
 }
 
 // IncidentAttributesStatus gets all the status types usable for an incident or the one provided
 // See https://releases.invgate.com/service-desk/api/#incidentattributestype
 func (c *Client) IncidentAttributesStatus() *endpoints.AttributesMethods {
-	m := newPublicMethod[endpoints.AttributesMethods](c, "/incident.attributes.status")
-	m.RequiredScope = scopes.IncidentAttributesStatusGet
-	return m
+	return newPublicMethod[endpoints.AttributesMethods](c, "/incident.attributes.status")
 }
 
 // IncidentAttributesType gets all the types usable for an incident or the one provided
 // See https://releases.invgate.com/service-desk/api/#incidentattributestype
 func (c *Client) IncidentAttributesType() *endpoints.AttributesMethods {
-	m := newPublicMethod[endpoints.AttributesMethods](c, "/incident.attributes.type")
-	m.RequiredScope = scopes.IncidentAttributesTypeGet
-	return m
+	return newPublicMethod[endpoints.AttributesMethods](c, "/incident.attributes.type")
 }
 
 // IncidentCancel manages canceling an incident
@@ -0,0 +1,422 @@
+// Code generated by go run ./scripts/genapi; DO NOT EDIT.
+
+package endpoints
+
+// Interfaces implemented by the endpoint types returned by the invgo.Client accessors.
+// Code that depends on these instead of the concrete types can be tested with invgomock.
+type (
+	// AttributesAPI is implemented by *AttributesMethods
+	AttributesAPI interface {
+		Get(p AttributesGetParams) ([]AttributesResponse, error)
+	}
+
+	// BreakingNewsAllAPI is implemented by *BreakingNewsAllMethods
+	BreakingNewsAllAPI interface {
+		Get() ([]BreakingNewsGetResponse, error)
+	}
+
+	// BreakingNewsAPI is implemented by *BreakingNewsMethods
+	BreakingNewsAPI interface {
+		Get(p BreakingNewsGetParams) (BreakingNewsGetResponse, error)
+		Post(p BreakingNewsPostParams) (BreakingNewsInfoResponse, error)
+		Put(p BreakingNewsPutParams) (BreakingNewsInfoResponse, error)
+	}
+
+	// BreakingNewsStatusAPI is implemented by *BreakingNewsStatusMethods
+	BreakingNewsStatusAPI interface {
+		Get(p BreakingNewsStatusGetParams) ([]BreakingNewsStatusGetResponse, error)
+		Post(p BreakingNewsStatusPostParams) (BreakingNewsInfoResponse, error)
+	}
+
+	// CategoriesAPI is implemented by *CategoriesMethods
+	CategoriesAPI interface {
+		Get(p CategoriesGetParams) ([]CategoriesGetResponse, error)
+	}
+
+	// CustomFieldsByCategoryAPI is implemented by *CustomFieldsByCategoryMethods
+	CustomFieldsByCategoryAPI interface {
+		Get(p CustomFieldsByCategoryGetParams) ([]CustomFieldDefinition, error)
+	}
+
+	// HelpDesksAPI is implemented by *HelpDesksMethods
+	HelpDesksAPI interface {
+		Get(p HelpDeskGetParams) ([]HelpDesksGetResponse, error)
+	}
+
+	// IncidentApprovalAcceptAPI is implemented by *IncidentApprovalAcceptMethods
+	IncidentApprovalAcceptAPI interface {
+		Put(p IncidentApprovalAcceptPutParams) (IncidentApprovalAcceptPutResponse, error)
+	}
+
+	// IncidentApprovalAddVoterAPI is implemented by *IncidentApprovalAddVoterMethods
+	IncidentApprovalAddVoterAPI interface {
+		Post(p IncidentApprovalAddVoterPostParams) (IncidentApprovalAddVoterPostResponse, error)
+	}
+
+	// IncidentApprovalCancelAPI is implemented by *IncidentApprovalCancelMethods
+	IncidentApprovalCancelAPI interface {
+		Put(p IncidentApprovalCancelPutParams) (IncidentApprovalCancelPutResponse, error)
+	}
+
+	// IncidentApprovalAPI is implemented by *IncidentApprovalMethods
+	IncidentApprovalAPI interface {
+		Get(p IncidentApprovalGetParams) ([]IncidentApprovalGetResponse, error)
+	}
+
+	// IncidentApprovalPossibleVotersAPI is implemented by *IncidentApprovalPossibleVotersMethods
+	IncidentApprovalPossibleVotersAPI interface {
+		Get(p IncidentApprovalPossibleVotersGetParams) ([]IncidentApprovalPossibleVotersGetResponse, error)
+	}
+
+	// IncidentApprovalRejectAPI is implemented by *IncidentApprovalRejectMethods
+	IncidentApprovalRejectAPI interface {
+		Put(p IncidentApprovalRejectPutParams) (IncidentApprovalRejectPutResponse, error)
+	}
+
+	// IncidentApprovalStatusAPI is implemented by *IncidentApprovalStatusMethods
+	IncidentApprovalStatusAPI interface {
+		Get() ([]IncidentApprovalStatusGetResponse, error)
+	}
+
+	// IncidentApprovalTypeAPI is implemented by *IncidentApprovalTypeMethods
+	IncidentApprovalTypeAPI interface {
+		Get() ([]IncidentApprovalTypeGetResponse, error)
+	}
+
+	// IncidentApprovalVoteStatusAPI is implemented by *IncidentApprovalVoteStatusMethods
+	IncidentApprovalVoteStatusAPI interface {
+		Get() ([]IncidentApprovalVoteStatusGetResponse, error)
+	}
+
+	// IncidentAttachmentAPI is implemented by *IncidentAttachmentMethods
+	IncidentAttachmentAPI interface {
+		Get(p IncidentAttachmentGetParams) (IncidentAttachmentGetResponse, error)
+	}
+
+	// IncidentCancelAPI is implemented by *IncidentCancelMethods
+	IncidentCancelAPI interface {
+		Post(p IncidentCancelPostParams) (IncidentCancelPostResponse, error)
+	}
+
+	// IncidentCollaboratorAPI is implemented by *IncidentCollaboratorMethods
+	IncidentCollaboratorAPI interface {
+		Get(p IncidentCollaboratorGetParams) (IncidentCollaboratorGetResponse, error)
+		Post(p IncidentCollaboratorPostParams) (IncidentCollaboratorPostResponse, error)
+	}
+
+	// IncidentCommentAPI is implemented by *IncidentCommentMethods
+	IncidentCommentAPI interface {
+		Get(p IncidentCommentGetParams) ([]IncidentCommentGetResponse, error)
+		Post(p IncidentCommentPostParams) (IncidentCommentPostResponse, error)
+	}
+
+	// IncidentCustomApprovalAPI is implemented by *IncidentCustomApprovalMethods
+	IncidentCustomApprovalAPI interface {
+		Get(p IncidentCustomApprovalGetParams) ([]IncidentCustomApprovalGetResponse, error)
+		Post(p IncidentCustomApprovalPostParams) (IncidentCustomApprovalPostResponse, error)
+	}
+
+	// IncidentExternalEntityAPI is implemented by *IncidentExternalEntityMethods
+	IncidentExternalEntityAPI interface {
+		Get(p IncidentExternalEntityGetParams) ([]IncidentExternalEntityGetResponse, error)
+		Post(p IncidentExternalEntityPostParams) (IncidentExternalEntityPostResponse, error)
+	}
+
+	// IncidentLinkAPI is implemented by *IncidentLinkMethods
+	IncidentLinkAPI interface {
+		Get(p IncidentLinkGetParams) ([]IncidentLinkGetResponse, error)
+		Post(p IncidentLinkPostParams) (IncidentLinkPostResponse, error)
+	}
+
+	// IncidentLinkedCIsCountersFromAPI is implemented by *IncidentLinkedCIsCountersFromMethods
+	IncidentLinkedCIsCountersFromAPI interface {
+		Get(p IncidentLinkedCIsCountersFromGetParams) ([]IncidentLinkedCIsCountersFromGetResponse, error)
+	}
+
+	// IncidentAPI is implemented by *IncidentMethods
+	IncidentAPI interface {
+		Get(p IncidentGetParams) ([]Incident, error)
+		Post(p IncidentPostParams) (IncidentPostResponse, error)
+		Put(p IncidentPutParams) ([]Incident, error)
+	}
+
+	// IncidentObserverAPI is implemented by *IncidentObserverMethods
+	IncidentObserverAPI interface {
+		Get(p IncidentObserverGetParams) (IncidentObserverGetResponse, error)
+		Post(p IncidentObserverPostParams) (IncidentObserverPostResponse, error)
+	}
+
+	// IncidentReassignAPI is implemented by *IncidentReassignMethods
+	IncidentReassignAPI interface {
+		Post(p IncidentReassignPostParams) (IncidentReassignPostResponse, error)
+	}
+
+	// IncidentRejectAPI is implemented by *IncidentRejectMethods
+	IncidentRejectAPI interface {
+		Post(p IncidentRejectPostParams) (IncidentRejectPostResponse, error)
+	}
+
+	// IncidentReopenAPI is implemented by *IncidentReopenMethods
+	IncidentReopenAPI interface {
+		Put(p IncidentReopenPutParams) (IncidentReopenPutResponse, error)
+	}
+
+	// IncidentSolutionAcceptAPI is implemented by *IncidentSolutionAcceptMethods
+	IncidentSolutionAcceptAPI interface {
+		Put(p IncidentSolutionAcceptPutParams) (IncidentSolutionAcceptPutResponse, error)
+	}
+
+	// IncidentSolutionRejectAPI is implemented by *IncidentSolutionRejectMethods
+	IncidentSolutionRejectAPI interface {
+		Put(p IncidentSolutionRejectPutParams) (IncidentSolutionRejectPutResponse, error)
+	}
+
+	// IncidentSpontaneousApprovalAPI is implemented by *IncidentSpontaneousApprovalMethods
+	IncidentSpontaneousApprovalAPI interface {
+		Post(p IncidentSpontaneousApprovalPostParams) (IncidentSpontaneousApprovalPostResponse, error)
+	}
+
+	// IncidentTasksAPI is implemented by *IncidentTasksMethods
+	IncidentTasksAPI interface {
+		Get(p IncidentTasksGetParams) ([]IncidentTasksGetResponse, error)
+	}
+
+	// IncidentWaitingForAgentAPI is implemented by *IncidentWaitingForAgentMethods
+	IncidentWaitingForAgentAPI interface {
+		Post(p IncidentWaitingForAgentPostParams) (IncidentWaitingForAgentPostResponse, error)
+	}
+
+	// IncidentWaitingForCustomerAPI is implemented by *IncidentWaitingForCustomerMethods
+	IncidentWaitingForCustomerAPI interface {
+		Post(p IncidentWaitingForCustomerPostParams) (IncidentWaitingForCustomerPostResponse, error)
+	}
+
+	// IncidentWaitingForDateAPI is implemented by *IncidentWaitingForDateMethods
+	IncidentWaitingForDateAPI interface {
+		Post(p IncidentWaitingForDatePostParams) (IncidentWaitingForDatePostResponse, error)
+	}
+
+	// IncidentWaitingForExternalEntityAPI is implemented by *IncidentWaitingForExternalEntityMethods
+	IncidentWaitingForExternalEntityAPI interface {
+		Post(p IncidentWaitingForExternalEntityPostParams) (IncidentWaitingForExternalEntityPostResponse, error)
+	}
+
+	// IncidentWaitingForIncidentAPI is implemented by *IncidentWaitingForIncidentMethods
+	IncidentWaitingForIncidentAPI interface {
+		Post(p IncidentWaitingForIncidentPostParams) (IncidentWaitingForIncidentPostResponse, error)
+	}
+
+	// IncidentsByAgentAPI is implemented by *IncidentsByAgentMethods
+	IncidentsByAgentAPI interface {
+		Get(p IncidentsByAgentGetParams) (IncidentsByAgentGetResponse, error)
+	}
+
+	// IncidentsByCIsAPI is implemented by *IncidentsByCIsMethods
+	IncidentsByCIsAPI interface {
+		Get(p IncidentsByCIsGetParams) (IncidentsByCIsGetResponse, error)
+	}
+
+	// IncidentsByCustomerAPI is implemented by *IncidentsByCustomerMethods
+	IncidentsByCustomerAPI interface {
+		Get(p IncidentsByCustomerGetParams) (IncidentsByCustomerGetResponse, error)
+	}
+
+	// IncidentsByHelpDeskAPI is implemented by *IncidentsByHelpDeskMethods
+	IncidentsByHelpDeskAPI interface {
+		Get(p IncidentsByHelpDeskGetParams) (IncidentsByHelpDeskGetResponse, error)
+	}
+
+	// IncidentsBySentimentAPI is implemented by *IncidentsBySentimentMethods
+	IncidentsBySentimentAPI interface {
+		Get(p IncidentsBySentimentGetParams) (IncidentsBySentimentGetResponse, error)
+	}
+
+	// IncidentsByStatusAPI is implemented by *IncidentsByStatusMethods
+	IncidentsByStatusAPI interface {
+		Get(p IncidentsByStatusGetParams) (IncidentsByStatusGetResponse, error)
+	}
+
+	// IncidentsByViewAPI is implemented by *IncidentsByViewMethods
+	IncidentsByViewAPI interface {
+		Get(p IncidentsByViewGetParams) (IncidentsByViewGetResponse, error)
+	}
+
+	// IncidentsDetailsByViewAPI is implemented by *IncidentsDetailsByViewMethods
+	IncidentsDetailsByViewAPI interface {
+		Get(p IncidentsDetailsByViewGetParams) (IncidentsDetailsByViewGetResponse, error)
+	}
+
+	// IncidentsLastHourAPI is implemented by *IncidentsLastHourMethods
+	IncidentsLastHourAPI interface {
+		Get(p IncidentsLastHourGetParams) ([]IncidentsLastHourGetResponse, error)
+	}
+
+	// IncidentsAPI is implemented by *IncidentsMethods
+	IncidentsAPI interface {
+		Get(p IncidentsGetParams) ([]Incident, error)
+	}
+
+	// ServiceDeskVersionAPI is implemented by *ServiceDeskVersionMethods
+	ServiceDeskVersionAPI interface {
+		Get() (string, error)
+	}
+
+	// TimeTrackingAttributesCategoryAPI is implemented by *TimeTrackingAttributesCategoryMethods
+	TimeTrackingAttributesCategoryAPI interface {
+		Get(p TimeTrackingAttributesCategoryGetParams) ([]TimeTrackingAttributesCategoryGetResponse, error)
+	}
+
+	// TimeTrackingAPI is implemented by *TimeTrackingMethods
+	TimeTrackingAPI interface {
+		Delete(p TimeTrackingDeleteParams) (TimeTrackingDeleteResponse, error)
+		Get(p TimeTrackingGetParams) ([]TimeTrackingGetResponse, error)
+		Post(p TimeTrackingPostParams) (TimeTrackingPostResponse, error)
+	}
+
+	// TriggersExecutionsAPI is implemented by *TriggersExecutionsMethods
+	TriggersExecutionsAPI interface {
+		Get(p TriggersGetParams) ([]TriggersExecutionsGetResponse, error)
+	}
+
+	// TriggersAPI is implemented by *TriggersMethods
+	TriggersAPI interface {
+		Get(p TriggersGetParams) ([]TriggersGetResponse, error)
+	}
+
+	// UserByAPI is implemented by *UserByMethods
+	UserByAPI interface {
+		Get(p UserByGetParams) (UserByGetResponse, error)
+	}
+
+	// UserConvertAPI is implemented by *UserConvertMethods
+	UserConvertAPI interface {
+		Post(p UserConvertPostParams) (UserConvertPostResponse, error)
+	}
+
+	// UserDisableAPI is implemented by *UserDisableMethods
+	UserDisableAPI interface {
+		Put(p UserDisablePutParams) (UserDisablePutResponse, error)
+	}
+
+	// UserEnableAPI is implemented by *UserEnableMethods
+	UserEnableAPI interface {
+		Put(p UserEnablePutParams) (UserEnablePutResponse, error)
+	}
+
+	// UserAPI is implemented by *UserMethods
+	UserAPI interface {
+		Delete(p UserDeleteParams) ([]UserDeleteResponse, error)
+		Get(p UserGetParams) (UserGetResponse, error)
+		Post(p UserPostParams) (UserPostResponse, error)
+		Put(p UserPutParams) (UserPutResponse, error)
+	}
+
+	// UserPasswordAPI is implemented by *UserPasswordMethods
+	UserPasswordAPI interface {
+		Put(p UserPasswordPutParams) (UserPasswordPutResponse, error)
+	}
+
+	// UserPasswordResetAPI is implemented by *UserPasswordResetMethods
+	UserPasswordResetAPI interface {
+		Post(p UserPasswordResetPostParams) (UserPasswordResetPostResponse, error)
+	}
+
+	// UserTokenAPI is implemented by *UserTokenMethods
+	UserTokenAPI interface {
+		Post(p UserTokenPostParams) (UserTokenPostResponse, error)
+	}
+
+	// UsersByAPI is implemented by *UsersByMethods
+	UsersByAPI interface {
+		Get(p UsersByGetParams) (UsersByGetResponse, error)
+	}
+
+	// UsersGroupsAPI is implemented by *UsersGroupsMethods
+	UsersGroupsAPI interface {
+		Get(p UsersGroupsGetParams) ([]UsersGroupsGetResponse, error)
+	}
+
+	// UsersAPI is implemented by *UsersMethods
+	UsersAPI interface {
+		Get(p UsersGetParams) ([]UsersGetResponse, error)
+	}
+
+	// WorkflowDeployAPI is implemented by *WorkflowDeployMethods
+	WorkflowDeployAPI interface {
+		Put(p WorkflowDeployPutParams) (WorkflowDeployPutResponse, error)
+	}
+
+	// WorkflowInitialFieldsByCategoryAPI is implemented by *WorkflowInitialFieldsByCategoryMethods
+	WorkflowInitialFieldsByCategoryAPI interface {
+		Get(p WorkflowInitialFieldsByCategoryGetParams) (WorkflowInitialFieldsByCategoryGetResponse, error)
+	}
+)
+
+var (
+	_ AttributesAPI                       = (*AttributesMethods)(nil)
+	_ BreakingNewsAllAPI                  = (*BreakingNewsAllMethods)(nil)
+	_ BreakingNewsAPI                     = (*BreakingNewsMethods)(nil)
+	_ BreakingNewsStatusAPI               = (*BreakingNewsStatusMethods)(nil)
+	_ CategoriesAPI                       = (*CategoriesMethods)(nil)
+	_ CustomFieldsByCategoryAPI           = (*CustomFieldsByCategoryMethods)(nil)
+	_ HelpDesksAPI                        = (*HelpDesksMethods)(nil)
+	_ IncidentApprovalAcceptAPI           = (*IncidentApprovalAcceptMethods)(nil)
+	_ IncidentApprovalAddVoterAPI         = (*IncidentApprovalAddVoterMethods)(nil)
+	_ IncidentApprovalCancelAPI           = (*IncidentApprovalCancelMethods)(nil)
+	_ IncidentApprovalAPI                 = (*IncidentApprovalMethods)(nil)
+	_ IncidentApprovalPossibleVotersAPI   = (*IncidentApprovalPossibleVotersMethods)(nil)
+	_ IncidentApprovalRejectAPI           = (*IncidentApprovalRejectMethods)(nil)
+	_ IncidentApprovalStatusAPI           = (*IncidentApprovalStatusMethods)(nil)
+	_ IncidentApprovalTypeAPI             = (*IncidentApprovalTypeMethods)(nil)
+	_ IncidentApprovalVoteStatusAPI       = (*IncidentApprovalVoteStatusMethods)(nil)
+	_ IncidentAttachmentAPI               = (*IncidentAttachmentMethods)(nil)
+	_ IncidentCancelAPI                   = (*IncidentCancelMethods)(nil)
+	_ IncidentCollaboratorAPI             = (*IncidentCollaboratorMethods)(nil)
+	_ IncidentCommentAPI                  = (*IncidentCommentMethods)(nil)
+	_ IncidentCustomApprovalAPI           = (*IncidentCustomApprovalMethods)(nil)
+	_ IncidentExternalEntityAPI           = (*IncidentExternalEntityMethods)(nil)
+	_ IncidentLinkAPI                     = (*IncidentLinkMethods)(nil)
+	_ IncidentLinkedCIsCountersFromAPI    = (*IncidentLinkedCIsCountersFromMethods)(nil)
+	_ IncidentAPI                         = (*IncidentMethods)(nil)
+	_ IncidentObserverAPI                 = (*IncidentObserverMethods)(nil)
+	_ IncidentReassignAPI                 = (*IncidentReassignMethods)(nil)
+	_ IncidentRejectAPI                   = (*IncidentRejectMethods)(nil)
+	_ IncidentReopenAPI                   = (*IncidentReopenMethods)(nil)
+	_ IncidentSolutionAcceptAPI           = (*IncidentSolutionAcceptMethods)(nil)
+	_ IncidentSolutionRejectAPI           = (*IncidentSolutionRejectMethods)(nil)
+	_ IncidentSpontaneousApprovalAPI      = (*IncidentSpontaneousApprovalMethods)(nil)
+	_ IncidentTasksAPI                    = (*IncidentTasksMethods)(nil)
+	_ IncidentWaitingForAgentAPI          = (*IncidentWaitingForAgentMethods)(nil)
+	_ IncidentWaitingForCustomerAPI       = (*IncidentWaitingForCustomerMethods)(nil)
+	_ IncidentWaitingForDateAPI           = (*IncidentWaitingForDateMethods)(nil)
+	_ IncidentWaitingForExternalEntityAPI = (*IncidentWaitingForExternalEntityMethods)(nil)
+	_ IncidentWaitingForIncidentAPI       = (*IncidentWaitingForIncidentMethods)(nil)
+	_ IncidentsByAgentAPI                 = (*IncidentsByAgentMethods)(nil)
+	_ IncidentsByCIsAPI                   = (*IncidentsByCIsMethods)(nil)
+	_ IncidentsByCustomerAPI              = (*IncidentsByCustomerMethods)(nil)
+	_ IncidentsByHelpDeskAPI              = (*IncidentsByHelpDeskMethods)(nil)
+	_ IncidentsBySentimentAPI             = (*IncidentsBySentimentMethods)(nil)
+	_ IncidentsByStatusAPI                = (*IncidentsByStatusMethods)(nil)
+	_ IncidentsByViewAPI                  = (*IncidentsByViewMethods)(nil)
+	_ IncidentsDetailsByViewAPI           = (*IncidentsDetailsByViewMethods)(nil)
+	_ IncidentsLastHourAPI                = (*IncidentsLastHourMethods)(nil)
+	_ IncidentsAPI                        = (*IncidentsMethods)(nil)
+	_ ServiceDeskVersionAPI               = (*ServiceDeskVersionMethods)(nil)
+	_ TimeTrackingAttributesCategoryAPI   = (*TimeTrackingAttributesCategoryMethods)(nil)
+	_ TimeTrackingAPI                     = (*TimeTrackingMethods)(nil)
+	_ TriggersExecutionsAPI               = (*TriggersExecutionsMethods)(nil)
+	_ TriggersAPI                         = (*TriggersMethods)(nil)
+	_ UserByAPI                           = (*UserByMethods)(nil)
+	_ UserConvertAPI                      = (*UserConvertMethods)(nil)
+	_ UserDisableAPI                      = (*UserDisableMethods)(nil)
+	_ UserEnableAPI                       = (*UserEnableMethods)(nil)
+	_ UserAPI                             = (*UserMethods)(nil)
+	_ UserPasswordAPI                     = (*UserPasswordMethods)(nil)
+	_ UserPasswordResetAPI                = (*UserPasswordResetMethods)(nil)
+	_ UserTokenAPI                        = (*UserTokenMethods)(nil)
+	_ UsersByAPI                          = (*UsersByMethods)(nil)
+	_ UsersGroupsAPI                      = (*UsersGroupsMethods)(nil)
+	_ UsersAPI                            = (*UsersMethods)(nil)
+	_ WorkflowDeployAPI                   = (*WorkflowDeployMethods)(nil)
+	_ WorkflowInitialFieldsByCategoryAPI  = (*WorkflowInitialFieldsByCategoryMethods)(nil)
+)
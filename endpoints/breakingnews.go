@@ -6,7 +6,6 @@ import (
 
 	"github.com/tmstorm/invgo/internal/methods"
 	"github.com/tmstorm/invgo/internal/utils"
-	"github.com/tmstorm/invgo/scopes"
 )
 
 type (
@@ -37,7 +36,7 @@ type (
 	// BreakingNewsGetParams extends BreakingNewsBase for GET requests
 	BreakingNewsGetParams struct {
 		ID         int    `url:"id,required"`
-		DateFormat string `url:"date_format"`
+		DateFormat string `url:"date_format" validate:"oneof=epoch iso8601"`
 		BreakingNewsBase
 	}
 )
@@ -48,8 +47,6 @@ type (
 func (b *BreakingNewsMethods) Get(p BreakingNewsGetParams) (BreakingNewsGetResponse, error) {
 	news := BreakingNewsGetResponse{}
 
-	b.RequiredScope = scopes.BreakingNewsGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return BreakingNewsGetResponse{}, err
@@ -90,8 +87,6 @@ type (
 // Requires scope: BreakingNewsPost
 // See https://releases.invgate.com/service-desk/api/#breakingnews-POST
 func (b *BreakingNewsMethods) Post(p BreakingNewsPostParams) (BreakingNewsInfoResponse, error) {
-	b.RequiredScope = scopes.BreakingNewsPost
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return BreakingNewsInfoResponse{}, err
@@ -125,8 +120,6 @@ type BreakingNewsPutParams struct {
 // Requires scope: BreakingNewsPut
 // See https://releases.invgate.com/service-desk/api/#breakingnews-PUT
 func (b *BreakingNewsMethods) Put(p BreakingNewsPutParams) (BreakingNewsInfoResponse, error) {
-	b.RequiredScope = scopes.BreakingNewsPut
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return BreakingNewsInfoResponse{}, err
@@ -154,8 +147,6 @@ type BreakingNewsAllMethods struct{ methods.MethodCall }
 // Requires scope: BreakingNewsAll
 // See https://releases.invgate.com/service-desk/api/#breakingnewsall
 func (c *BreakingNewsAllMethods) Get() ([]BreakingNewsGetResponse, error) {
-	c.RequiredScope = scopes.BreakingNewsAll
-
 	resp, err := c.RemoteGet()
 	if err != nil {
 		return nil, err
@@ -197,8 +188,6 @@ type (
 // Requires scope: BreakingNewsStatusGet
 // See https://releases.invgate.com/service-desk/api/#breakingnewsstatus-GET
 func (b *BreakingNewsStatusMethods) Get(p BreakingNewsStatusGetParams) ([]BreakingNewsStatusGetResponse, error) {
-	b.RequiredScope = scopes.BreakingNewsStatusGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return nil, err
@@ -231,8 +220,6 @@ type BreakingNewsStatusPostParams struct {
 // Requires scope: BreakingNewsStatusPost
 // See https://releases.invgate.com/service-desk/api/#breakingnewsstatus-POST
 func (b *BreakingNewsStatusMethods) Post(p BreakingNewsStatusPostParams) (BreakingNewsInfoResponse, error) {
-	b.RequiredScope = scopes.BreakingNewsStatusPost
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return BreakingNewsInfoResponse{}, err
@@ -17,6 +17,7 @@ func TestBreakingNewsGet(t *testing.T) {
 
 	var req endpoints.BreakingNewsGetParams
 	gofakeit.Struct(&req)
+	req.DateFormat = "iso8601"
 
 	server := newTestServer(t, http.MethodGet, "/breakingnews", news)
 
@@ -5,7 +5,6 @@ import (
 
 	"github.com/tmstorm/invgo/internal/methods"
 	"github.com/tmstorm/invgo/internal/utils"
-	"github.com/tmstorm/invgo/scopes"
 )
 
 type (
@@ -29,8 +28,6 @@ type (
 // If id == 0 all IDs will be provided
 // See https://releases.invgate.com/service-desk/api/#categories-GET
 func (cat *CategoriesMethods) Get(p CategoriesGetParams) ([]CategoriesGetResponse, error) {
-	cat.RequiredScope = scopes.CategoriesGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,425 @@
+package endpoints
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tmstorm/invgo/internal/methods"
+	"github.com/tmstorm/invgo/internal/utils"
+)
+
+type (
+	// CustomFields maps the custom fields of an incident by their field ID.
+	//
+	// NOTE: Invgate returns custom fields as an object keyed by the field ID
+	// and an empty array when the incident has no custom fields. Since Invgate encodes
+	// a map whose keys are 0, 1, 2... as an array, the values of an array are keyed by their index.
+	CustomFields map[int]CustomFieldValue
+
+	// CustomFieldValue holds the raw value of a single custom field.
+	// Use its accessors to read the value as the type expected for the field.
+	CustomFieldValue struct {
+		raw json.RawMessage
+	}
+)
+
+// customFieldTimeLayouts defines the layouts tried when reading a custom field as time.Time
+var customFieldTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// UnmarshalJSON decodes custom fields from either an object keyed by field ID or an array
+// keyed by index
+func (cf *CustomFields) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+
+	if len(b) > 0 && b[0] == '[' {
+		var list []CustomFieldValue
+		if err := json.Unmarshal(b, &list); err != nil {
+			return err
+		}
+		m := make(map[int]CustomFieldValue, len(list))
+		for i, v := range list {
+			m[i] = v
+		}
+		*cf = m
+		return nil
+	}
+
+	var m map[int]CustomFieldValue
+	if err := json.Unmarshal(b, &m); err != nil {
+		return err
+	}
+	*cf = m
+	return nil
+}
+
+// Get returns the value of the custom field with the given ID and if it was present
+func (cf CustomFields) Get(id int) (CustomFieldValue, bool) {
+	v, ok := cf[id]
+	return v, ok
+}
+
+// IDs returns the custom field IDs in ascending order
+func (cf CustomFields) IDs() []int {
+	ids := make([]int, 0, len(cf))
+	for id := range cf {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// NewCustomFieldValue creates a CustomFieldValue from a Go value by encoding it to JSON
+func NewCustomFieldValue(v any) (CustomFieldValue, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return CustomFieldValue{}, err
+	}
+	return CustomFieldValue{raw: b}, nil
+}
+
+// UnmarshalJSON stores the raw value so it can be read by the accessors
+func (v *CustomFieldValue) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(bytes.TrimSpace(b), []byte("null")) {
+		v.raw = nil
+		return nil
+	}
+	v.raw = append(json.RawMessage{}, b...)
+	return nil
+}
+
+// MarshalJSON returns the raw value as it was received from Invgate
+func (v CustomFieldValue) MarshalJSON() ([]byte, error) {
+	if len(v.raw) == 0 {
+		return []byte("null"), nil
+	}
+	return v.raw, nil
+}
+
+// Raw returns the undecoded JSON value
+func (v CustomFieldValue) Raw() json.RawMessage { return v.raw }
+
+// IsNull returns true if the value is empty or JSON null
+func (v CustomFieldValue) IsNull() bool { return len(v.raw) == 0 }
+
+// String returns the value as a string.
+// Numbers are returned as they were sent, and arrays are joined with a comma.
+func (v CustomFieldValue) String() string {
+	if v.IsNull() {
+		return ""
+	}
+
+	var s string
+	if err := json.Unmarshal(v.raw, &s); err == nil {
+		return s
+	}
+
+	if v.raw[0] == '[' {
+		opts, err := v.Options()
+		if err == nil {
+			return strings.Join(opts, ",")
+		}
+	}
+	return string(v.raw)
+}
+
+// Int returns the value as an int. Numeric strings are also accepted.
+func (v CustomFieldValue) Int() (int, error) {
+	if v.IsNull() {
+		return 0, nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(v.raw, &n); err == nil {
+		i, err := strconv.ParseInt(n.String(), 10, 64)
+		if err != nil {
+			f, ferr := n.Float64()
+			if ferr != nil {
+				return 0, err
+			}
+			return int(f), nil
+		}
+		return int(i), nil
+	}
+
+	var s string
+	if err := json.Unmarshal(v.raw, &s); err != nil {
+		return 0, fmt.Errorf("custom field value %s is not an int", v.raw)
+	}
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(s)
+}
+
+// Time returns the value as a time.Time in UTC.
+// Epoch timestamps (as numbers or strings) and ISO-8601 formatted dates are accepted.
+// Dates without a zone are read as UTC.
+func (v CustomFieldValue) Time() (time.Time, error) {
+	if v.IsNull() {
+		return time.Time{}, nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(v.raw, &n); err == nil {
+		i, err := n.Int64()
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(i, 0).UTC(), nil
+	}
+
+	var s string
+	if err := json.Unmarshal(v.raw, &s); err != nil {
+		return time.Time{}, fmt.Errorf("custom field value %s is not a time", v.raw)
+	}
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(i, 0).UTC(), nil
+	}
+	for _, layout := range customFieldTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("custom field value %q is not a known time format", s)
+}
+
+// Options returns the selected options of a list or tree field.
+// A single value is returned as a slice with one element.
+func (v CustomFieldValue) Options() ([]string, error) {
+	if v.IsNull() {
+		return nil, nil
+	}
+
+	if v.raw[0] != '[' {
+		return []string{v.String()}, nil
+	}
+
+	var list []json.RawMessage
+	if err := json.Unmarshal(v.raw, &list); err != nil {
+		return nil, err
+	}
+
+	opts := make([]string, 0, len(list))
+	for _, item := range list {
+		opts = append(opts, CustomFieldValue{raw: item}.String())
+	}
+	return opts, nil
+}
+
+/*
+Bind sets the fields of the struct pointed to by dst from the custom fields using the `invgate` tag.
+
+The expected `invgate` format is:
+
+	`cf=ID`: ID of the custom field to bind
+	`name=Name`: Name of the custom field to bind. The ID is looked up in defs so they must be provided.
+
+Supported field types are string, int, uint, float, bool, time.Time, []string, []int and CustomFieldValue.
+Fields missing from cf are left unchanged.
+
+Example:
+
+	type Extra struct {
+		CostCenter string    `invgate:"cf=42"`
+		DueDate    time.Time `invgate:"name=Due date"`
+	}
+*/
+func (cf CustomFields) Bind(dst any, defs ...CustomFieldDefinition) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("bind destination must be a non nil pointer to a struct")
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	var errs []error
+	for i := range rv.NumField() {
+		tag := rt.Field(i).Tag.Get("invgate")
+		if tag == "" || tag == "-" || !rv.Field(i).CanSet() {
+			continue
+		}
+
+		id, err := customFieldTagID(tag, defs)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("field %s: %w", rt.Field(i).Name, err))
+			continue
+		}
+
+		val, ok := cf[id]
+		if !ok {
+			continue
+		}
+
+		if err := setCustomField(rv.Field(i), val); err != nil {
+			errs = append(errs, fmt.Errorf("field %s (cf=%d): %w", rt.Field(i).Name, id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// customFieldTagID returns the custom field ID referenced by an `invgate` tag
+func customFieldTagID(tag string, defs []CustomFieldDefinition) (int, error) {
+	key, value, ok := strings.Cut(tag, "=")
+	if !ok {
+		return 0, fmt.Errorf("invalid invgate tag %q", tag)
+	}
+	value = strings.TrimSpace(value)
+
+	switch strings.TrimSpace(key) {
+	case "cf":
+		return strconv.Atoi(value)
+	case "name":
+		for _, d := range defs {
+			if strings.EqualFold(d.Name, value) {
+				return d.ID, nil
+			}
+		}
+		return 0, fmt.Errorf("no custom field definition found with name %q", value)
+	default:
+		return 0, fmt.Errorf("invalid invgate tag %q", tag)
+	}
+}
+
+var (
+	timeType             = reflect.TypeOf(time.Time{})
+	customFieldValueType = reflect.TypeOf(CustomFieldValue{})
+)
+
+// setCustomField sets field to val converting it to the fields type
+func setCustomField(field reflect.Value, val CustomFieldValue) error {
+	switch field.Type() {
+	case customFieldValueType:
+		field.Set(reflect.ValueOf(val))
+		return nil
+	case timeType:
+		t, err := val.Time()
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(val.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := val.Int()
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(i))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := val.Int()
+		if err != nil {
+			return err
+		}
+		if i < 0 {
+			return fmt.Errorf("value %d can not be set on an unsigned field", i)
+		}
+		field.SetUint(uint64(i))
+	case reflect.Float32, reflect.Float64:
+		s := val.String()
+		if s == "" {
+			return nil
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Bool:
+		s := strings.ToLower(val.String())
+		field.SetBool(s == "1" || s == "true" || s == "yes")
+	case reflect.Slice:
+		opts, err := val.Options()
+		if err != nil {
+			return err
+		}
+		switch field.Type().Elem().Kind() {
+		case reflect.String:
+			field.Set(reflect.ValueOf(opts))
+		case reflect.Int:
+			ints := make([]int, 0, len(opts))
+			for _, o := range opts {
+				i, err := strconv.Atoi(o)
+				if err != nil {
+					return err
+				}
+				ints = append(ints, i)
+			}
+			field.Set(reflect.ValueOf(ints))
+		default:
+			return fmt.Errorf("unsupported slice type %s", field.Type())
+		}
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
+
+type (
+	// CustomFieldsByCategoryMethods is used to call methods for CustomFieldsByCategory
+	CustomFieldsByCategoryMethods struct{ methods.MethodCall }
+
+	// CustomFieldsByCategoryGetParams is used to get the custom field definitions of a category
+	CustomFieldsByCategoryGetParams struct {
+		CategoryID int `url:"category_id,required"`
+	}
+
+	// CustomFieldDefinition is used to map a custom field definition returned from the Invgate API
+	//
+	// NOTE: The Invgate API docs do not fully describe what is returned for a definition.
+	// Options is set to any until its structure is known.
+	CustomFieldDefinition struct {
+		ID          int    `json:"id,omitempty"`
+		Name        string `json:"name,omitempty"`
+		Description string `json:"description,omitempty"`
+		Type        int    `json:"type,omitempty"`
+		IsRequired  bool   `json:"is_required,omitempty"`
+		Options     any    `json:"options,omitempty"`
+	}
+)
+
+// Get for CustomFieldsByCategory
+// Requires scope: CustomFieldsByCategoryGet
+// See https://releases.invgate.com/service-desk/api/#cffieldsbycategory-GET
+func (c *CustomFieldsByCategoryMethods) Get(p CustomFieldsByCategoryGetParams) ([]CustomFieldDefinition, error) {
+	r := []CustomFieldDefinition{}
+	q, err := utils.StructToQuery(p)
+	if err != nil {
+		return r, err
+	}
+	c.Endpoint.RawQuery = q.Encode()
+
+	resp, err := c.RemoteGet()
+	if err != nil {
+		return r, err
+	}
+
+	err = json.Unmarshal(resp, &r)
+	if err != nil {
+		return r, err
+	}
+	return r, nil
+}
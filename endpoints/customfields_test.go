@@ -0,0 +1,109 @@
+package endpoints_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/tmstorm/invgo/endpoints"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+func TestCustomFieldsUnmarshal(t *testing.T) {
+	a := assert.New(t)
+
+	var inc endpoints.Incident
+	err := json.Unmarshal([]byte(`{"id":1,"custom_fields":{"42":"CC-100","43":"7","44":1735689600,"45":["a","b"],"46":null}}`), &inc)
+	a.NoError(err)
+
+	s, ok := inc.CustomFields.Get(42)
+	a.True(ok)
+	a.Equal("CC-100", s.String())
+
+	i, err := inc.CustomFields[43].Int()
+	a.NoError(err)
+	a.Equal(7, i)
+
+	tm, err := inc.CustomFields[44].Time()
+	a.NoError(err)
+	a.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), tm)
+
+	opts, err := inc.CustomFields[45].Options()
+	a.NoError(err)
+	a.Equal([]string{"a", "b"}, opts)
+
+	a.True(inc.CustomFields[46].IsNull())
+	a.Equal([]int{42, 43, 44, 45, 46}, inc.CustomFields.IDs())
+
+	// Invgate sends an empty array when there are no custom fields
+	var empty endpoints.Incident
+	err = json.Unmarshal([]byte(`{"id":2,"custom_fields":[]}`), &empty)
+	a.NoError(err)
+	a.Empty(empty.CustomFields)
+
+	// a map keyed 0, 1, 2... is sent as an array
+	var list endpoints.Incident
+	err = json.Unmarshal([]byte(`{"id":3,"custom_fields":["CC-100","1735689600"]}`), &list)
+	a.NoError(err)
+	a.Equal("CC-100", list.CustomFields[0].String())
+	tm, err = list.CustomFields[1].Time()
+	a.NoError(err)
+	a.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), tm)
+}
+
+func TestCustomFieldsBind(t *testing.T) {
+	a := assert.New(t)
+
+	var cf endpoints.CustomFields
+	err := json.Unmarshal([]byte(`{"42":"CC-100","43":"7","44":"2025-01-01 10:30","45":["1","2"],"46":"1"}`), &cf)
+	a.NoError(err)
+
+	var dst struct {
+		CostCenter string    `invgate:"cf=42"`
+		Units      int       `invgate:"cf=43"`
+		Due        time.Time `invgate:"name=Due date"`
+		Tags       []int     `invgate:"cf=45"`
+		Urgent     bool      `invgate:"cf=46"`
+		Missing    string    `invgate:"cf=99"`
+		Ignored    string
+	}
+
+	defs := []endpoints.CustomFieldDefinition{{ID: 44, Name: "Due date"}}
+	err = cf.Bind(&dst, defs...)
+	a.NoError(err)
+
+	a.Equal("CC-100", dst.CostCenter)
+	a.Equal(7, dst.Units)
+	a.Equal(time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC), dst.Due)
+	a.Equal([]int{1, 2}, dst.Tags)
+	a.True(dst.Urgent)
+	a.Empty(dst.Missing)
+
+	// name tags can not be resolved without definitions
+	err = cf.Bind(&dst)
+	a.Error(err)
+
+	err = cf.Bind(dst)
+	a.Error(err)
+}
+
+func TestCustomFieldsByCategoryGet(t *testing.T) {
+	a := assert.New(t)
+
+	defs := make([]endpoints.CustomFieldDefinition, 2)
+	for i := range defs {
+		gofakeit.Struct(&defs[i])
+		defs[i].Options = nil
+	}
+
+	server := newTestServer(t, http.MethodGet, "/cf.fields.by.category", defs)
+
+	c := newTestClient(t, server, scopes.CustomFieldsByCategoryGet)
+
+	resp, err := c.CustomFieldsByCategory().Get(endpoints.CustomFieldsByCategoryGetParams{CategoryID: 1})
+	a.NoError(err)
+	a.Equal(defs, resp)
+}
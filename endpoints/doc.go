@@ -4,7 +4,7 @@ Package endpoints is the implementation of Invgate endpoints and their methods.
 Each endpoint should be in its own file for clarity and readbility e.g. breaking news should be BreakingNews.go.
 To expose the endpoint through the Invgo public API a Client method should be defined for each it in invgo/endpoint_methods.go.
 
-If a new endpoint is added ensure it is added to invgo/coverage.go along with its related methods. This ensures the coverage script catches that
-it has been implemented.
+If a new endpoint is added ensure it is registered in invgo/scopes/registry.go along with its related methods and scopes. This ensures the
+coverage script catches that it has been implemented and its scopes can be inferred.
 */
 package endpoints
@@ -8,7 +8,18 @@ import (
 
 	"github.com/tmstorm/invgo/internal/methods"
 	"github.com/tmstorm/invgo/internal/utils"
-	"github.com/tmstorm/invgo/scopes"
+)
+
+// QueryEncoder is implemented by types that encode themselves as query parameters when used
+// in the params of a method. key is the full key of the field including any parent prefix.
+type QueryEncoder = utils.QueryEncoder
+
+type (
+	// ValidationError is returned by a method before any request is made when its params fail
+	// validation. Fields lists every param that failed.
+	ValidationError = utils.ValidationError
+	// FieldError is a param that failed validation
+	FieldError = utils.FieldError
 )
 
 type (
@@ -26,23 +37,19 @@ type (
 	}
 
 	// AttributesGetParams is used to construct a GET call to AttributesMethods
-	// NOTE: Even though this is required, 0 is a valid input so required is not specified in the tag.
-	// A better approach should be taken to this at some point
+	// NOTE: Even though this is required, 0 is a valid input so it is always sent instead of required.
 	AttributesGetParams struct {
-		ID int `url:"id"`
+		ID int `url:"id,always"`
 	}
 )
 
 // Get for Attributes
 // Requires scope: This depends on which attributes endpoint you are calling ensure its scope
-// is created in invgo/scopes/scopes.go
+// is created in invgo/scopes/scopes.go and registered in invgo/scopes/registry.go
 // This Get method works for all attribute endpoints see the related endpoints documentation
 // for ID definition and return definitions.
 // If ID > 0 is provided, only one will be listed.
 func (b *AttributesMethods) Get(p AttributesGetParams) ([]AttributesResponse, error) {
-	// NOTE: RequiredScope type should be set in invgo/endpoint_methods.go when
-	// creating the public method for each endpoint attribute since they all share this
-	// Get method.
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return nil, err
@@ -78,8 +85,6 @@ type ServiceDeskVersionResponse struct {
 // Requires scope: ServiceDeskVersionGet
 // See https://releases.invgate.com/service-desk/api/#sdversion-GET
 func (s *ServiceDeskVersionMethods) Get() (string, error) {
-	s.RequiredScope = scopes.ServiceDeskVersionGet
-
 	resp, err := s.RemoteGet()
 	if err != nil {
 		return "", err
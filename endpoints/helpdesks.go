@@ -5,7 +5,6 @@ import (
 
 	"github.com/tmstorm/invgo/internal/methods"
 	"github.com/tmstorm/invgo/internal/utils"
-	"github.com/tmstorm/invgo/scopes"
 )
 
 // HelpDesksMethods is used to call methods for HelpDesks
@@ -32,8 +31,6 @@ type HelpDeskGetParams struct {
 // If an ID of 0 is passed all help desks will be returned
 // See https://releases.invgate.com/service-desk/api/#helpdesks-GET
 func (h *HelpDesksMethods) Get(p HelpDeskGetParams) ([]HelpDesksGetResponse, error) {
-	h.RequiredScope = scopes.HelpDesksGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return nil, err
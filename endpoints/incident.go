@@ -6,7 +6,6 @@ import (
 
 	"github.com/tmstorm/invgo/internal/methods"
 	"github.com/tmstorm/invgo/internal/utils"
-	"github.com/tmstorm/invgo/scopes"
 )
 
 type (
@@ -16,7 +15,7 @@ type (
 		CategoryID                      int                       `json:"category_id,omitempty"`
 		CreatedAt                       int                       `json:"created_at,omitempty"`
 		UserID                          int                       `json:"user_id,omitempty"`
-		CustomFields                    any                       `json:"custom_fields,omitempty"`
+		CustomFields                    CustomFields              `json:"custom_fields,omitempty"`
 		Description                     string                    `json:"description,omitempty"`
 		CreatorID                       int                       `json:"creator_id,omitempty"`
 		SourceID                        int                       `json:"source_id,omitempty"`
@@ -67,7 +66,7 @@ type (
 	IncidentGetParams struct {
 		ID                       int    `url:"id,required"`
 		DecodedSpecialCharacters bool   `url:"decoded_special_character"`
-		DateFormat               string `url:"date_format"`
+		DateFormat               string `url:"date_format" validate:"oneof=epoch iso8601"`
 		Comments                 bool   `url:"comments"`
 	}
 )
@@ -78,8 +77,6 @@ type (
 // NOTE: Invgate documentation says it returns and array. This does not appear to be the case.
 // However this method still accounts for that if it is ever the case.
 func (i *IncidentMethods) Get(p IncidentGetParams) ([]Incident, error) {
-	i.RequiredScope = scopes.IncidentGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return nil, err
@@ -133,8 +130,6 @@ type (
 // Requires scope: IncidentPost
 // See https://releases.invgate.com/service-desk/api/#incident-POST
 func (i *IncidentMethods) Post(p IncidentPostParams) (IncidentPostResponse, error) {
-	i.RequiredScope = scopes.IncidentPost
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return IncidentPostResponse{}, err
@@ -167,7 +162,7 @@ type IncidentPutParams struct {
 	CategoryID   int    `url:"category_id"`
 	Description  string `url:"description"`
 	Reassignment bool   `url:"reassignment"`
-	DateFormat   string `url:"date_format"`
+	DateFormat   string `url:"date_format" validate:"oneof=epoch iso8601"`
 	CustomerID   int    `url:"customer_id"`
 }
 
@@ -177,8 +172,6 @@ type IncidentPutParams struct {
 // NOTE: Invgate documentation says it returns an array. This does not appear to be the case.
 // However this method still accounts for that if it is ever the case.
 func (i *IncidentMethods) Put(p IncidentPutParams) ([]Incident, error) {
-	i.RequiredScope = scopes.IncidentPut
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return []Incident{}, err
@@ -209,7 +202,7 @@ type (
 
 	IncidentApprovalGetParams struct {
 		OnlyPending bool   `url:"only_pending"`
-		DateFormat  string `url:"date_format"`
+		DateFormat  string `url:"date_format" validate:"oneof=epoch iso8601"`
 		RequestID   int    `url:"request_id,required"`
 	}
 
@@ -241,8 +234,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentapproval-GET
 func (i *IncidentApprovalMethods) Get(p IncidentApprovalGetParams) ([]IncidentApprovalGetResponse, error) {
 	incs := []IncidentApprovalGetResponse{}
-	i.RequiredScope = scopes.IncidentApprovalGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return incs, err
@@ -282,8 +273,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentapprovalaccept-PUT
 func (i *IncidentApprovalAcceptMethods) Put(p IncidentApprovalAcceptPutParams) (IncidentApprovalAcceptPutResponse, error) {
 	inc := IncidentApprovalAcceptPutResponse{}
-	i.RequiredScope = scopes.IncidentApprovalAcceptPut
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return inc, err
@@ -322,8 +311,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentapprovaladd_voter-POST
 func (i *IncidentApprovalAddVoterMethods) Post(p IncidentApprovalAddVoterPostParams) (IncidentApprovalAddVoterPostResponse, error) {
 	inc := IncidentApprovalAddVoterPostResponse{}
-	i.RequiredScope = scopes.IncidentApprovalAddVoterPost
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return inc, err
@@ -362,8 +349,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentapprovalcancel-PUT
 func (i *IncidentApprovalCancelMethods) Put(p IncidentApprovalCancelPutParams) (IncidentApprovalCancelPutResponse, error) {
 	inc := IncidentApprovalCancelPutResponse{}
-	i.RequiredScope = scopes.IncidentApprovalCancelPut
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return inc, err
@@ -403,8 +388,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentapprovalpossible_voters-GET
 func (i *IncidentApprovalPossibleVotersMethods) Get(p IncidentApprovalPossibleVotersGetParams) ([]IncidentApprovalPossibleVotersGetResponse, error) {
 	inc := []IncidentApprovalPossibleVotersGetResponse{}
-	i.RequiredScope = scopes.IncidentApprovalPossibleVotersGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return inc, err
@@ -444,8 +427,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentapprovalreject-PUT
 func (i *IncidentApprovalRejectMethods) Put(p IncidentApprovalRejectPutParams) (IncidentApprovalRejectPutResponse, error) {
 	inc := IncidentApprovalRejectPutResponse{}
-	i.RequiredScope = scopes.IncidentApprovalRejectPut
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return inc, err
@@ -484,8 +465,6 @@ type (
 // To make this easier to access it has been converted into an array of type IncidentApprovalStatusGetResponse
 // containing the ID and Description for each status.
 func (i *IncidentApprovalStatusMethods) Get() ([]IncidentApprovalStatusGetResponse, error) {
-	i.RequiredScope = scopes.IncidentApprovalStatusGet
-
 	resp, err := i.RemoteGet()
 	if err != nil {
 		return nil, err
@@ -529,8 +508,6 @@ type (
 // To make this easier to access it has been converted into an array of type IncidentApprovalTypeGetResponse
 // containing the ID and Description for each type.
 func (i *IncidentApprovalTypeMethods) Get() ([]IncidentApprovalTypeGetResponse, error) {
-	i.RequiredScope = scopes.IncidentApprovalTypeGet
-
 	resp, err := i.RemoteGet()
 	if err != nil {
 		return nil, err
@@ -574,8 +551,6 @@ type (
 // To make this easier to access it has been converted into an array of type IncidentApprovalVoteStatusGetResponse
 // containing the ID and Description for each type.
 func (i *IncidentApprovalVoteStatusMethods) Get() ([]IncidentApprovalVoteStatusGetResponse, error) {
-	i.RequiredScope = scopes.IncidentApprovalVoteStatusGet
-
 	resp, err := i.RemoteGet()
 	if err != nil {
 		return nil, err
@@ -622,8 +597,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentattachment-GET
 func (i *IncidentAttachmentMethods) Get(p IncidentAttachmentGetParams) (IncidentAttachmentGetResponse, error) {
 	att := IncidentAttachmentGetResponse{}
-	i.RequiredScope = scopes.IncidentAttachmentGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return att, err
@@ -664,8 +637,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentcancel-POST
 func (i *IncidentCancelMethods) Post(p IncidentCancelPostParams) (IncidentCancelPostResponse, error) {
 	inc := IncidentCancelPostResponse{}
-	i.RequiredScope = scopes.IncidentCancelPost
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return inc, err
@@ -702,8 +673,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentcancel-Get
 func (i *IncidentCollaboratorMethods) Get(p IncidentCollaboratorGetParams) (IncidentCollaboratorGetResponse, error) {
 	inc := IncidentCollaboratorGetResponse{}
-	i.RequiredScope = scopes.IncidentCollaboratorGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return inc, err
@@ -748,8 +717,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentcancel-POST
 func (i *IncidentCollaboratorMethods) Post(p IncidentCollaboratorPostParams) (IncidentCollaboratorPostResponse, error) {
 	inc := IncidentCollaboratorPostResponse{}
-	i.RequiredScope = scopes.IncidentCollaboratorPost
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return inc, err
@@ -781,7 +748,7 @@ type (
 		RequestID int `url:"request_id,required"`
 		// Indicate the date format. The available formats are 'epoch' or 'iso8601'.
 		// If null, epoch format is returned.
-		DateFormat               string `url:"date_format"`
+		DateFormat               string `url:"date_format" validate:"oneof=epoch iso8601"`
 		IsSolution               bool   `url:"is_solution"`
 		DecodedSpecialCharacters bool   `url:"decoded_special_characters"`
 	}
@@ -806,8 +773,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentcomment-GET
 func (i *IncidentCommentMethods) Get(p IncidentCommentGetParams) ([]IncidentCommentGetResponse, error) {
 	comms := []IncidentCommentGetResponse{}
-	i.RequiredScope = scopes.IncidentCommentGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return comms, err
@@ -850,8 +815,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentcomment-POST
 func (i *IncidentCommentMethods) Post(p IncidentCommentPostParams) (IncidentCommentPostResponse, error) {
 	com := IncidentCommentPostResponse{}
-	i.RequiredScope = scopes.IncidentCommentPost
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return com, err
@@ -877,7 +840,7 @@ type (
 	IncidentCustomApprovalGetParams struct {
 		// Indicate the date format. The available formats are 'epoch' or 'iso8601'.
 		// If null, epoch format is returned.
-		DateFormat string `url:"date_format"`
+		DateFormat string `url:"date_format" validate:"oneof=epoch iso8601"`
 		RequestID  int    `url:"request_id,required"`
 	}
 
@@ -903,8 +866,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentcustom_approval-GET
 func (i *IncidentCustomApprovalMethods) Get(p IncidentCustomApprovalGetParams) ([]IncidentCustomApprovalGetResponse, error) {
 	cust := []IncidentCustomApprovalGetResponse{}
-	i.RequiredScope = scopes.IncidentCustomApprovalGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return cust, err
@@ -944,8 +905,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentcustom_approval-POST
 func (i *IncidentCustomApprovalMethods) Post(p IncidentCustomApprovalPostParams) (IncidentCustomApprovalPostResponse, error) {
 	cust := IncidentCustomApprovalPostResponse{}
-	i.RequiredScope = scopes.IncidentCustomApprovalPost
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return cust, err
@@ -993,8 +952,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentexternal_entity-GET
 func (i *IncidentExternalEntityMethods) Get(p IncidentExternalEntityGetParams) ([]IncidentExternalEntityGetResponse, error) {
 	cust := []IncidentExternalEntityGetResponse{}
-	i.RequiredScope = scopes.IncidentExternalEntityGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return cust, err
@@ -1034,8 +991,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentexternal_entity-POST
 func (i *IncidentExternalEntityMethods) Post(p IncidentExternalEntityPostParams) (IncidentExternalEntityPostResponse, error) {
 	cust := IncidentExternalEntityPostResponse{}
-	i.RequiredScope = scopes.IncidentExternalEntityPost
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return cust, err
@@ -1079,8 +1034,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentlink-GET
 func (i *IncidentLinkMethods) Get(p IncidentLinkGetParams) ([]IncidentLinkGetResponse, error) {
 	cust := []IncidentLinkGetResponse{}
-	i.RequiredScope = scopes.IncidentLinkGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return cust, err
@@ -1121,8 +1074,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentlink-POST
 func (i *IncidentLinkMethods) Post(p IncidentLinkPostParams) (IncidentLinkPostResponse, error) {
 	cust := IncidentLinkPostResponse{}
-	i.RequiredScope = scopes.IncidentLinkPost
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return cust, err
@@ -1168,8 +1119,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentlinked_ciscountersfrom-GET
 func (i *IncidentLinkedCIsCountersFromMethods) Get(p IncidentLinkedCIsCountersFromGetParams) ([]IncidentLinkedCIsCountersFromGetResponse, error) {
 	cust := []IncidentLinkedCIsCountersFromGetResponse{}
-	i.RequiredScope = scopes.IncidentLinkedCIsCountersFromGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return cust, err
@@ -1210,8 +1159,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentobserver-GET
 func (i *IncidentObserverMethods) Get(p IncidentObserverGetParams) (IncidentObserverGetResponse, error) {
 	var r IncidentObserverGetResponse
-	i.RequiredScope = scopes.IncidentObserverGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return r, err
@@ -1256,8 +1203,6 @@ type (
 // Requires scope: IncidentObserverPost
 // See https://releases.invgate.com/service-desk/api/#incidentobserver-POST
 func (i *IncidentObserverMethods) Post(p IncidentObserverPostParams) (IncidentObserverPostResponse, error) {
-	i.RequiredScope = scopes.IncidentObserverPost
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return IncidentObserverPostResponse{}, err
@@ -1305,8 +1250,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentreassign-POST
 func (i *IncidentReassignMethods) Post(p IncidentReassignPostParams) (IncidentReassignPostResponse, error) {
 	cust := IncidentReassignPostResponse{}
-	i.RequiredScope = scopes.IncidentReassignPost
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return cust, err
@@ -1351,8 +1294,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentreject-POST
 func (i *IncidentRejectMethods) Post(p IncidentRejectPostParams) (IncidentRejectPostResponse, error) {
 	cust := IncidentRejectPostResponse{}
-	i.RequiredScope = scopes.IncidentRejectPost
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return cust, err
@@ -1399,8 +1340,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentreopen-PUT
 func (i *IncidentReopenMethods) Put(p IncidentReopenPutParams) (IncidentReopenPutResponse, error) {
 	cust := IncidentReopenPutResponse{}
-	i.RequiredScope = scopes.IncidentReopenPut
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return cust, err
@@ -1446,8 +1385,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentsolutionaccept-PUT
 func (i *IncidentSolutionAcceptMethods) Put(p IncidentSolutionAcceptPutParams) (IncidentSolutionAcceptPutResponse, error) {
 	cust := IncidentSolutionAcceptPutResponse{}
-	i.RequiredScope = scopes.IncidentSolutionAcceptPut
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return cust, err
@@ -1492,8 +1429,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentsolutionareject-PUT
 func (i *IncidentSolutionRejectMethods) Put(p IncidentSolutionRejectPutParams) (IncidentSolutionRejectPutResponse, error) {
 	cust := IncidentSolutionRejectPutResponse{}
-	i.RequiredScope = scopes.IncidentSolutionRejectPut
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return cust, err
@@ -1540,8 +1475,6 @@ type (
 // Requires scope: IncidentSpontaneousApprovalPost
 // See https://releases.invgate.com/service-desk/api/#incidentspontaneous_approval-POST
 func (i *IncidentSpontaneousApprovalMethods) Post(p IncidentSpontaneousApprovalPostParams) (IncidentSpontaneousApprovalPostResponse, error) {
-	i.RequiredScope = scopes.IncidentSpontaneousApprovalPost
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return IncidentSpontaneousApprovalPostResponse{}, err
@@ -1597,8 +1530,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidenttasks-GET
 func (i *IncidentTasksMethods) Get(p IncidentTasksGetParams) ([]IncidentTasksGetResponse, error) {
 	var r []IncidentTasksGetResponse
-	i.RequiredScope = scopes.IncidentTasksGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return r, err
@@ -1639,8 +1570,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentwaitingforagent-POST
 func (i *IncidentWaitingForAgentMethods) Post(p IncidentWaitingForAgentPostParams) (IncidentWaitingForAgentPostResponse, error) {
 	r := IncidentWaitingForAgentPostResponse{}
-	i.RequiredScope = scopes.IncidentWaitingForAgentPost
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return r, err
@@ -1685,8 +1614,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentwaitingforagent-POST
 func (i *IncidentWaitingForCustomerMethods) Post(p IncidentWaitingForCustomerPostParams) (IncidentWaitingForCustomerPostResponse, error) {
 	r := IncidentWaitingForCustomerPostResponse{}
-	i.RequiredScope = scopes.IncidentWaitingForCustomerPost
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return r, err
@@ -1732,8 +1659,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentwaitingforagent-POST
 func (i *IncidentWaitingForDateMethods) Post(p IncidentWaitingForDatePostParams) (IncidentWaitingForDatePostResponse, error) {
 	r := IncidentWaitingForDatePostResponse{}
-	i.RequiredScope = scopes.IncidentWaitingForDatePost
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return r, err
@@ -1779,8 +1704,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentwaitingforexternal_entity-POST
 func (i *IncidentWaitingForExternalEntityMethods) Post(p IncidentWaitingForExternalEntityPostParams) (IncidentWaitingForExternalEntityPostResponse, error) {
 	r := IncidentWaitingForExternalEntityPostResponse{}
-	i.RequiredScope = scopes.IncidentWaitingForExternalEntityPost
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return r, err
@@ -1825,8 +1748,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#incidentwaitingforagent-POST
 func (i *IncidentWaitingForIncidentMethods) Post(p IncidentWaitingForIncidentPostParams) (IncidentWaitingForIncidentPostResponse, error) {
 	r := IncidentWaitingForIncidentPostResponse{}
-	i.RequiredScope = scopes.IncidentWaitingForIncidentPost
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return r, err
@@ -1856,7 +1777,7 @@ type IncidentsMethods struct{ methods.MethodCall }
 type IncidentsGetParams struct {
 	IDs             []int  `url:"ids,required"`
 	IncludeComments bool   `url:"comments"`
-	DateFormat      string `url:"date_format"`
+	DateFormat      string `url:"date_format" validate:"oneof=epoch iso8601"`
 }
 
 // Get for Incidents
@@ -1864,8 +1785,6 @@ type IncidentsGetParams struct {
 // At least one incident must be provided
 // See https://releases.invgate.com/service-desk/api/#incidents-GET
 func (i *IncidentsMethods) Get(p IncidentsGetParams) ([]Incident, error) {
-	i.RequiredScope = scopes.IncidentsGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return nil, err
@@ -1897,12 +1816,12 @@ type (
 
 	// IncidentsByAgentGetParams you must provide an email, id, or username
 	IncidentsByAgentGetParams struct {
-		Email    string `url:"email"`
-		ID       int    `url:"id"`
+		Email    string `url:"email" validate:"group=user"`
+		ID       int    `url:"id" validate:"group=user"`
 		Limit    int    `url:"limit"`
 		Comments bool   `url:"comments"`
 		PageKey  string `url:"page_key"`
-		Username string `url:"username"`
+		Username string `url:"username" validate:"group=user"`
 	}
 
 	// IncidentsByAgentGetResponse maps the response for getting incidents by agent
@@ -1924,8 +1843,6 @@ type (
 func (i *IncidentsByAgentMethods) Get(p IncidentsByAgentGetParams) (IncidentsByAgentGetResponse, error) {
 	r := IncidentsByAgentGetResponse{}
 
-	i.RequiredScope = scopes.IncidentsByAgentGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return r, err
@@ -1973,8 +1890,6 @@ type (
 func (i *IncidentsByCIsMethods) Get(p IncidentsByCIsGetParams) (IncidentsByCIsGetResponse, error) {
 	r := IncidentsByCIsGetResponse{}
 
-	i.RequiredScope = scopes.IncidentsByCIsGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return r, err
@@ -2000,12 +1915,12 @@ type (
 
 	// IncidentsByCustomerGetParams you must provide an email, id, or username
 	IncidentsByCustomerGetParams struct {
-		Email    string `url:"email"`
-		ID       int    `url:"id"`
+		Email    string `url:"email" validate:"group=user"`
+		ID       int    `url:"id" validate:"group=user"`
 		Limit    int    `url:"limit"`
 		Comments bool   `url:"comments"`
 		PageKey  string `url:"page_key"`
-		Username string `url:"username"`
+		Username string `url:"username" validate:"group=user"`
 	}
 
 	// IncidentsByCustomerGetResponse maps the response for getting incidents by agent
@@ -2027,8 +1942,6 @@ type (
 func (i *IncidentsByCustomerMethods) Get(p IncidentsByCustomerGetParams) (IncidentsByCustomerGetResponse, error) {
 	r := IncidentsByCustomerGetResponse{}
 
-	i.RequiredScope = scopes.IncidentsByCustomerGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return r, err
@@ -2070,8 +1983,6 @@ type (
 func (i *IncidentsByHelpDeskMethods) Get(p IncidentsByHelpDeskGetParams) (IncidentsByHelpDeskGetResponse, error) {
 	r := IncidentsByHelpDeskGetResponse{}
 
-	i.RequiredScope = scopes.IncidentsByHelpDeskGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return r, err
@@ -2117,8 +2028,6 @@ type (
 func (i *IncidentsBySentimentMethods) Get(p IncidentsBySentimentGetParams) (IncidentsBySentimentGetResponse, error) {
 	r := IncidentsBySentimentGetResponse{}
 
-	i.RequiredScope = scopes.IncidentsBySentimentGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return r, err
@@ -2165,8 +2074,6 @@ type (
 func (i *IncidentsByStatusMethods) Get(p IncidentsByStatusGetParams) (IncidentsByStatusGetResponse, error) {
 	r := IncidentsByStatusGetResponse{}
 
-	i.RequiredScope = scopes.IncidentsByStatusGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return r, err
@@ -2212,8 +2119,6 @@ type (
 func (i *IncidentsByViewMethods) Get(p IncidentsByViewGetParams) (IncidentsByViewGetResponse, error) {
 	r := IncidentsByViewGetResponse{}
 
-	i.RequiredScope = scopes.IncidentsByViewGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return r, err
@@ -2290,8 +2195,6 @@ type (
 func (i *IncidentsDetailsByViewMethods) Get(p IncidentsDetailsByViewGetParams) (IncidentsDetailsByViewGetResponse, error) {
 	r := IncidentsDetailsByViewGetResponse{}
 
-	i.RequiredScope = scopes.IncidentsDetailsByViewGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return r, err
@@ -2332,8 +2235,6 @@ type (
 func (i *IncidentsLastHourMethods) Get(p IncidentsLastHourGetParams) ([]IncidentsLastHourGetResponse, error) {
 	r := []IncidentsLastHourGetResponse{}
 
-	i.RequiredScope = scopes.IncidentsLastHourGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return r, err
@@ -6,6 +6,8 @@ import (
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/stretchr/testify/assert"
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/cassette"
 	"github.com/tmstorm/invgo/endpoints"
 	"github.com/tmstorm/invgo/scopes"
 )
@@ -27,6 +29,55 @@ func TestIncidentGet(t *testing.T) {
 	a.Equal(incs, resp)
 }
 
+// TestIncidentGetCassette decodes a recorded /incident response. To record it again set
+// INVGO_CASSETTE=record and the INVGO_ environment variables of an instance with incident 5120.
+func TestIncidentGetCassette(t *testing.T) {
+	a := assert.New(t)
+	rec := cassette.ForTest(t, "incident_get")
+
+	cfg := &invgo.Invgate{
+		BaseURL:      "https://invgate.example.com",
+		TokenURL:     "https://invgate.example.com/oauth/v2.0/access_token",
+		ClientID:     "invgo",
+		ClientSecret: "invgo",
+		Scopes:       []scopes.ScopeType{scopes.IncidentGet},
+	}
+	if rec.Mode() == cassette.ModeRecord {
+		var err error
+		cfg, err = invgo.LoadConfig(invgo.FromEnv())
+		if !a.NoError(err) {
+			return
+		}
+		cfg.Scopes = []scopes.ScopeType{scopes.IncidentGet}
+	}
+	cfg.Transport = rec
+
+	c, err := invgo.New(cfg)
+	if !a.NoError(err) {
+		return
+	}
+	incs, err := c.Incident().Get(endpoints.IncidentGetParams{ID: 5120, Comments: true})
+	if !a.NoError(err) || !a.Len(incs, 1) {
+		return
+	}
+
+	inc := incs[0]
+	a.Equal("Printer on fire", inc.Title)
+	a.Equal("#5120", inc.PrettyID)
+	a.Zero(inc.ClosedAt)
+	a.Equal("on_time", inc.SLAIncidentResolution)
+
+	floor, ok := inc.CustomFields.Get(18)
+	if a.True(ok) {
+		a.Equal("Floor 3", floor.String())
+	}
+
+	if a.Len(inc.Comments, 1) {
+		a.Equal(1, inc.Comments[0].CustomerVisible)
+		a.Equal(5120, inc.Comments[0].IncidentID)
+	}
+}
+
 func TestIncidentPost(t *testing.T) {
 	a := assert.New(t)
 
@@ -814,6 +865,12 @@ func TestIncidentsByAgentGet(t *testing.T) {
 	resp, err := c.IncidentsByAgent().Get(endpoints.IncidentsByAgentGetParams{ID: 1})
 	a.NoError(err)
 	a.Equal(body, resp)
+
+	// no request is made when the params are invalid
+	_, err = c.IncidentsByAgent().Get(endpoints.IncidentsByAgentGetParams{Limit: 10})
+	var verr *endpoints.ValidationError
+	a.ErrorAs(err, &verr)
+	a.EqualError(err, "field email, id or username is required")
 }
 
 func TestIncidentsByCIsGet(t *testing.T) {
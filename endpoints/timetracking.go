@@ -6,7 +6,6 @@ import (
 
 	"github.com/tmstorm/invgo/internal/methods"
 	"github.com/tmstorm/invgo/internal/utils"
-	"github.com/tmstorm/invgo/scopes"
 )
 
 type (
@@ -35,15 +34,15 @@ type (
 	TimeTrackingGetParams struct {
 		// Indicate the date format. The available formats are 'iso8601noT' or 'iso8601'. If null,
 		// 'iso8601noT' format is returned, which is ISO-8601 no T (YYYY-mm-dd H:i).
-		DateFormat string `url:"date_format"`
+		DateFormat string `url:"date_format" validate:"oneof=iso8601noT iso8601"`
 		// Ending date and time of the interval (must be in ISO-8601 format).
 		// If it's not specified, the current time will be used.
-		To string `url:"to"`
+		To string `url:"to" validate:"format=iso8601"`
 		// RequestID is required if from parameter is not provided
-		RequestID int `url:"request_id"`
+		RequestID int `url:"request_id" validate:"group=interval"`
 		// From Initial date and time of the interval (must be in ISO-8601 format).
 		// Required if the request_id parameter is not provided.
-		From string `url:"from"`
+		From string `url:"from" validate:"group=interval,format=iso8601"`
 	}
 )
 
@@ -52,8 +51,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#timetracking-Get
 func (w *TimeTrackingMethods) Get(p TimeTrackingGetParams) ([]TimeTrackingGetResponse, error) {
 	r := []TimeTrackingGetResponse{}
-	w.RequiredScope = scopes.TimeTrackingGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return r, err
@@ -98,8 +95,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#timetracking-POST
 func (w *TimeTrackingMethods) Post(p TimeTrackingPostParams) (TimeTrackingPostResponse, error) {
 	r := TimeTrackingPostResponse{}
-	w.RequiredScope = scopes.TimeTrackingPost
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return r, err
@@ -138,8 +133,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#timetracking-DELETE
 func (w *TimeTrackingMethods) Delete(p TimeTrackingDeleteParams) (TimeTrackingDeleteResponse, error) {
 	r := TimeTrackingDeleteResponse{}
-	w.RequiredScope = scopes.TimeTrackingDelete
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return r, err
@@ -186,8 +179,6 @@ type (
 // If no ID or 0 is provided all records will be returned
 func (w *TimeTrackingAttributesCategoryMethods) Get(p TimeTrackingAttributesCategoryGetParams) ([]TimeTrackingAttributesCategoryGetResponse, error) {
 	r := []TimeTrackingAttributesCategoryGetResponse{}
-	w.RequiredScope = scopes.TimeTrackingAttributesCategoryGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return r, err
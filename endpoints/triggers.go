@@ -5,7 +5,6 @@ import (
 
 	"github.com/tmstorm/invgo/internal/methods"
 	"github.com/tmstorm/invgo/internal/utils"
-	"github.com/tmstorm/invgo/scopes"
 )
 
 type (
@@ -18,8 +17,8 @@ type (
 
 	// TriggersGetResponse is used to map an trigger returned from the Invgate API
 	TriggersGetResponse struct {
-		TriggerName string `url:"trigger_name"`
-		ID          int    `url:"id"`
+		TriggerName string `json:"trigger_name,omitempty"`
+		ID          int    `json:"id,omitempty"`
 	}
 )
 
@@ -27,8 +26,6 @@ type (
 // Requires scope: TriggersGet
 // See https://releases.invgate.com/service-desk/api/#triggers-GET
 func (c *TriggersMethods) Get(p TriggersGetParams) ([]TriggersGetResponse, error) {
-	c.RequiredScope = scopes.TriggersGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return nil, err
@@ -55,10 +52,10 @@ type (
 
 	// TriggersExecutionsGetResponse is used to map an trigger returned from the Invgate API
 	TriggersExecutionsGetResponse struct {
-		ExecutedAt  int `url:"executed_at"`
-		RequestedID int `url:"requested_id"`
-		TriggerID   int `url:"trigger_id"`
-		ID          int `url:"id"`
+		ExecutedAt  int `json:"executed_at,omitempty"`
+		RequestedID int `json:"requested_id,omitempty"`
+		TriggerID   int `json:"trigger_id,omitempty"`
+		ID          int `json:"id,omitempty"`
 	}
 )
 
@@ -66,8 +63,6 @@ type (
 // Requires scope: TriggersExecutionsGet
 // See https://releases.invgate.com/service-desk/api/#triggersexecutions-GET
 func (c *TriggersExecutionsMethods) Get(p TriggersGetParams) ([]TriggersExecutionsGetResponse, error) {
-	c.RequiredScope = scopes.TriggersExecutionsGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return nil, err
@@ -1,6 +1,7 @@
 package endpoints_test
 
 import (
+	"encoding/json"
 	"net/http"
 	"testing"
 
@@ -45,3 +46,24 @@ func TestTriggersExecutionsGet(t *testing.T) {
 	a.NoError(err)
 	a.EqualValues(trigs, got)
 }
+
+// TestTriggersDecode decodes the documented Invgate responses so the json tags are checked
+// against the real field names instead of a round trip of the response types
+func TestTriggersDecode(t *testing.T) {
+	a := assert.New(t)
+
+	server := newTestServer(t, http.MethodGet, "/triggers", json.RawMessage(`[{"trigger_name":"Escalate","id":12}]`))
+	c := newTestClient(t, server, scopes.TriggersGet)
+
+	trigs, err := c.Triggers().Get(endpoints.TriggersGetParams{})
+	a.NoError(err)
+	a.Equal([]endpoints.TriggersGetResponse{{TriggerName: "Escalate", ID: 12}}, trigs)
+
+	server = newTestServer(t, http.MethodGet, "/triggers.executions",
+		json.RawMessage(`[{"executed_at":1735689600,"requested_id":5,"trigger_id":12,"id":40}]`))
+	c = newTestClient(t, server, scopes.TriggersExecutionsGet)
+
+	execs, err := c.TriggersExecutions().Get(endpoints.TriggersGetParams{TriggerID: 12})
+	a.NoError(err)
+	a.Equal([]endpoints.TriggersExecutionsGetResponse{{ExecutedAt: 1735689600, RequestedID: 5, TriggerID: 12, ID: 40}}, execs)
+}
@@ -6,7 +6,6 @@ import (
 
 	"github.com/tmstorm/invgo/internal/methods"
 	"github.com/tmstorm/invgo/internal/utils"
-	"github.com/tmstorm/invgo/scopes"
 )
 
 type (
@@ -61,8 +60,6 @@ type (
 func (c *UserMethods) Get(p UserGetParams) (UserGetResponse, error) {
 	u := UserGetResponse{}
 
-	c.RequiredScope = scopes.UserGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return u, err
@@ -111,8 +108,6 @@ type (
 func (c *UserMethods) Put(p UserPutParams) (UserPutResponse, error) {
 	u := UserPutResponse{}
 
-	c.RequiredScope = scopes.UserPut
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return u, err
@@ -155,8 +150,6 @@ type (
 func (c *UserMethods) Post(p UserPostParams) (UserPostResponse, error) {
 	u := UserPostResponse{}
 
-	c.RequiredScope = scopes.UserPost
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return u, err
@@ -198,8 +191,6 @@ type (
 func (c *UserMethods) Delete(p UserDeleteParams) ([]UserDeleteResponse, error) {
 	u := []UserDeleteResponse{}
 
-	c.RequiredScope = scopes.UserDelete
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return u, err
@@ -239,8 +230,6 @@ type (
 func (c *UserByMethods) Get(p UserByGetParams) (UserByGetResponse, error) {
 	u := UserByGetResponse{}
 
-	c.RequiredScope = scopes.UserByGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return u, err
@@ -282,8 +271,6 @@ type (
 func (c *UserConvertMethods) Post(p UserConvertPostParams) (UserConvertPostResponse, error) {
 	u := UserConvertPostResponse{}
 
-	c.RequiredScope = scopes.UserConvertPost
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return u, err
@@ -329,8 +316,6 @@ type (
 func (c *UserDisableMethods) Put(p UserDisablePutParams) (UserDisablePutResponse, error) {
 	u := UserDisablePutResponse{}
 
-	c.RequiredScope = scopes.UserDisablePut
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return u, err
@@ -376,8 +361,6 @@ type (
 func (c *UserEnableMethods) Put(p UserEnablePutParams) (UserEnablePutResponse, error) {
 	u := UserEnablePutResponse{}
 
-	c.RequiredScope = scopes.UserEnablePut
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return u, err
@@ -426,8 +409,6 @@ type (
 func (c *UserPasswordMethods) Put(p UserPasswordPutParams) (UserPasswordPutResponse, error) {
 	u := UserPasswordPutResponse{}
 
-	c.RequiredScope = scopes.UserPasswordPut
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return u, err
@@ -457,7 +438,7 @@ type (
 
 	UserPasswordResetPostParams struct {
 		// Type only accepts 'NEW_USER' and 'RESET_PASSWORD'
-		Type string `url:"type,required"`
+		Type string `url:"type,required" validate:"oneof=NEW_USER RESET_PASSWORD"`
 		ID   int    `url:"id,required"`
 	}
 
@@ -478,8 +459,6 @@ type (
 func (c *UserPasswordResetMethods) Post(p UserPasswordResetPostParams) (UserPasswordResetPostResponse, error) {
 	u := UserPasswordResetPostResponse{}
 
-	c.RequiredScope = scopes.UserPasswordResetPost
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return u, err
@@ -524,8 +503,6 @@ type (
 func (c *UserTokenMethods) Post(p UserTokenPostParams) (UserTokenPostResponse, error) {
 	u := UserTokenPostResponse{}
 
-	c.RequiredScope = scopes.UserTokenPost
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return u, err
@@ -565,8 +542,6 @@ type (
 func (c *UsersMethods) Get(p UsersGetParams) ([]UsersGetResponse, error) {
 	u := []UsersGetResponse{}
 
-	c.RequiredScope = scopes.UsersGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return u, err
@@ -624,8 +599,6 @@ type (
 func (c *UsersByMethods) Get(p UsersByGetParams) (UsersByGetResponse, error) {
 	u := UsersByGetResponse{}
 
-	c.RequiredScope = scopes.UsersByGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return u, err
@@ -707,8 +680,6 @@ type (
 func (c *UsersGroupsMethods) Get(p UsersGroupsGetParams) ([]UsersGroupsGetResponse, error) {
 	u := []UsersGroupsGetResponse{}
 
-	c.RequiredScope = scopes.UsersGroupsGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return u, err
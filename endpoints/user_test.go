@@ -2,6 +2,7 @@ package endpoints_test
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/brianvoe/gofakeit/v7"
@@ -200,6 +201,25 @@ func TestUserPasswordResetPost(t *testing.T) {
 	a.Equal(u.Status, gotErr.Status)
 }
 
+func TestUserPasswordResetPostInvalid(t *testing.T) {
+	a := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server, scopes.UserPasswordResetPost)
+
+	_, err := c.UserPasswordReset().Post(endpoints.UserPasswordResetPostParams{Type: "NEW"})
+	var verr *endpoints.ValidationError
+	a.ErrorAs(err, &verr)
+	a.Equal([]endpoints.FieldError{
+		{Field: "type", Rule: "oneof", Message: "must be one of NEW_USER, RESET_PASSWORD"},
+		{Field: "id", Rule: "required", Message: "is required"},
+	}, verr.Fields)
+}
+
 func TestUserTokenPost(t *testing.T) {
 	a := assert.New(t)
 	var u endpoints.UserTokenPostResponse
@@ -5,7 +5,6 @@ import (
 
 	"github.com/tmstorm/invgo/internal/methods"
 	"github.com/tmstorm/invgo/internal/utils"
-	"github.com/tmstorm/invgo/scopes"
 )
 
 // WorkflowDeployMethods is used to call methods for ServiceDeskVersionMethods
@@ -32,8 +31,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#wfdeploy-PUT
 func (w *WorkflowDeployMethods) Put(p WorkflowDeployPutParams) (WorkflowDeployPutResponse, error) {
 	wf := WorkflowDeployPutResponse{}
-	w.RequiredScope = scopes.WorkflowDeployPut
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return wf, err
@@ -74,8 +71,6 @@ type (
 // See https://releases.invgate.com/service-desk/api/#wfinitialfieldsbycategory-GET
 func (w *WorkflowInitialFieldsByCategoryMethods) Get(p WorkflowInitialFieldsByCategoryGetParams) (WorkflowInitialFieldsByCategoryGetResponse, error) {
 	wf := WorkflowInitialFieldsByCategoryGetResponse{}
-	w.RequiredScope = scopes.WorkflowInitialFieldsByCategoryGet
-
 	q, err := utils.StructToQuery(p)
 	if err != nil {
 		return wf, err
@@ -0,0 +1,174 @@
+package invgo
+
+import (
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/tmstorm/invgo/endpoints"
+)
+
+// DefaultEnrichUserBatchSize defines how many user IDs are requested per call to /users
+// when EnrichOptions.UserBatchSize is not set
+var DefaultEnrichUserBatchSize = 100
+
+type (
+	// EnrichedIncident wraps an endpoints.Incident with the names and records its IDs refer to
+	EnrichedIncident struct {
+		endpoints.Incident
+		StatusName   string `json:"status_name,omitempty"`
+		PriorityName string `json:"priority_name,omitempty"`
+		TypeName     string `json:"type_name,omitempty"`
+		SourceName   string `json:"source_name,omitempty"`
+		CategoryName string `json:"category_name,omitempty"`
+		// HelpDeskName is the name of the help desk the incident is assigned to (AssignedGroupID)
+		HelpDeskName string `json:"helpdesk_name,omitempty"`
+		// Assignee is the user the incident is assigned to (AssignedID).
+		// It is nil if the incident is not assigned or the user could not be found.
+		Assignee *endpoints.UsersGetResponse `json:"assignee,omitempty"`
+		// Customer is the user the incident belongs to (UserID).
+		// It is nil if the user could not be found.
+		Customer *endpoints.UsersGetResponse `json:"customer,omitempty"`
+		// IncidentComments are only populated if EnrichOptions.Comments is true
+		IncidentComments []endpoints.IncidentCommentGetResponse `json:"incident_comments,omitempty"`
+	}
+
+	// EnrichOptions is used to configure Catalog.Enrich
+	EnrichOptions struct {
+		// Comments fetches each incidents comments from /incident.comment
+		Comments bool
+		// UserBatchSize is the max amount of user IDs requested per call to /users.
+		// If 0 DefaultEnrichUserBatchSize is used.
+		UserBatchSize int
+		// Concurrency is the max amount of comment requests made at once. If 0, 4 is used.
+		Concurrency int
+	}
+)
+
+// Enrich resolves the attribute names, users and optionally comments for every incident provided.
+// Users are deduplicated across all incidents and requested in batches, and comments are
+// requested once per unique incident.
+//
+// Requires scopes: all scopes required by Catalog, UsersGet and IncidentCommentGet if comments are requested
+func (c *Catalog) Enrich(incs []endpoints.Incident, opts EnrichOptions) ([]EnrichedIncident, error) {
+	if err := c.refreshIfExpired(catalogKinds...); err != nil {
+		return nil, err
+	}
+
+	users, err := c.loadUsers(incs, opts.UserBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var comments map[int][]endpoints.IncidentCommentGetResponse
+	if opts.Comments {
+		comments, err = c.loadComments(incs, opts.Concurrency)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	enriched := make([]EnrichedIncident, 0, len(incs))
+	for _, inc := range incs {
+		e := EnrichedIncident{
+			Incident:         inc,
+			StatusName:       c.tables["status"].byID[inc.StatusID],
+			PriorityName:     c.tables["priority"].byID[inc.PriorityID],
+			TypeName:         c.tables["type"].byID[inc.TypeID],
+			SourceName:       c.tables["source"].byID[inc.SourceID],
+			CategoryName:     c.tables["category"].byID[inc.CategoryID],
+			HelpDeskName:     c.tables["help desk"].byID[inc.AssignedGroupID],
+			IncidentComments: comments[inc.ID],
+		}
+		if u, ok := users[inc.AssignedID]; ok {
+			e.Assignee = &u
+		}
+		if u, ok := users[inc.UserID]; ok {
+			e.Customer = &u
+		}
+		enriched = append(enriched, e)
+	}
+
+	return enriched, nil
+}
+
+// loadUsers requests every unique assignee and customer in incs in batches of batchSize
+func (c *Catalog) loadUsers(incs []endpoints.Incident, batchSize int) (map[int]endpoints.UsersGetResponse, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultEnrichUserBatchSize
+	}
+
+	var ids []int
+	seen := map[int]struct{}{}
+	for _, inc := range incs {
+		for _, id := range []int{inc.AssignedID, inc.UserID} {
+			if _, ok := seen[id]; id > 0 && !ok {
+				seen[id] = struct{}{}
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	users := map[int]endpoints.UsersGetResponse{}
+	for batch := range slices.Chunk(ids, batchSize) {
+		resp, err := c.client.Users().Get(endpoints.UsersGetParams{IDs: batch, IncludeDisabled: true})
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range resp {
+			users[u.ID] = u
+		}
+	}
+	return users, nil
+}
+
+// loadComments requests the comments for every unique incident in incs
+func (c *Catalog) loadComments(incs []endpoints.Incident, concurrency int) (map[int][]endpoints.IncidentCommentGetResponse, error) {
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	var ids []int
+	seen := map[int]struct{}{}
+	for _, inc := range incs {
+		if _, ok := seen[inc.ID]; inc.ID > 0 && !ok {
+			seen[inc.ID] = struct{}{}
+			ids = append(ids, inc.ID)
+		}
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		errs     []error
+		comments = map[int][]endpoints.IncidentCommentGetResponse{}
+		sem      = make(chan struct{}, concurrency)
+	)
+	for _, id := range ids {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			resp, err := c.client.IncidentComment().Get(endpoints.IncidentCommentGetParams{RequestID: id})
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			comments[id] = resp
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
@@ -0,0 +1,117 @@
+package invgo_test
+
+import (
+	"maps"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/endpoints"
+	"github.com/tmstorm/invgo/invgotest"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+func TestCatalogEnrich(t *testing.T) {
+	a := assert.New(t)
+
+	agent := endpoints.UsersGetResponse{UserGetResponse: endpoints.UserGetResponse{ID: 20, Name: "Agent"}}
+	customer := endpoints.UsersGetResponse{UserGetResponse: endpoints.UserGetResponse{ID: 30, Name: "Customer"}}
+	comment := endpoints.IncidentCommentGetResponse{ID: 1, Message: "hello"}
+
+	routes := maps.Clone(catalogRoutes)
+	routes["/users"] = []endpoints.UsersGetResponse{agent, customer}
+	routes["/incident.comment"] = []endpoints.IncidentCommentGetResponse{comment}
+
+	server := newRoutedServer(t, routes)
+	defer server.Close()
+
+	s := append([]scopes.ScopeType{scopes.UsersGet, scopes.IncidentCommentGet}, catalogScopes...)
+	cat := invgo.NewCatalog(newTestClient(t, server, s...), 0)
+
+	incs := []endpoints.Incident{
+		{ID: 1, StatusID: 5, PriorityID: 3, TypeID: 2, SourceID: 4, CategoryID: 10, AssignedGroupID: 7, AssignedID: 20, UserID: 30},
+		{ID: 2, StatusID: 1, UserID: 30},
+	}
+
+	enriched, err := cat.Enrich(incs, invgo.EnrichOptions{Comments: true})
+	a.NoError(err)
+	a.Len(enriched, 2)
+
+	first := enriched[0]
+	a.Equal("Solved", first.StatusName)
+	a.Equal("High", first.PriorityName)
+	a.Equal("Incident", first.TypeName)
+	a.Equal("Email", first.SourceName)
+	a.Equal("Hardware", first.CategoryName)
+	a.Equal("Service Desk", first.HelpDeskName)
+	a.Equal(&agent, first.Assignee)
+	a.Equal(&customer, first.Customer)
+	a.Equal([]endpoints.IncidentCommentGetResponse{comment}, first.IncidentComments)
+
+	second := enriched[1]
+	a.Equal("New", second.StatusName)
+	a.Nil(second.Assignee)
+	a.Equal(&customer, second.Customer)
+}
+
+func TestCatalogEnrichRequests(t *testing.T) {
+	a := assert.New(t)
+
+	srv := invgotest.NewServer(t)
+	var users []int
+	for range 5 {
+		users = append(users, srv.AddUser(endpoints.UserGetResponse{Name: "User"}))
+	}
+	// the incidents share customers, creators and agents
+	var incs []endpoints.Incident
+	for i := range 6 {
+		id := srv.AddIncident(endpoints.Incident{UserID: users[i%3], CreatorID: users[i%3], AssignedID: users[3+i%2]})
+		inc, _ := srv.Incident(id)
+		incs = append(incs, inc, inc)
+	}
+
+	s := append([]scopes.ScopeType{scopes.UsersGet, scopes.IncidentCommentGet}, catalogScopes...)
+	cat := invgo.NewCatalog(srv.Client(t, s...), 0)
+	enriched, err := cat.Enrich(incs, invgo.EnrichOptions{Comments: true, UserBatchSize: 2})
+	a.NoError(err)
+	a.Len(enriched, 12)
+	for _, e := range enriched {
+		a.NotNil(e.Customer)
+		a.NotNil(e.Assignee)
+	}
+
+	var batches int
+	requested := map[string]int{}
+	comments := map[string]int{}
+	for _, r := range srv.Requests() {
+		if r.Method != http.MethodGet {
+			continue
+		}
+		switch r.Path {
+		case "/users":
+			batches++
+			n := 0
+			for k, ids := range r.Query {
+				if strings.HasPrefix(k, "ids[") {
+					n++
+					requested[ids[0]]++
+				}
+			}
+			a.LessOrEqual(n, 2)
+		case "/incident.comment":
+			comments[r.Query.Get("request_id")]++
+		}
+	}
+	// 5 unique users in batches of 2
+	a.Equal(3, batches)
+	a.Len(requested, 5)
+	for id, n := range requested {
+		a.Equal(1, n, "user %s requested more than once", id)
+	}
+	a.Len(comments, 6)
+	for id, n := range comments {
+		a.Equal(1, n, "comments of incident %s requested more than once", id)
+	}
+}
@@ -0,0 +1,42 @@
+package invgo_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/internal/utils"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+func newTestClient(t *testing.T, server *httptest.Server, scopes ...scopes.ScopeType) *invgo.Client {
+	uri, err := utils.ParseURL(server.URL, "", true)
+	assert.NoError(t, err)
+
+	return &invgo.Client{
+		APIURL:        uri,
+		HTTPClient:    server.Client(),
+		CurrentScopes: scopes,
+	}
+}
+
+// newRoutedServer returns a server that responds to each path in routes with its JSON encoded value
+func newRoutedServer(t *testing.T, routes map[string]any) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := routes[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"not found","status":404}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		b, err := json.Marshal(&response)
+		assert.NoError(t, err)
+
+		w.Write(b)
+	}))
+}
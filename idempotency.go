@@ -0,0 +1,359 @@
+package invgo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tmstorm/invgo/endpoints"
+)
+
+// ErrInvalidIdempotencyKey is returned when an idempotency key is empty or contains
+// characters other than letters, digits, '.', '_' and '-'
+var ErrInvalidIdempotencyKey = errors.New("invalid idempotency key")
+
+// DefaultIdempotentAttempts is the amount of times an incident is posted when
+// IdempotentOptions.Attempts is not set
+var DefaultIdempotentAttempts = 3
+
+// DefaultIdempotentBackoff is the wait before the first retry when IdempotentOptions.Backoff
+// is not set. It doubles after every attempt.
+var DefaultIdempotentBackoff = time.Second
+
+type (
+	// IdempotencyRecord is the incident created for an idempotency key
+	IdempotencyRecord struct {
+		Key string `json:"key"`
+		// RequestID is the ID of the incident. It is 0 until the incident is known to exist.
+		RequestID int `json:"request_id,omitempty"`
+		// AttemptedAt is when the incident was first posted
+		AttemptedAt time.Time `json:"attempted_at"`
+	}
+
+	// IdempotencyStore persists IdempotencyRecords so creation can be retried by another process.
+	// Load must return a nil record and nil error if nothing is stored for key.
+	IdempotencyStore interface {
+		Load(key string) (*IdempotencyRecord, error)
+		Save(rec *IdempotencyRecord) error
+	}
+
+	// FileIdempotencyStore stores each record as a JSON file in Dir.
+	// If Dir is empty the invgo directory in os.UserCacheDir is used.
+	FileIdempotencyStore struct {
+		Dir string
+	}
+
+	// MemoryIdempotencyStore stores records in memory
+	MemoryIdempotencyStore struct {
+		mu      sync.Mutex
+		records map[string]*IdempotencyRecord
+	}
+
+	// IdempotentOptions is used to configure IdempotentIncidents
+	IdempotentOptions struct {
+		// Store persists the incident created for each key. If nil a MemoryIdempotencyStore is used.
+		Store IdempotencyStore
+		// Attempts is the max amount of times an incident is posted. If 0 DefaultIdempotentAttempts is used.
+		Attempts int
+		// Backoff is the wait before the first retry. If 0 DefaultIdempotentBackoff is used.
+		Backoff time.Duration
+	}
+
+	// IdempotentIncidents creates incidents that can safely be retried. Create it with NewIdempotentIncidents.
+	//
+	// The idempotency key is added to the description of the incident as a marker. Before an
+	// incident is posted again the incidents changed in the last hour and the incidents of the
+	// customer are searched for the marker so an incident that was created by a request that
+	// failed is not created twice.
+	//
+	// Requires scopes: IncidentPost, IncidentsLastHourGet and IncidentsByCustomerGet
+	IdempotentIncidents struct {
+		client *Client
+		opts   IdempotentOptions
+	}
+)
+
+// IdempotencyMarker returns the marker added to the description of incidents created with key
+func IdempotencyMarker(key string) string {
+	return "[invgo-key:" + key + "]"
+}
+
+// NewIdempotentIncidents creates IdempotentIncidents for client
+func NewIdempotentIncidents(client *Client, opts IdempotentOptions) *IdempotentIncidents {
+	if opts.Store == nil {
+		opts.Store = &MemoryIdempotencyStore{}
+	}
+	if opts.Attempts <= 0 {
+		opts.Attempts = DefaultIdempotentAttempts
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = DefaultIdempotentBackoff
+	}
+	return &IdempotentIncidents{client: client, opts: opts}
+}
+
+// Create posts p once for key and returns the ID of the incident. If an incident was already
+// created for key its ID is returned without posting p. The marker of key is appended to the
+// description of p.
+//
+// A post that fails with a network error or a 5xx status may have created the incident so it is
+// retried after searching for the marker. If the search fails the error is returned without
+// posting again since the incident may exist. Other errors are returned without retrying.
+func (c *IdempotentIncidents) Create(key string, p endpoints.IncidentPostParams) (int, error) {
+	if err := checkIdempotencyKey(key); err != nil {
+		return 0, err
+	}
+
+	rec, err := c.opts.Store.Load(key)
+	if err != nil {
+		return 0, err
+	}
+	if rec != nil && rec.RequestID != 0 {
+		return rec.RequestID, nil
+	}
+
+	marker := IdempotencyMarker(key)
+	if p.Description == "" {
+		p.Description = marker
+	} else {
+		p.Description += "\n\n" + marker
+	}
+
+	// a previous call posted the incident but did not see the response
+	search := rec != nil
+	if rec == nil {
+		rec = &IdempotencyRecord{Key: key, AttemptedAt: time.Now()}
+		if err := c.opts.Store.Save(rec); err != nil {
+			return 0, err
+		}
+	}
+
+	var lastErr error
+	backoff := c.opts.Backoff
+	for attempt := range c.opts.Attempts {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		if search {
+			id, found, err := c.find(marker, p.CustomerID)
+			if err != nil {
+				return 0, fmt.Errorf("unable to search for incident %s: %w", key, err)
+			}
+			if found {
+				return c.save(rec, id)
+			}
+		}
+
+		resp, err := c.client.Incident().Post(p)
+		if err == nil {
+			if resp.Status == "ERROR" {
+				return 0, fmt.Errorf("unable to create incident %s: invgate returned ERROR: %s", key, resp.Info)
+			}
+			id, err := strconv.Atoi(resp.RequestID)
+			if err != nil {
+				return 0, fmt.Errorf("invalid request id %q: %w", resp.RequestID, err)
+			}
+			return c.save(rec, id)
+		}
+		if !mayHaveCreated(err) {
+			return 0, fmt.Errorf("unable to create incident %s: %w", key, err)
+		}
+		lastErr = err
+		search = true
+	}
+	return 0, fmt.Errorf("unable to create incident %s after %d attempts: %w", key, c.opts.Attempts, lastErr)
+}
+
+// Find returns the ID of the incident created for key. The store is checked first and then
+// the incidents changed in the last hour and the incidents of customerID are searched for the marker.
+// customerID is not searched if it is 0.
+func (c *IdempotentIncidents) Find(key string, customerID int) (int, bool, error) {
+	if err := checkIdempotencyKey(key); err != nil {
+		return 0, false, err
+	}
+
+	rec, err := c.opts.Store.Load(key)
+	if err != nil {
+		return 0, false, err
+	}
+	if rec != nil && rec.RequestID != 0 {
+		return rec.RequestID, true, nil
+	}
+
+	id, found, err := c.find(IdempotencyMarker(key), customerID)
+	if err != nil || !found {
+		return 0, false, err
+	}
+	if rec == nil {
+		rec = &IdempotencyRecord{Key: key}
+	}
+	_, err = c.save(rec, id)
+	return id, true, err
+}
+
+// find searches the incidents changed in the last hour and the incidents of customerID for marker
+func (c *IdempotentIncidents) find(marker string, customerID int) (int, bool, error) {
+	recent, err := c.client.IncidentsLastHour().Get(endpoints.IncidentsLastHourGetParams{})
+	if err != nil {
+		return 0, false, err
+	}
+	for _, r := range recent {
+		if strings.Contains(r.Description, marker) {
+			return r.ID, true, nil
+		}
+	}
+
+	if customerID == 0 {
+		return 0, false, nil
+	}
+	params := endpoints.IncidentsByCustomerGetParams{ID: customerID}
+	for {
+		resp, err := c.client.IncidentsByCustomer().Get(params)
+		if err != nil {
+			return 0, false, err
+		}
+		for id, inc := range resp.Requests {
+			if strings.Contains(inc.Description, marker) {
+				return id, true, nil
+			}
+		}
+		if resp.NextPageKey == "" || resp.NextPageKey == params.PageKey {
+			return 0, false, nil
+		}
+		params.PageKey = resp.NextPageKey
+	}
+}
+
+// mayHaveCreated reports if a post that failed with err may still have created the incident
+func mayHaveCreated(err error) bool {
+	var respErr *ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.StatusCode >= 500
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+func (c *IdempotentIncidents) save(rec *IdempotencyRecord, id int) (int, error) {
+	rec.RequestID = id
+	if err := c.opts.Store.Save(rec); err != nil {
+		return id, fmt.Errorf("incident %d was created but could not be saved for key %s: %w", id, rec.Key, err)
+	}
+	return id, nil
+}
+
+func checkIdempotencyKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("%w: key can not be empty", ErrInvalidIdempotencyKey)
+	}
+	for _, r := range key {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '_', r == '-':
+		default:
+			return fmt.Errorf("%w: %q", ErrInvalidIdempotencyKey, key)
+		}
+	}
+	return nil
+}
+
+// Load reads the record stored for key
+func (s *FileIdempotencyStore) Load(key string) (*IdempotencyRecord, error) {
+	dir, err := s.dir()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, key+".idempotency.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var rec IdempotencyRecord
+	if err := json.Unmarshal(b, &rec); err != nil {
+		return nil, fmt.Errorf("invalid idempotency record: %w", err)
+	}
+	return &rec, nil
+}
+
+// Save writes rec. The file is written to a temporary file first and renamed
+// so a crash never leaves a partially written record.
+func (s *FileIdempotencyStore) Save(rec *IdempotencyRecord) error {
+	if err := checkIdempotencyKey(rec.Key); err != nil {
+		return err
+	}
+	dir, err := s.dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(dir, rec.Key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(dir, rec.Key+".idempotency.json"))
+}
+
+func (s *FileIdempotencyStore) dir() (string, error) {
+	if s.Dir != "" {
+		return s.Dir, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to find idempotency directory: %w", err)
+	}
+	return filepath.Join(dir, "invgo"), nil
+}
+
+// Load returns the record stored for key
+func (s *MemoryIdempotencyStore) Load(key string) (*IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if rec, ok := s.records[key]; ok {
+		cp := *rec
+		return &cp, nil
+	}
+	return nil, nil
+}
+
+// Save stores rec
+func (s *MemoryIdempotencyStore) Save(rec *IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.records == nil {
+		s.records = map[string]*IdempotencyRecord{}
+	}
+	cp := *rec
+	s.records[rec.Key] = &cp
+	return nil
+}
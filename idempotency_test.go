@@ -0,0 +1,149 @@
+package invgo_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/endpoints"
+	"github.com/tmstorm/invgo/invgotest"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+func countPosts(srv *invgotest.Server) int {
+	n := 0
+	for _, r := range srv.Requests() {
+		if r.Method == http.MethodPost && r.Path == "/incident" {
+			n++
+		}
+	}
+	return n
+}
+
+func TestIdempotentIncidents(t *testing.T) {
+	a := assert.New(t)
+
+	srv := invgotest.NewServer(t)
+	customer := srv.AddUser(endpoints.UserGetResponse{Email: "jane@example.com"})
+	c := srv.Client(t, scopes.IncidentPost, scopes.IncidentsLastHourGet, scopes.IncidentsByCustomerGet)
+	store := &invgo.FileIdempotencyStore{Dir: t.TempDir()}
+	ii := invgo.NewIdempotentIncidents(c, invgo.IdempotentOptions{Store: store, Backoff: time.Millisecond})
+
+	p := endpoints.IncidentPostParams{
+		Title:       "Printer on fire",
+		Description: "It is very hot",
+		TypeID:      1,
+		CreatorID:   customer,
+		PriorityID:  1,
+		CustomerID:  customer,
+	}
+
+	id, err := ii.Create("ticket-1", p)
+	a.NoError(err)
+	inc, ok := srv.Incident(id)
+	if a.True(ok) {
+		a.Equal("It is very hot\n\n"+invgo.IdempotencyMarker("ticket-1"), inc.Description)
+	}
+
+	// the stored incident is returned without posting again
+	again, err := ii.Create("ticket-1", p)
+	a.NoError(err)
+	a.Equal(id, again)
+	a.Equal(1, countPosts(srv))
+
+	// the response is lost so the retry finds the incident instead of posting again
+	srv.InjectFault(invgotest.Fault{Path: "/incident", Method: http.MethodPost, Status: http.StatusGatewayTimeout, Times: 1, Lost: true})
+	lost, err := ii.Create("ticket-2", p)
+	a.NoError(err)
+	a.NotZero(lost)
+	a.NotEqual(id, lost)
+	a.Equal(2, countPosts(srv))
+
+	rec, err := store.Load("ticket-2")
+	a.NoError(err)
+	if a.NotNil(rec) {
+		a.Equal(lost, rec.RequestID)
+		a.False(rec.AttemptedAt.IsZero())
+	}
+
+	// a failed post that did not create the incident is posted again
+	srv.InjectFault(invgotest.Fault{Path: "/incident", Method: http.MethodPost, Status: http.StatusServiceUnavailable, Times: 1})
+	_, err = ii.Create("ticket-3", p)
+	a.NoError(err)
+	a.Equal(4, countPosts(srv))
+
+	srv.InjectFault(invgotest.Fault{Path: "/incident", Method: http.MethodPost, Status: http.StatusServiceUnavailable})
+	_, err = ii.Create("ticket-4", p)
+	a.ErrorContains(err, "after 3 attempts")
+	srv.ClearFaults()
+
+	// errors that did not create the incident are returned without retrying
+	posts := countPosts(srv)
+	srv.InjectFault(invgotest.Fault{Path: "/incident", Method: http.MethodPost, Status: http.StatusBadRequest, Times: 1})
+	_, err = ii.Create("ticket-6", p)
+	var respErr *invgo.ResponseError
+	if a.ErrorAs(err, &respErr) {
+		a.Equal(http.StatusBadRequest, respErr.StatusCode)
+	}
+	a.Equal(posts+1, countPosts(srv))
+
+	noScope := invgo.NewIdempotentIncidents(srv.Client(t, scopes.IncidentsLastHourGet), invgo.IdempotentOptions{Backoff: time.Millisecond})
+	_, err = noScope.Create("ticket-7", p)
+	a.Error(err)
+	a.Equal(posts+1, countPosts(srv))
+
+	_, err = ii.Create("ticket 5", p)
+	a.ErrorIs(err, invgo.ErrInvalidIdempotencyKey)
+}
+
+func TestIdempotentIncidentsErrorResponse(t *testing.T) {
+	a := assert.New(t)
+
+	server := newRoutedServer(t, map[string]any{
+		"/incident": endpoints.IncidentPostResponse{Status: "ERROR", Info: "customer_id is not valid"},
+	})
+	defer server.Close()
+
+	c := newTestClient(t, server, scopes.IncidentPost, scopes.IncidentsLastHourGet)
+	ii := invgo.NewIdempotentIncidents(c, invgo.IdempotentOptions{Backoff: time.Millisecond})
+	_, err := ii.Create("ticket-1", endpoints.IncidentPostParams{Title: "Printer on fire", TypeID: 1, CreatorID: 7, PriorityID: 1, CustomerID: 7})
+	a.ErrorContains(err, "customer_id is not valid")
+}
+
+func TestIdempotentIncidentsResume(t *testing.T) {
+	a := assert.New(t)
+
+	srv := invgotest.NewServer(t)
+	customer := srv.AddUser(endpoints.UserGetResponse{Email: "jane@example.com"})
+	// created more than an hour ago by a process that stopped before it saw the response
+	old := srv.Now().Add(-2 * time.Hour).Unix()
+	existing := srv.AddIncident(endpoints.Incident{
+		Title:       "Printer on fire",
+		Description: "It is very hot\n\n" + invgo.IdempotencyMarker("ticket-1"),
+		UserID:      customer,
+		CreatedAt:   int(old),
+		LastUpdate:  int(old),
+	})
+
+	store := &invgo.MemoryIdempotencyStore{}
+	a.NoError(store.Save(&invgo.IdempotencyRecord{Key: "ticket-1", AttemptedAt: time.Unix(old, 0)}))
+
+	c := srv.Client(t, scopes.IncidentPost, scopes.IncidentsLastHourGet, scopes.IncidentsByCustomerGet)
+	ii := invgo.NewIdempotentIncidents(c, invgo.IdempotentOptions{Store: store})
+
+	id, found, err := ii.Find("ticket-1", customer)
+	a.NoError(err)
+	a.True(found)
+	a.Equal(existing, id)
+
+	id, err = ii.Create("ticket-1", endpoints.IncidentPostParams{Title: "Printer on fire", CustomerID: customer})
+	a.NoError(err)
+	a.Equal(existing, id)
+	a.Zero(countPosts(srv))
+
+	_, found, err = ii.Find("ticket-2", customer)
+	a.NoError(err)
+	a.False(found)
+}
@@ -0,0 +1,276 @@
+// Package apigen generates the endpoint interfaces, invgo.API and the invgomock fakes
+// from the Client accessors in endpoint_methods.go and the methods in the endpoints package.
+package apigen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// Output paths of the generated files relative to the module root
+const (
+	EndpointsFile = "endpoints/api_gen.go"
+	APIFile       = "api_gen.go"
+	MockFile      = "invgomock/mock_gen.go"
+)
+
+const header = "// Code generated by go run ./scripts/genapi; DO NOT EDIT.\n\n"
+
+type (
+	// accessor is a Client method returning an endpoint
+	accessor struct {
+		Name string
+		Doc  []string
+		// Type is the endpoint type returned e.g. IncidentMethods
+		Type string
+	}
+
+	// endpoint is a type in the endpoints package with methods
+	endpoint struct {
+		Type    string
+		Methods []method
+	}
+
+	method struct {
+		Name    string
+		Params  []field
+		Results []string
+		// the same values qualified with the endpoints package
+		QParams  []field
+		QResults []string
+	}
+
+	field struct {
+		Name string
+		Type string
+	}
+)
+
+// Interface returns the interface name for the endpoint type e.g. IncidentMethods becomes IncidentAPI
+func (e endpoint) Interface() string {
+	return strings.TrimSuffix(e.Type, "Methods") + "API"
+}
+
+// Interface returns the interface name for the endpoint the accessor returns
+func (a accessor) Interface() string {
+	return endpoint{Type: a.Type}.Interface()
+}
+
+// Generate returns the generated files for the module at root keyed by their path relative to root
+func Generate(root string) (map[string][]byte, error) {
+	accessors, err := parseAccessors(filepath.Join(root, "endpoint_methods.go"))
+	if err != nil {
+		return nil, err
+	}
+	eps, err := parseEndpoints(filepath.Join(root, "endpoints"))
+	if err != nil {
+		return nil, err
+	}
+
+	// only endpoints returned by an accessor are part of the API
+	used := map[string]bool{}
+	for _, a := range accessors {
+		if _, ok := eps[a.Type]; !ok {
+			return nil, fmt.Errorf("accessor %s returns %s which has no methods", a.Name, a.Type)
+		}
+		used[a.Type] = true
+	}
+	var endpoints []endpoint
+	for t, ep := range eps {
+		if used[t] {
+			endpoints = append(endpoints, ep)
+		}
+	}
+	sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].Type < endpoints[j].Type })
+
+	data := struct {
+		Accessors []accessor
+		Endpoints []endpoint
+	}{accessors, endpoints}
+
+	files := map[string][]byte{}
+	for path, tmpl := range map[string]*template.Template{
+		EndpointsFile: endpointsTmpl,
+		APIFile:       apiTmpl,
+		MockFile:      mockTmpl,
+	} {
+		var buf bytes.Buffer
+		buf.WriteString(header)
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, err
+		}
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("unable to format %s: %w", path, err)
+		}
+		files[path] = src
+	}
+	return files, nil
+}
+
+// parseAccessors returns every Client method in path that returns a *endpoints type
+func parseAccessors(path string) ([]accessor, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	var accessors []accessor
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || !fn.Name.IsExported() || receiver(fn) != "Client" {
+			continue
+		}
+		if fn.Type.Params.NumFields() != 0 || fn.Type.Results.NumFields() != 1 {
+			continue
+		}
+		star, ok := fn.Type.Results.List[0].Type.(*ast.StarExpr)
+		if !ok {
+			continue
+		}
+		sel, ok := star.X.(*ast.SelectorExpr)
+		if !ok || exprString(sel.X, "", nil) != "endpoints" {
+			continue
+		}
+
+		a := accessor{Name: fn.Name.Name, Type: sel.Sel.Name}
+		if fn.Doc != nil {
+			for _, c := range fn.Doc.List {
+				a.Doc = append(a.Doc, c.Text)
+			}
+		}
+		accessors = append(accessors, a)
+	}
+	return accessors, nil
+}
+
+// parseEndpoints returns the exported methods of every *Methods type in dir
+func parseEndpoints(dir string) (map[string]endpoint, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, path := range paths {
+		name := filepath.Base(path)
+		if strings.HasSuffix(name, "_test.go") || name == filepath.Base(EndpointsFile) {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	// types declared in the package are qualified when used outside of it
+	declared := map[string]bool{}
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.TYPE {
+				for _, spec := range gen.Specs {
+					declared[spec.(*ast.TypeSpec).Name.Name] = true
+				}
+			}
+		}
+	}
+
+	eps := map[string]endpoint{}
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || !fn.Name.IsExported() {
+				continue
+			}
+			t := receiver(fn)
+			if !strings.HasSuffix(t, "Methods") {
+				continue
+			}
+
+			m := method{Name: fn.Name.Name}
+			for i, p := range fn.Type.Params.List {
+				names := p.Names
+				if len(names) == 0 {
+					names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("p%d", i))}
+				}
+				for _, n := range names {
+					m.Params = append(m.Params, field{n.Name, exprString(p.Type, "", declared)})
+					m.QParams = append(m.QParams, field{n.Name, exprString(p.Type, "endpoints.", declared)})
+				}
+			}
+			if fn.Type.Results != nil {
+				for _, r := range fn.Type.Results.List {
+					for range max(len(r.Names), 1) {
+						m.Results = append(m.Results, exprString(r.Type, "", declared))
+						m.QResults = append(m.QResults, exprString(r.Type, "endpoints.", declared))
+					}
+				}
+			}
+			// the fakes return a zero value and an error
+			if n := len(m.Results); n == 0 || n > 2 || m.Results[n-1] != "error" {
+				return nil, fmt.Errorf("%s.%s must return (T, error) or error", t, m.Name)
+			}
+
+			ep := eps[t]
+			ep.Type = t
+			ep.Methods = append(ep.Methods, m)
+			eps[t] = ep
+		}
+	}
+
+	for t, ep := range eps {
+		sort.Slice(ep.Methods, func(i, j int) bool { return ep.Methods[i].Name < ep.Methods[j].Name })
+		eps[t] = ep
+	}
+	return eps, nil
+}
+
+// receiver returns the name of the type of a method receiver
+func receiver(fn *ast.FuncDecl) string {
+	t := fn.Recv.List[0].Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	if id, ok := t.(*ast.Ident); ok {
+		return id.Name
+	}
+	return ""
+}
+
+// exprString prints a type expression adding qualifier to the types in declared
+func exprString(e ast.Expr, qualifier string, declared map[string]bool) string {
+	switch t := e.(type) {
+	case *ast.Ident:
+		if declared[t.Name] {
+			return qualifier + t.Name
+		}
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + exprString(t.X, qualifier, declared)
+	case *ast.ArrayType:
+		if t.Len != nil {
+			return "[" + exprString(t.Len, qualifier, declared) + "]" + exprString(t.Elt, qualifier, declared)
+		}
+		return "[]" + exprString(t.Elt, qualifier, declared)
+	case *ast.MapType:
+		return "map[" + exprString(t.Key, qualifier, declared) + "]" + exprString(t.Value, qualifier, declared)
+	case *ast.SelectorExpr:
+		return exprString(t.X, "", nil) + "." + t.Sel.Name
+	case *ast.BasicLit:
+		return t.Value
+	case *ast.InterfaceType:
+		return "any"
+	default:
+		panic(fmt.Sprintf("apigen: unsupported type expression %T", e))
+	}
+}
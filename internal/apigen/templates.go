@@ -0,0 +1,135 @@
+package apigen
+
+import (
+	"strings"
+	"text/template"
+)
+
+var funcs = template.FuncMap{
+	"params": func(fs []field) string {
+		var s []string
+		for _, f := range fs {
+			s = append(s, f.Name+" "+f.Type)
+		}
+		return strings.Join(s, ", ")
+	},
+	"args": func(fs []field) string {
+		var s []string
+		for _, f := range fs {
+			s = append(s, f.Name)
+		}
+		return strings.Join(s, ", ")
+	},
+	"results": func(rs []string) string {
+		if len(rs) == 1 {
+			return rs[0]
+		}
+		return "(" + strings.Join(rs, ", ") + ")"
+	},
+}
+
+var endpointsTmpl = template.Must(template.New("endpoints").Funcs(funcs).Parse(`package endpoints
+
+// Interfaces implemented by the endpoint types returned by the invgo.Client accessors.
+// Code that depends on these instead of the concrete types can be tested with invgomock.
+type (
+{{- range .Endpoints}}
+	// {{.Interface}} is implemented by *{{.Type}}
+	{{.Interface}} interface {
+	{{- range .Methods}}
+		{{.Name}}({{params .Params}}) {{results .Results}}
+	{{- end}}
+	}
+{{end -}}
+)
+
+var (
+{{- range .Endpoints}}
+	_ {{.Interface}} = (*{{.Type}})(nil)
+{{- end}}
+)
+`))
+
+var apiTmpl = template.Must(template.New("api").Funcs(funcs).Parse(`package invgo
+
+import "github.com/tmstorm/invgo/endpoints"
+
+// API exposes every endpoint accessor of Client as an interface so it can be replaced in tests.
+// Use Client.API to get the API of a Client and invgomock.New for a fake.
+type API interface {
+{{- range .Accessors}}
+	{{range .Doc}}{{.}}
+	{{end -}}
+	{{.Name}}() endpoints.{{.Interface}}
+{{- end}}
+}
+
+// clientAPI implements API using a Client
+type clientAPI struct{ c *Client }
+
+// API returns the Client as an API
+func (c *Client) API() API {
+	return clientAPI{c}
+}
+{{range .Accessors}}
+func (a clientAPI) {{.Name}}() endpoints.{{.Interface}} { return a.c.{{.Name}}() }
+{{end}}
+`))
+
+var mockTmpl = template.Must(template.New("mock").Funcs(funcs).Parse(`package invgomock
+
+import (
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/endpoints"
+)
+
+// API is a fake invgo.API. Each accessor returns the fake in its Mock field.
+type API struct {
+{{- range .Accessors}}
+	{{.Name}}Mock *{{.Interface}}
+{{- end}}
+}
+
+var _ invgo.API = (*API)(nil)
+
+// New returns an API with a fake for every endpoint
+func New() *API {
+	return &API{
+	{{- range .Accessors}}
+		{{.Name}}Mock: &{{.Interface}}{},
+	{{- end}}
+	}
+}
+{{range .Accessors}}
+// {{.Name}} returns {{.Name}}Mock
+func (m *API) {{.Name}}() endpoints.{{.Interface}} { return m.{{.Name}}Mock }
+{{end}}
+{{- range .Endpoints}}
+{{- $iface := .Interface}}
+// {{$iface}} is a fake endpoints.{{$iface}}. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type {{$iface}} struct {
+	recorder
+{{- range .Methods}}
+	{{.Name}}Func func({{params .QParams}}) {{results .QResults}}
+{{- end}}
+}
+
+var _ endpoints.{{$iface}} = (*{{$iface}})(nil)
+{{range .Methods}}
+// {{.Name}} records the call and calls {{.Name}}Func
+func (m *{{$iface}}) {{.Name}}({{params .QParams}}) {{results .QResults}} {
+	m.record("{{.Name}}"{{range .QParams}}, {{.Name}}{{end}})
+	if m.{{.Name}}Func == nil {
+		{{- if gt (len .QResults) 1}}
+		var r {{index .QResults 0}}
+		return r, notImplemented("{{$iface}}.{{.Name}}")
+		{{- else}}
+		return notImplemented("{{$iface}}.{{.Name}}")
+		{{- end}}
+	}
+	return m.{{.Name}}Func({{args .QParams}})
+}
+{{end}}
+{{- end}}
+`))
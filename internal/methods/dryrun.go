@@ -0,0 +1,81 @@
+package methods
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/tmstorm/invgo/scopes"
+)
+
+// DefaultDryRunResponse is returned for writes recorded by a Plan when no response is set for the endpoint
+var DefaultDryRunResponse = []byte(`{"status":"OK","info":"dry run"}`)
+
+// dryRunResponses are the responses of endpoints that do not return an object
+var dryRunResponses = map[string][]byte{
+	http.MethodPut + " /incident":  []byte(`[]`),
+	http.MethodDelete + " /user":   []byte(`[]`),
+	http.MethodPost + " /incident": []byte(`{"request_id":"0","info":"dry run","status":"OK"}`),
+}
+
+type (
+	// PlannedRequest is a write that was recorded by a Plan instead of being sent to Invgate
+	PlannedRequest struct {
+		// Method is the HTTP method of the request
+		Method string `json:"method"`
+		// Endpoint is the path of the endpoint relative to the API path e.g. /incident
+		Endpoint string `json:"endpoint"`
+		// Scope is the scope required by the request
+		Scope scopes.ScopeType `json:"scope"`
+		// Params are the encoded query parameters of the request
+		Params url.Values `json:"params"`
+	}
+
+	// Plan records the POST, PUT, PATCH and DELETE requests of a Client instead of sending them.
+	// GET requests are still sent. The zero value is ready to use.
+	Plan struct {
+		// Responses overrides the synthetic response returned for an endpoint.
+		// It is keyed by method and endpoint e.g. "POST /incident".
+		Responses map[string][]byte
+
+		mu       sync.Mutex
+		requests []PlannedRequest
+	}
+)
+
+// Requests returns the requests recorded so far in the order they were made
+func (p *Plan) Requests() []PlannedRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]PlannedRequest{}, p.requests...)
+}
+
+// Reset removes every recorded request
+func (p *Plan) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requests = nil
+}
+
+// record adds the request to the plan and returns its synthetic response
+func (p *Plan) record(methodType string, m *MethodCall, scope scopes.ScopeType) []byte {
+	r := PlannedRequest{
+		Method:   methodType,
+		Endpoint: m.path(),
+		Scope:    scope,
+		Params:   m.Endpoint.Query(),
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requests = append(p.requests, r)
+
+	key := r.Method + " " + r.Endpoint
+	if resp, ok := p.Responses[key]; ok {
+		return resp
+	}
+	if resp, ok := dryRunResponses[key]; ok {
+		return resp
+	}
+	return DefaultDryRunResponse
+}
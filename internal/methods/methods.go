@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/tmstorm/invgo/scopes"
 )
@@ -19,8 +20,10 @@ type (
 		Client *Client
 		// Endpoint is the URl of the end point to be called in the invoked method
 		Endpoint *url.URL
-		// RequiredScope is used to set the required scope be the method that is calling it
-		// This must be set or invgo will throw an error when making request
+		// RequiredScope is the scope checked before the request is made. Endpoint methods do
+		// not set it, the scope registered for the endpoint in scopes/registry.go is used.
+		// It only needs to be set for endpoints that are not registered.
+		// If neither exist invgo will throw an error when making request
 		RequiredScope scopes.ScopeType
 	}
 
@@ -32,6 +35,8 @@ type (
 		CurrentScopes []scopes.ScopeType
 		// APIURL is used to se the BaseURL URL for connecting to an API Invgate instance
 		APIURL *url.URL
+		// Plan records write requests instead of sending them when set. See Plan.
+		Plan *Plan
 	}
 
 	// InvgateError is used to construct an error received from the Invgate API
@@ -39,8 +44,19 @@ type (
 		Error  string `json:"error,omitempty"`
 		Status int    `json:"status,omitempty"`
 	}
+
+	// ResponseError is returned when Invgate responds with a status other than 200
+	ResponseError struct {
+		// StatusCode is the HTTP status code of the response
+		StatusCode int
+		InvgateError
+	}
 )
 
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("%v status: %v", e.InvgateError.Error, e.Status)
+}
+
 // RemoteGet is the underlying GET method called when making a GET request to Invgate
 func (m *MethodCall) RemoteGet() ([]byte, error) { return m.get() }
 
@@ -83,10 +99,21 @@ func (m *MethodCall) delete() ([]byte, error) {
 
 // methodConstructor is used to build and call all internal methods the the Invgate API
 func methodConstructor(methodType string, m *MethodCall, body io.Reader) ([]byte, error) {
-	if err := scopes.CheckScopes(m.Client.CurrentScopes, m.RequiredScope); err != nil {
+	// the scope is looked up on every call since the MethodCall is reused for every method
+	// of the endpoint
+	scope := m.RequiredScope
+	if scope == "" {
+		scope = m.registeredScope(methodType)
+	}
+
+	if err := scopes.CheckScopes(m.Client.CurrentScopes, scope); err != nil {
 		return nil, err
 	}
 
+	if m.Client.Plan != nil && methodType != http.MethodGet {
+		return m.Client.Plan.record(methodType, m, scope), nil
+	}
+
 	req, err := http.NewRequest(methodType, m.Endpoint.String(), body)
 	if err != nil {
 		return nil, err
@@ -100,6 +127,25 @@ func methodConstructor(methodType string, m *MethodCall, body io.Reader) ([]byte
 	return checkErrorResponse(resp)
 }
 
+// registeredScope looks up the scope registered for the endpoint being called
+func (m *MethodCall) registeredScope(methodType string) scopes.ScopeType {
+	if m.Client.APIURL == nil {
+		return ""
+	}
+
+	s, _ := scopes.Lookup(m.path(), methodType)
+	return s
+}
+
+// path returns the path of the endpoint being called relative to the API path
+func (m *MethodCall) path() string {
+	path := m.Endpoint.Path
+	if m.Client.APIURL != nil {
+		path = strings.TrimPrefix(path, m.Client.APIURL.Path)
+	}
+	return "/" + strings.TrimPrefix(path, "/")
+}
+
 // checkErrorResponse is used to check for errors from the Invgate API
 func checkErrorResponse(r *http.Response) ([]byte, error) {
 	body, err := io.ReadAll(r.Body)
@@ -109,12 +155,12 @@ func checkErrorResponse(r *http.Response) ([]byte, error) {
 	defer r.Body.Close()
 
 	if r.StatusCode != 200 {
-		var m InvgateError
-		err = json.Unmarshal(body, &m)
-		if err != nil {
-			return nil, err
+		e := &ResponseError{StatusCode: r.StatusCode}
+		// the body is not JSON when the error comes from a proxy in front of Invgate
+		if err := json.Unmarshal(body, &e.InvgateError); err != nil {
+			e.InvgateError = InvgateError{Error: http.StatusText(r.StatusCode), Status: r.StatusCode}
 		}
-		return nil, fmt.Errorf("%v status: %v", m.Error, m.Status)
+		return nil, e
 	}
 	return body, nil
 }
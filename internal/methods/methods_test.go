@@ -164,7 +164,11 @@ func TestRemoteGetError(t *testing.T) {
 	}
 
 	_, err = m.RemoteGet()
-	a.Error(err)
+	var respErr *methods.ResponseError
+	if a.ErrorAs(err, &respErr) {
+		a.Equal(501, respErr.StatusCode)
+		a.Equal("It broke like it should status: 501", err.Error())
+	}
 }
 
 func newTestServer(t *testing.T, expectedMethod, expectedPath string, status int, response any) *httptest.Server {
@@ -179,3 +183,132 @@ func newTestServer(t *testing.T, expectedMethod, expectedPath string, status int
 		w.Write(b)
 	}))
 }
+
+func TestRemoteGetRegisteredScope(t *testing.T) {
+	a := assert.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	api, err := url.Parse(server.URL + "/api/v1")
+	a.NoError(err)
+
+	// RequiredScope is not set so the registered scope for the endpoint is used
+	m := &methods.MethodCall{
+		Client: &methods.Client{
+			HTTPClient:    server.Client(),
+			CurrentScopes: []scopes.ScopeType{scopes.IncidentAttributesStatusGet},
+			APIURL:        api,
+		},
+		Endpoint: api.JoinPath("/incident.attributes.status"),
+	}
+
+	_, err = m.RemoteGet()
+	a.NoError(err)
+	a.Empty(m.RequiredScope)
+}
+
+func TestReusedMethodCallScope(t *testing.T) {
+	a := assert.New(t)
+	var methodsSent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methodsSent = append(methodsSent, r.Method)
+		w.WriteHeader(200)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	api, err := url.Parse(server.URL + "/api/v1")
+	a.NoError(err)
+
+	// the scope of the GET is not used to check the POST made with the same MethodCall
+	client := &methods.Client{
+		HTTPClient:    server.Client(),
+		CurrentScopes: []scopes.ScopeType{scopes.IncidentGet},
+		APIURL:        api,
+	}
+	m := &methods.MethodCall{Client: client, Endpoint: api.JoinPath("/incident")}
+	_, err = m.RemoteGet()
+	a.NoError(err)
+	_, err = m.RemotePost()
+	a.Error(err)
+	a.Equal([]string{http.MethodGet}, methodsSent)
+
+	// a dry run records the scope of the POST
+	plan := &methods.Plan{}
+	client.Plan = plan
+	client.CurrentScopes = append(client.CurrentScopes, scopes.IncidentPost)
+	_, err = m.RemoteGet()
+	a.NoError(err)
+	_, err = m.RemotePost()
+	a.NoError(err)
+	if reqs := plan.Requests(); a.Len(reqs, 1) {
+		a.Equal(scopes.IncidentPost, reqs[0].Scope)
+	}
+}
+
+func TestDryRunPlan(t *testing.T) {
+	a := assert.New(t)
+	var methodsSent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methodsSent = append(methodsSent, r.Method)
+		w.WriteHeader(200)
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	api, err := url.Parse(server.URL + "/api/v1")
+	a.NoError(err)
+
+	plan := &methods.Plan{Responses: map[string][]byte{"DELETE /user": []byte(`[{"status":"deleted"}]`)}}
+	client := &methods.Client{
+		HTTPClient:    server.Client(),
+		CurrentScopes: []scopes.ScopeType{scopes.IncidentPost, scopes.IncidentGet, scopes.BreakingNewsPut},
+		APIURL:        api,
+		Plan:          plan,
+	}
+
+	call := func(path, query string) *methods.MethodCall {
+		ep := api.JoinPath(path)
+		ep.RawQuery = query
+		return &methods.MethodCall{Client: client, Endpoint: ep}
+	}
+
+	resp, err := call("/incident", "title=Printer&type_id=1").RemotePost()
+	a.NoError(err)
+	a.JSONEq(`{"request_id":"0","info":"dry run","status":"OK"}`, string(resp))
+
+	resp, err = call("/breakingnews", "id=3").RemotePut()
+	a.NoError(err)
+	a.Equal(methods.DefaultDryRunResponse, resp)
+
+	// the scope is still checked before the request is recorded
+	_, err = call("/user", "id=3").RemoteDelete()
+	a.Error(err)
+	client.CurrentScopes = append(client.CurrentScopes, scopes.UserDelete)
+	resp, err = call("/user", "id=3").RemoteDelete()
+	a.NoError(err)
+	a.Equal(`[{"status":"deleted"}]`, string(resp))
+
+	// GET requests are sent
+	_, err = call("/incident", "id=1").RemoteGet()
+	a.NoError(err)
+	a.Equal([]string{http.MethodGet}, methodsSent)
+
+	reqs := plan.Requests()
+	if a.Len(reqs, 3) {
+		a.Equal(methods.PlannedRequest{
+			Method:   http.MethodPost,
+			Endpoint: "/incident",
+			Scope:    scopes.IncidentPost,
+			Params:   url.Values{"title": {"Printer"}, "type_id": {"1"}},
+		}, reqs[0])
+		a.Equal(http.MethodDelete, reqs[2].Method)
+		a.Equal(scopes.UserDelete, reqs[2].Scope)
+	}
+
+	plan.Reset()
+	a.Empty(plan.Requests())
+}
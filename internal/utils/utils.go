@@ -6,21 +6,64 @@ import (
 	"log"
 	"net/url"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// QueryEncoder is implemented by types that encode themselves as query parameters.
+// key is the full key of the field, including the prefix of any parent struct or map.
+type QueryEncoder interface {
+	EncodeQuery(key string, q url.Values) error
+}
+
+var (
+	queryEncoderType = reflect.TypeFor[QueryEncoder]()
+	timeType         = reflect.TypeFor[time.Time]()
+)
+
+// queryTag is a parsed `url` tag
+type queryTag struct {
+	name     string
+	required bool
+	// always sends the zero value of the field
+	always bool
+	// style is how slices and arrays are encoded: indexed, brackets or comma
+	style string
+	// rfc3339 encodes time.Time as RFC 3339 instead of a Unix timestamp
+	rfc3339 bool
+}
+
 /*
 StructToQuery uses reflection to parse a struct with the `url` tag and add them to url.Values.
 
-The expected `url` format is:
+The expected `url` format is `url:"field_name,option,option"`:
 
 	`field_name`: Name of the key for the query param to be added
-	`required`: If this is added addQuery will throw an error if the field is nil, 0, or ""
+	`required`: StructToQuery will return a *ValidationError if the field is nil, 0, or ""
+	`omitempty`: The field is not sent if it is the zero value. This is the default.
+	`always`: The field is sent even if it is the zero value e.g. id=0 or is_solution=false
+	`indexed`: Slices are sent as key[0]=a&key[1]=b. This is the default.
+	`brackets`: Slices are sent as key[]=a&key[]=b
+	`comma`: Slices are sent as key=a,b
+	`unix`: time.Time is sent as a Unix timestamp. This is the default.
+	`rfc3339`: time.Time is sent in RFC 3339 format
+
+A non nil pointer field is always sent so a pointer can be used to explicitly send a zero value.
+Maps are sent as key[map_key]=value and fields of a tagged struct as key[field_name]=value.
+Fields of untagged structs, such as embedded structs, are added as if they were fields of the parent.
+Types that implement QueryEncoder encode themselves.
+
+v is checked with Validate before it is encoded. See Validate for the rules of the `validate` tag.
 
 Example:
 
 	type Foo struct {
-		Bar string `url:"bar,required"`
+		Bar    string         `url:"bar,required"`
+		IDs    []int          `url:"ids,comma"`
+		Closed *bool          `url:"closed"`
+		Fields map[string]int `url:"fields"`
 	}
 */
 func StructToQuery(v any) (url.Values, error) {
@@ -28,88 +71,220 @@ func StructToQuery(v any) (url.Values, error) {
 	if v == nil {
 		return q, nil
 	}
-	if err := addQuery(q, v, ""); err != nil {
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return q, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unable to encode %s as a query, expected a struct", rv.Type())
+	}
+	if err := Validate(rv.Interface()); err != nil {
+		return nil, err
+	}
+
+	if err := addStruct(q, rv, ""); err != nil {
 		return nil, err
 	}
 	return q, nil
 }
 
-// addQuery is the internal implementation of StructToQuery
-func addQuery(q url.Values, v any, prefix string) error {
-	if v == nil {
-		return nil
+// addStruct adds the tagged fields of v to q. The keys of the fields are nested under prefix.
+func addStruct(q url.Values, v reflect.Value, prefix string) error {
+	t := v.Type()
+	for i := range v.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		field := v.Field(i)
+
+		raw, tagged := sf.Tag.Lookup("url")
+		if raw == "-" {
+			continue
+		}
+		if !tagged || raw == "" {
+			// untagged structs are flattened into the parent
+			if s, ok := untaggedStruct(field); ok {
+				if err := addStruct(q, s, prefix); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		tag := parseTag(raw)
+		key := tag.name
+		if prefix != "" {
+			key = prefix + "[" + tag.name + "]"
+		}
+		if err := addValue(q, key, field, tag); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	vals := reflect.ValueOf(v)
-	if vals.Kind() == reflect.Pointer {
-		if vals.IsNil() {
+// addValue adds v to q under key
+func addValue(q url.Values, key string, v reflect.Value, tag queryTag) error {
+	explicit := false
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
 			return nil
 		}
-		vals = vals.Elem()
+		explicit = explicit || v.Kind() == reflect.Pointer
+		v = v.Elem()
 	}
 
-	switch vals.Kind() {
-	case reflect.Struct:
-		t := vals.Type()
-		for i := range vals.NumField() {
-			field := vals.Field(i)
-			if !field.CanInterface() {
-				continue
-			}
+	if !explicit && !tag.always && v.IsZero() {
+		return nil
+	}
 
-			if field.Kind() == reflect.Struct {
-				if err := addQuery(q, field.Interface(), prefix); err != nil {
-					return err
-				}
-			}
+	if enc, ok := queryEncoder(v); ok {
+		return enc.EncodeQuery(key, q)
+	}
+	if v.Type() == timeType {
+		q.Add(key, formatTime(v.Interface().(time.Time), tag.rfc3339))
+		return nil
+	}
 
-			tag := t.Field(i).Tag.Get("url")
-			if tag == "" || tag == "-" {
-				continue
-			}
+	// elements of slices and maps are always sent
+	elem := queryTag{always: true, style: tag.style, rfc3339: tag.rfc3339}
 
-			parts := strings.Split(tag, ",")
-			key := strings.TrimSpace(parts[0])
-			isRequired := len(parts) > 1 && strings.TrimSpace(parts[1]) == "required"
+	switch v.Kind() {
+	case reflect.Struct:
+		return addStruct(q, v, key)
 
-			fullKey := key
-			if prefix != "" {
-				fullKey = prefix + tag
+	case reflect.Slice, reflect.Array:
+		if tag.style == "comma" {
+			parts := make([]string, 0, v.Len())
+			for i := range v.Len() {
+				s, err := scalar(v.Index(i), tag.rfc3339)
+				if err != nil {
+					return fmt.Errorf("field %s: %w", key, err)
+				}
+				parts = append(parts, s)
 			}
-
-			if isRequired && isZero(field.Interface()) {
-				return fmt.Errorf("field %s is required", key)
+			if len(parts) > 0 {
+				q.Add(key, strings.Join(parts, ","))
 			}
+			return nil
+		}
 
-			if err := addQuery(q, field.Interface(), fullKey); err != nil {
+		for i := range v.Len() {
+			k := fmt.Sprintf("%s[%d]", key, i)
+			if tag.style == "brackets" {
+				k = key + "[]"
+			}
+			if err := addValue(q, k, v.Index(i), elem); err != nil {
 				return err
 			}
 		}
 
-	case reflect.Slice, reflect.Array:
-		if vals.Len() == 0 {
-			return nil
-		}
-
-		for i := range vals.Len() {
-			elem := vals.Index(i)
-			key := fmt.Sprintf("%s[%d]", prefix, i)
-			if err := addQuery(q, elem.Interface(), key); err != nil {
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		for _, mk := range keys {
+			s, err := scalar(mk, false)
+			if err != nil {
+				return fmt.Errorf("field %s: map key: %w", key, err)
+			}
+			if err := addValue(q, key+"["+s+"]", v.MapIndex(mk), elem); err != nil {
 				return err
 			}
 		}
+
 	default:
-		if isZero(vals.Interface()) {
-			return nil
+		s, err := scalar(v, tag.rfc3339)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", key, err)
 		}
-		q.Add(prefix, fmt.Sprint(vals.Interface()))
+		q.Add(key, s)
 	}
-
 	return nil
 }
 
-func isZero(v any) bool {
-	return reflect.DeepEqual(v, reflect.Zero(reflect.TypeOf(v)).Interface())
+// scalar returns v as a single query value
+func scalar(v reflect.Value, rfc3339 bool) (string, error) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	if v.Type() == timeType {
+		return formatTime(v.Interface().(time.Time), rfc3339), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map, reflect.Func, reflect.Chan:
+		return "", fmt.Errorf("%s can not be sent as a single value", v.Type())
+	}
+	return fmt.Sprint(v.Interface()), nil
+}
+
+func formatTime(t time.Time, rfc3339 bool) string {
+	if rfc3339 {
+		return t.Format(time.RFC3339)
+	}
+	return strconv.FormatInt(t.Unix(), 10)
+}
+
+// queryEncoder returns v as a QueryEncoder if v or a pointer to v implements it
+func queryEncoder(v reflect.Value) (QueryEncoder, bool) {
+	if v.Type().Implements(queryEncoderType) {
+		return v.Interface().(QueryEncoder), true
+	}
+	if reflect.PointerTo(v.Type()).Implements(queryEncoderType) {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		return p.Interface().(QueryEncoder), true
+	}
+	return nil, false
+}
+
+// untaggedStruct returns the struct v points to if it should be flattened into its parent
+func untaggedStruct(v reflect.Value) (reflect.Value, bool) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct || v.Type() == timeType {
+		return v, false
+	}
+	if _, ok := queryEncoder(v); ok {
+		return v, false
+	}
+	return v, true
+}
+
+func parseTag(raw string) queryTag {
+	parts := strings.Split(raw, ",")
+	tag := queryTag{name: strings.TrimSpace(parts[0])}
+	for _, opt := range parts[1:] {
+		switch strings.TrimSpace(opt) {
+		case "required":
+			tag.required = true
+		case "always":
+			tag.always = true
+		case "omitempty":
+			tag.always = false
+		case "indexed", "brackets", "comma":
+			tag.style = strings.TrimSpace(opt)
+		case "rfc3339":
+			tag.rfc3339 = true
+		case "unix":
+			tag.rfc3339 = false
+		}
+	}
+	return tag
 }
 
 // ParseURL is used to pre-parse the provided rawURL before a client is created.
@@ -4,6 +4,7 @@ import (
 	"net/url"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/tmstorm/invgo/internal/utils"
@@ -79,3 +80,140 @@ func TestParseURL(t *testing.T) {
 	a.NoError(err)
 	a.Equal("http", u.Scheme)
 }
+
+type unixDay time.Time
+
+func (d unixDay) EncodeQuery(key string, q url.Values) error {
+	q.Set(key, time.Time(d).Format("2006-01-02"))
+	return nil
+}
+
+type Options struct {
+	Closed bool     `url:"closed"`
+	Page   *int     `url:"page"`
+	Tags   []string `url:"tags,brackets"`
+}
+
+type Rich struct {
+	ID       int               `url:"id,always"`
+	Archived bool              `url:"archived,always"`
+	Visible  *bool             `url:"visible"`
+	Hidden   *bool             `url:"hidden"`
+	IDs      []int             `url:"ids,comma"`
+	Fields   map[string]string `url:"fields"`
+	Since    time.Time         `url:"since"`
+	Until    time.Time         `url:"until,rfc3339"`
+	Day      unixDay           `url:"day"`
+	Opts     Options           `url:"opts"`
+	Skipped  string            `url:"-"`
+}
+
+func TestStructToQueryOptions(t *testing.T) {
+	a := assert.New(t)
+
+	visible := false
+	page := 0
+	since := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	q, err := utils.StructToQuery(&Rich{
+		Visible: &visible,
+		IDs:     []int{1, 2, 3},
+		Fields:  map[string]string{"b": "2", "a": ""},
+		Since:   since,
+		Until:   since,
+		Day:     unixDay(since),
+		Opts:    Options{Page: &page, Tags: []string{"x", "y"}},
+		Skipped: "no",
+	})
+	a.NoError(err)
+	a.Equal(url.Values{
+		"id":           {"0"},
+		"archived":     {"false"},
+		"visible":      {"false"},
+		"ids":          {"1,2,3"},
+		"fields[a]":    {""},
+		"fields[b]":    {"2"},
+		"since":        {strconv.FormatInt(since.Unix(), 10)},
+		"until":        {"2025-01-06T09:00:00Z"},
+		"day":          {"2025-01-06"},
+		"opts[page]":   {"0"},
+		"opts[tags][]": {"x", "y"},
+	}, q)
+
+	type Required struct {
+		ID    *int `url:"id,required"`
+		Count int  `url:"count,required"`
+	}
+	_, err = utils.StructToQuery(Required{Count: 1})
+	a.ErrorContains(err, "field id is required")
+	_, err = utils.StructToQuery(Required{ID: &page})
+	a.ErrorContains(err, "field count is required")
+	_, err = utils.StructToQuery(Required{ID: &page, Count: 1})
+	a.NoError(err)
+
+	type Bad struct {
+		IDs [][]int `url:"ids,comma"`
+	}
+	_, err = utils.StructToQuery(Bad{IDs: [][]int{{1}}})
+	a.Error(err)
+
+	_, err = utils.StructToQuery([]int{1})
+	a.Error(err)
+}
+
+type ValidatedParams struct {
+	Email string `url:"email" validate:"group=user,format=email"`
+	ID    int    `url:"id" validate:"group=user,min=1"`
+	From  string `url:"from" validate:"format=iso8601"`
+	Type  string `url:"type,required" validate:"oneof=NEW_USER RESET_PASSWORD"`
+	Limit *int   `url:"limit" validate:"min=1,max=100"`
+	IDs   []int  `url:"ids" validate:"max=2"`
+	Opts  *Named `url:"opts"`
+}
+
+type Named struct {
+	Name string `url:"name,required"`
+}
+
+func TestValidate(t *testing.T) {
+	a := assert.New(t)
+
+	limit := 10
+	a.NoError(utils.Validate(ValidatedParams{ID: 1, From: "2025-01-06 09:00", Type: "NEW_USER", Limit: &limit}))
+	a.NoError(utils.Validate(ValidatedParams{Email: "jo@example.com", From: "2025-01-06T09:00:00Z", Type: "RESET_PASSWORD"}))
+
+	// every failing field is returned
+	limit = 0
+	err := utils.Validate(&ValidatedParams{
+		Email: "jo",
+		From:  "06/01/2025",
+		Type:  "NEW",
+		Limit: &limit,
+		IDs:   []int{1, 2, 3},
+		Opts:  &Named{},
+	})
+	var verr *utils.ValidationError
+	a.ErrorAs(err, &verr)
+	a.Equal([]utils.FieldError{
+		{Field: "email", Rule: "format", Message: "must be an email address"},
+		{Field: "from", Rule: "format", Message: "must be an ISO-8601 date"},
+		{Field: "type", Rule: "oneof", Message: "must be one of NEW_USER, RESET_PASSWORD"},
+		{Field: "limit", Rule: "min", Message: "must be at least 1"},
+		{Field: "ids", Rule: "max", Message: "must have a length of at most 2"},
+		{Field: "opts[name]", Rule: "required", Message: "is required"},
+	}, verr.Fields)
+
+	// groups are only satisfied by a set field
+	err = utils.Validate(ValidatedParams{Type: "NEW_USER"})
+	a.EqualError(err, "field email or id is required")
+
+	_, err = utils.StructToQuery(ValidatedParams{ID: -1})
+	a.ErrorAs(err, &verr)
+	a.EqualError(err, "field id must be at least 1; field type is required")
+
+	type BadRule struct {
+		Name string `url:"name" validate:"between=1 2"`
+	}
+	err = utils.Validate(BadRule{Name: "x"})
+	a.Error(err)
+	a.NotErrorAs(err, &verr)
+}
@@ -0,0 +1,305 @@
+package utils
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// iso8601Layouts are the layouts accepted by format=iso8601
+var iso8601Layouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+type (
+	// FieldError is a param that failed validation
+	FieldError struct {
+		// Field is the query key of the param. For a group it lists every field in the group.
+		Field string
+		// Rule is the rule that failed e.g. required, group, oneof, min, max or format
+		Rule string
+		// Message describes why the param is invalid
+		Message string
+	}
+
+	// ValidationError is returned by Validate and StructToQuery with every param that failed validation
+	ValidationError struct {
+		Fields []FieldError
+	}
+
+	// group is the fields of a `validate:"group=name"` group in declaration order
+	group struct {
+		keys []string
+		set  bool
+	}
+)
+
+func (e FieldError) Error() string {
+	return "field " + e.Field + " " + e.Message
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+/*
+Validate checks the params in v using the `url` and `validate` tags. Every param that fails is
+returned in a *ValidationError. Any other error means a tag is invalid.
+
+The `required` option of the `url` tag is checked along with the rules of the `validate` tag:
+
+	`group=name`: At least one field in the group must be set
+	`oneof=a b c`: The value must be one of the space separated values
+	`min=n`: Numbers must be at least n. Strings and slices must have a length of at least n.
+	`max=n`: Numbers must be at most n. Strings and slices must have a length of at most n.
+	`format=iso8601`: The value must be an ISO-8601 date e.g. 2006-01-02 or 2006-01-02T15:04:05Z
+	`format=email`: The value must be an email address
+
+Rules other than group are only checked for fields that will be sent.
+
+Example:
+
+	type Foo struct {
+		Email string `url:"email" validate:"group=user,format=email"`
+		ID    int    `url:"id" validate:"group=user,min=1"`
+		Type  string `url:"type,required" validate:"oneof=NEW_USER RESET_PASSWORD"`
+	}
+*/
+func Validate(v any) error {
+	if v == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("unable to validate %s, expected a struct", rv.Type())
+	}
+
+	verr := &ValidationError{}
+	if err := validateStruct(verr, rv, ""); err != nil {
+		return err
+	}
+	if len(verr.Fields) > 0 {
+		return verr
+	}
+	return nil
+}
+
+// validateStruct validates the fields of v and the groups declared in it
+func validateStruct(verr *ValidationError, v reflect.Value, prefix string) error {
+	groups := map[string]*group{}
+	var order []string
+	if err := validateFields(verr, v, prefix, groups, &order); err != nil {
+		return err
+	}
+
+	for _, name := range order {
+		g := groups[name]
+		if g.set {
+			continue
+		}
+		verr.Fields = append(verr.Fields, FieldError{
+			Field:   joinOr(g.keys),
+			Rule:    "group",
+			Message: "is required",
+		})
+	}
+	return nil
+}
+
+// validateFields validates the fields of v. Fields of untagged structs are validated as fields of
+// the parent and share its groups.
+func validateFields(verr *ValidationError, v reflect.Value, prefix string, groups map[string]*group, order *[]string) error {
+	t := v.Type()
+	for i := range v.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		field := v.Field(i)
+
+		raw, tagged := sf.Tag.Lookup("url")
+		if raw == "-" {
+			continue
+		}
+		if !tagged || raw == "" {
+			if s, ok := untaggedStruct(field); ok {
+				if err := validateFields(verr, s, prefix, groups, order); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		tag := parseTag(raw)
+		key := tag.name
+		if prefix != "" {
+			key = prefix + "[" + tag.name + "]"
+		}
+
+		val, set := sentValue(field, tag)
+		if tag.required && !set {
+			verr.Fields = append(verr.Fields, FieldError{Field: key, Rule: "required", Message: "is required"})
+		}
+
+		for _, rule := range splitRules(sf.Tag.Get("validate")) {
+			name, arg, _ := strings.Cut(rule, "=")
+			if name == "group" {
+				g, ok := groups[arg]
+				if !ok {
+					g = &group{}
+					groups[arg] = g
+					*order = append(*order, arg)
+				}
+				g.keys = append(g.keys, key)
+				g.set = g.set || set
+				continue
+			}
+			if !set {
+				continue
+			}
+
+			msg, err := checkRule(name, arg, val)
+			if err != nil {
+				return fmt.Errorf("invalid validate tag on %s: %w", key, err)
+			}
+			if msg != "" {
+				verr.Fields = append(verr.Fields, FieldError{Field: key, Rule: name, Message: msg})
+			}
+		}
+
+		if !set {
+			continue
+		}
+		if s, ok := untaggedStruct(val); ok {
+			if err := validateStruct(verr, s, key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// sentValue returns the value of field with pointers removed and if StructToQuery would send it
+func sentValue(field reflect.Value, tag queryTag) (reflect.Value, bool) {
+	explicit := false
+	for field.Kind() == reflect.Pointer || field.Kind() == reflect.Interface {
+		if field.IsNil() {
+			return field, false
+		}
+		explicit = explicit || field.Kind() == reflect.Pointer
+		field = field.Elem()
+	}
+	return field, explicit || tag.always || !field.IsZero()
+}
+
+// checkRule returns a message if v breaks the rule. An error is returned if the rule is invalid.
+func checkRule(name, arg string, v reflect.Value) (string, error) {
+	switch name {
+	case "oneof":
+		allowed := strings.Fields(arg)
+		if len(allowed) == 0 {
+			return "", fmt.Errorf("oneof requires values")
+		}
+		if !slices.Contains(allowed, fmt.Sprint(v.Interface())) {
+			return "must be one of " + strings.Join(allowed, ", "), nil
+		}
+
+	case "min", "max":
+		limit, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", name, err)
+		}
+		n, isLen, err := measure(v)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", name, err)
+		}
+		if (name == "min" && n >= limit) || (name == "max" && n <= limit) {
+			return "", nil
+		}
+		bound := "at least"
+		if name == "max" {
+			bound = "at most"
+		}
+		if isLen {
+			return fmt.Sprintf("must have a length of %s %s", bound, arg), nil
+		}
+		return fmt.Sprintf("must be %s %s", bound, arg), nil
+
+	case "format":
+		if v.Kind() != reflect.String {
+			return "", fmt.Errorf("format can only be used on strings")
+		}
+		s := v.String()
+		switch arg {
+		case "iso8601":
+			for _, layout := range iso8601Layouts {
+				if _, err := time.Parse(layout, s); err == nil {
+					return "", nil
+				}
+			}
+			return "must be an ISO-8601 date", nil
+		case "email":
+			if a, err := mail.ParseAddress(s); err != nil || a.Address != s {
+				return "must be an email address", nil
+			}
+		default:
+			return "", fmt.Errorf("unknown format %q", arg)
+		}
+
+	default:
+		return "", fmt.Errorf("unknown rule %q", name)
+	}
+	return "", nil
+}
+
+// measure returns the number compared by min and max. For strings, slices and maps it is the length.
+func measure(v reflect.Value) (float64, bool, error) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), false, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), false, nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), false, nil
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return float64(v.Len()), true, nil
+	}
+	return 0, false, fmt.Errorf("%s can not be compared", v.Type())
+}
+
+func splitRules(tag string) []string {
+	var rules []string
+	for r := range strings.SplitSeq(tag, ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+// joinOr returns keys as "a, b or c"
+func joinOr(keys []string) string {
+	if len(keys) == 1 {
+		return keys[0]
+	}
+	return strings.Join(keys[:len(keys)-1], ", ") + " or " + keys[len(keys)-1]
+}
@@ -3,11 +3,17 @@ package invgo
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/tmstorm/invgo/internal/methods"
 	"github.com/tmstorm/invgo/internal/utils"
 	"github.com/tmstorm/invgo/scopes"
-	"golang.org/x/oauth2/clientcredentials"
+	"golang.org/x/oauth2"
 )
 
 type (
@@ -21,16 +27,57 @@ type (
 		ClientID string `json:"client_id,omitempty"`
 		// ClientSecret defines the secret created in Invgate to allow Invgo to connect to the instance.
 		ClientSecret string `json:"client_secret,omitempty"`
+		// ClientSecretSource is used to load the client secret when ClientSecret is empty.
+		// See FileSecret, EnvSecret and CommandSecret.
+		ClientSecretSource SecretSource `json:"-"`
 		// AllowHTTP defines if the connection should be allowed to let the base url keep the scheme http or not.
 		// WARNING: This should only be set to true in testing or dev environments.
 		AllowHTTP bool `json:"allow_http,omitempty"`
 		// Scopes defines which scopes will be requested when requestion the token from the Invgate instance.
 		// If a scope is not defined here the client will be denied access to its endpoint on future requests.
 		Scopes []scopes.ScopeType `json:"scopes,omitempty"`
+		// ValidateScopes requests a token when the client is created and compares the scopes granted
+		// by the Invgate instance with Scopes. The client's CurrentScopes are set to the granted scopes.
+		ValidateScopes bool `json:"validate_scopes,omitempty"`
+		// StrictScopes causes New to return a *ScopeError if any requested scope was not granted.
+		// If false a warning is logged instead. This is only used if ValidateScopes is true.
+		StrictScopes bool `json:"strict_scopes,omitempty"`
+		// Auth defines how requests are authenticated. If nil ClientCredentials is used.
+		Auth Auth `json:"-"`
+		// TokenCache persists client credential tokens so they can be reused by other clients and processes.
+		// See FileTokenCache and MemoryTokenCache.
+		TokenCache TokenCache `json:"-"`
+		// TokenRefreshWindow is how long before a cached token expires a new token is requested.
+		// If 0 DefaultTokenRefreshWindow is used. This is only used if TokenCache is set.
+		TokenRefreshWindow time.Duration `json:"-"`
+		// Transport is used to send every request, including token requests.
+		// If nil http.DefaultTransport is used. See the cassette package for recording requests.
+		Transport http.RoundTripper `json:"-"`
+		// DryRun records POST, PUT, PATCH and DELETE requests in the client's Plan instead of sending them.
+		// GET requests are still sent.
+		DryRun bool `json:"dry_run,omitempty"`
+	}
+
+	// ScopeError is returned by New when scopes were requested but not granted by the Invgate instance
+	ScopeError struct {
+		// Missing are the scopes that were requested but not granted
+		Missing []scopes.ScopeType
+		// Granted are the scopes the Invgate instance granted
+		Granted []scopes.ScopeType
 	}
 
 	// Client implements methods.Client for use to connect with Invgate.
 	Client methods.Client
+
+	// DryRunPlan records the writes of a client in dry run mode and returns synthetic
+	// success responses for them. See Invgate.DryRun and Client.DryRun.
+	DryRunPlan = methods.Plan
+
+	// PlannedRequest is a write recorded by a DryRunPlan
+	PlannedRequest = methods.PlannedRequest
+
+	// ResponseError is returned by API calls when Invgate responds with a status other than 200
+	ResponseError = methods.ResponseError
 )
 
 // InvgateAPIPath defines the base path for the Invgate API.
@@ -38,33 +85,143 @@ type (
 var InvgateAPIPath = "/api/v1"
 
 // New authenticates with the Invgate using the provided options and returns the client for API calls.
+// Requests are authenticated with cfg.Auth, or OAuth2 client credentials if it is not set.
 func New(cfg *Invgate) (*Client, error) {
 	// check that at least one scop has been provided
 	if len(cfg.Scopes) == 0 {
 		return nil, errors.New("no scopes were provided")
 	}
 
-	// Set config for creating a connection with Invgate
-	scopes := scopes.CreateScopes(cfg.Scopes)
-	cred := &clientcredentials.Config{
-		ClientID:     cfg.ClientID,
-		ClientSecret: cfg.ClientSecret,
-		TokenURL:     cfg.TokenURL,
-		Scopes:       scopes,
-	}
-
 	// Parse base url given to ensure it is not malformed
 	apiURL, err := utils.ParseURL(cfg.BaseURL, InvgateAPIPath, cfg.AllowHTTP)
 	if err != nil {
 		return nil, err
 	}
 
+	auth := cfg.Auth
+	if auth == nil {
+		auth = ClientCredentials{}
+	}
+
+	// Create the transport used to authenticate requests.
+	// Token based auth shares its token source so ValidateScopes uses the same tokens.
+	ctx := context.Background()
+	base := http.DefaultTransport
+	if cfg.Transport != nil {
+		base = cfg.Transport
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: base})
+	}
+	var (
+		ts oauth2.TokenSource
+		rt http.RoundTripper
+	)
+	if ta, ok := auth.(TokenAuth); ok {
+		ts, err = ta.TokenSource(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		rt = &oauth2.Transport{Source: ts, Base: base}
+	} else {
+		rt, err = auth.Transport(ctx, cfg, base)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Create a client for future use
 	client := &Client{
-		HTTPClient:    cred.Client(context.Background()),
+		HTTPClient:    &http.Client{Transport: rt},
 		CurrentScopes: cfg.Scopes,
 		APIURL:        apiURL,
 	}
+	if cfg.DryRun {
+		client.Plan = &DryRunPlan{}
+	}
+
+	if cfg.ValidateScopes {
+		if ts == nil {
+			return nil, errors.New("scopes can only be validated when using token based auth")
+		}
+		granted, err := validateScopes(ts, cfg)
+		if err != nil {
+			return nil, err
+		}
+		client.CurrentScopes = granted
+	}
 
 	return client, nil
 }
+
+// DryRun returns a copy of c that records its writes in a new plan instead of sending them.
+// GET requests are still sent using c's credentials.
+func (c *Client) DryRun() (*Client, *DryRunPlan) {
+	dry := *c
+	dry.Plan = &DryRunPlan{}
+	return &dry, dry.Plan
+}
+
+// Error lists the scopes that were requested but not granted
+func (e *ScopeError) Error() string {
+	return fmt.Sprintf("requested scopes were not granted by invgate: %s", scopes.CreateScopes(e.Missing))
+}
+
+// validateScopes fetches a token and compares its granted scopes with the requested scopes.
+// The granted scopes are returned. If the token does not report its scopes the requested
+// scopes are returned.
+func validateScopes(ts oauth2.TokenSource, cfg *Invgate) ([]scopes.ScopeType, error) {
+	tok, err := ts.Token()
+	if err != nil {
+		return nil, fmt.Errorf("unable to validate scopes: %w", err)
+	}
+
+	granted, ok := grantedScopes(tok)
+	if !ok {
+		log.Printf("[INVGO] WARNING: Token did not include its granted scopes, unable to validate requested scopes")
+		return cfg.Scopes, nil
+	}
+
+	var missing []scopes.ScopeType
+	for _, s := range cfg.Scopes {
+		if !slices.Contains(granted, s) {
+			missing = append(missing, s)
+		}
+	}
+
+	if len(missing) > 0 {
+		scpErr := &ScopeError{Missing: missing, Granted: granted}
+		if cfg.StrictScopes {
+			return nil, scpErr
+		}
+		log.Printf("[INVGO] WARNING: %s", scpErr)
+	}
+
+	return granted, nil
+}
+
+// grantedScopes parses the scope field of a token. It is normally a space delimited
+// string but a list of scopes is also accepted.
+func grantedScopes(tok *oauth2.Token) ([]scopes.ScopeType, bool) {
+	var raw []string
+	switch v := tok.Extra("scope").(type) {
+	case string:
+		raw = strings.Fields(v)
+	case []any:
+		for _, s := range v {
+			if str, ok := s.(string); ok {
+				raw = append(raw, str)
+			}
+		}
+	default:
+		return nil, false
+	}
+
+	if len(raw) == 0 {
+		return nil, false
+	}
+
+	granted := make([]scopes.ScopeType, 0, len(raw))
+	for _, s := range raw {
+		granted = append(granted, scopes.ScopeType(s))
+	}
+	return granted, true
+}
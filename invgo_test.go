@@ -1,10 +1,14 @@
 package invgo_test
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/endpoints"
+	"github.com/tmstorm/invgo/invgotest"
 	"github.com/tmstorm/invgo/scopes"
 )
 
@@ -31,3 +35,99 @@ func TestInvgo(t *testing.T) {
 	a.NoError(err)
 	a.Equal("https", cNext.APIURL.Scheme)
 }
+
+func newTokenServer(t *testing.T, grantedScope string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"token","token_type":"bearer","expires_in":3600,"scope":"` + grantedScope + `"}`))
+	}))
+}
+
+func TestInvgoValidateScopes(t *testing.T) {
+	a := assert.New(t)
+
+	server := newTokenServer(t, string(scopes.IncidentGet)+" "+string(scopes.UsersGet))
+	defer server.Close()
+
+	cfg := &invgo.Invgate{
+		BaseURL:        server.URL,
+		TokenURL:       server.URL + "/oauth/token",
+		ClientID:       "12345",
+		ClientSecret:   "clientSecret",
+		AllowHTTP:      true,
+		Scopes:         []scopes.ScopeType{scopes.IncidentGet, scopes.IncidentPost},
+		ValidateScopes: true,
+	}
+
+	// missing scopes only warn by default and the granted scopes are used
+	c, err := invgo.New(cfg)
+	a.NoError(err)
+	a.Equal([]scopes.ScopeType{scopes.IncidentGet, scopes.UsersGet}, c.CurrentScopes)
+
+	cfg.StrictScopes = true
+	_, err = invgo.New(cfg)
+	var scpErr *invgo.ScopeError
+	a.ErrorAs(err, &scpErr)
+	a.Equal([]scopes.ScopeType{scopes.IncidentPost}, scpErr.Missing)
+}
+
+func TestInvgoValidateScopesNotReported(t *testing.T) {
+	a := assert.New(t)
+
+	server := newTokenServer(t, "")
+	defer server.Close()
+
+	cfg := &invgo.Invgate{
+		BaseURL:        server.URL,
+		TokenURL:       server.URL + "/oauth/token",
+		AllowHTTP:      true,
+		Scopes:         []scopes.ScopeType{scopes.IncidentGet},
+		ValidateScopes: true,
+		StrictScopes:   true,
+	}
+
+	c, err := invgo.New(cfg)
+	a.NoError(err)
+	a.Equal(cfg.Scopes, c.CurrentScopes)
+}
+
+func TestClientDryRun(t *testing.T) {
+	a := assert.New(t)
+
+	srv := invgotest.NewServer(t)
+	id := srv.AddIncident(endpoints.Incident{Title: "Printer on fire"})
+	c := srv.Client(t, scopes.IncidentGet, scopes.IncidentPost, scopes.IncidentCommentPost, scopes.IncidentPut)
+
+	dry, plan := c.DryRun()
+	a.Nil(c.Plan)
+
+	// reads are sent
+	incs, err := dry.Incident().Get(endpoints.IncidentGetParams{ID: id})
+	a.NoError(err)
+	a.Len(incs, 1)
+
+	resp, err := dry.Incident().Post(endpoints.IncidentPostParams{Title: "New", TypeID: 1, CreatorID: 1, PriorityID: 1, CustomerID: 1})
+	a.NoError(err)
+	a.Equal("OK", resp.Status)
+
+	com, err := dry.IncidentComment().Post(endpoints.IncidentCommentPostParams{AuthorID: 1, RequestID: id, Comment: "hello"})
+	a.NoError(err)
+	a.Equal("OK", com.Status)
+
+	_, err = dry.Incident().Put(endpoints.IncidentPutParams{ID: id, Title: "Renamed"})
+	a.NoError(err)
+
+	reqs := plan.Requests()
+	if a.Len(reqs, 3) {
+		a.Equal(http.MethodPost, reqs[0].Method)
+		a.Equal("/incident", reqs[0].Endpoint)
+		a.Equal(scopes.IncidentPost, reqs[0].Scope)
+		a.Equal("New", reqs[0].Params.Get("title"))
+		a.Equal("/incident.comment", reqs[1].Endpoint)
+		a.Equal(http.MethodPut, reqs[2].Method)
+	}
+
+	inc, _ := srv.Incident(id)
+	a.Equal("Printer on fire", inc.Title)
+	a.Empty(srv.Comments(id))
+}
@@ -0,0 +1,55 @@
+/*
+Package invgomock provides fakes of invgo.API for unit testing code that uses Invgo without HTTP.
+
+Every endpoint has a fake with a Func field for each of its methods. Methods without a Func
+return ErrNotImplemented. Calls are recorded so they can be checked after the test.
+
+	api := invgomock.New()
+	api.IncidentMock.GetFunc = func(p endpoints.IncidentGetParams) ([]endpoints.Incident, error) {
+		return []endpoints.Incident{{ID: p.ID, Title: "Printer on fire"}}, nil
+	}
+
+	title, err := incidentTitle(api, 1) // func incidentTitle(api invgo.API, id int) (string, error)
+	calls := api.IncidentMock.Calls()
+
+The fakes are generated from endpoint_methods.go by go generate.
+*/
+package invgomock
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrNotImplemented is returned by a fake method whose Func field is nil
+var ErrNotImplemented = errors.New("invgomock: method not implemented")
+
+// Call is a recorded call to a fake method
+type Call struct {
+	Method string
+	Args   []any
+}
+
+// recorder records the calls made to a fake
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Calls returns the calls made to the fake in order
+func (r *recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call{}, r.calls...)
+}
+
+func (r *recorder) record(method string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+func notImplemented(method string) error {
+	return fmt.Errorf("%w: %s", ErrNotImplemented, method)
+}
@@ -0,0 +1,1869 @@
+// Code generated by go run ./scripts/genapi; DO NOT EDIT.
+
+package invgomock
+
+import (
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/endpoints"
+)
+
+// API is a fake invgo.API. Each accessor returns the fake in its Mock field.
+type API struct {
+	BreakingNewsMock                     *BreakingNewsAPI
+	BreakingNewsAllMock                  *BreakingNewsAllAPI
+	BreakingNewsStatusMock               *BreakingNewsStatusAPI
+	BreakingNewsAttributesStatusMock     *AttributesAPI
+	BreakingNewsAttributesTypeMock       *AttributesAPI
+	CategoriesMock                       *CategoriesAPI
+	CustomFieldsByCategoryMock           *CustomFieldsByCategoryAPI
+	HelpDesksMock                        *HelpDesksAPI
+	IncidentMock                         *IncidentAPI
+	IncidentApprovalMock                 *IncidentApprovalAPI
+	IncidentApprovalAcceptMock           *IncidentApprovalAcceptAPI
+	IncidentApprovalAddVoterMock         *IncidentApprovalAddVoterAPI
+	IncidentApprovalCancelMock           *IncidentApprovalCancelAPI
+	IncidentApprovalPossibleVotersMock   *IncidentApprovalPossibleVotersAPI
+	IncidentApprovalRejectMock           *IncidentApprovalRejectAPI
+	IncidentApprovalStatusMock           *IncidentApprovalStatusAPI
+	IncidentApprovalTypeMock             *IncidentApprovalTypeAPI
+	IncidentApprovalVoteStatusMock       *IncidentApprovalVoteStatusAPI
+	IncidentAttachmentMock               *IncidentAttachmentAPI
+	IncidentAttributesPriorityMock       *AttributesAPI
+	IncidentAttributesSourceMock         *AttributesAPI
+	IncidentAttributesStatusMock         *AttributesAPI
+	IncidentAttributesTypeMock           *AttributesAPI
+	IncidentCancelMock                   *IncidentCancelAPI
+	IncidentCollaboratorMock             *IncidentCollaboratorAPI
+	IncidentCommentMock                  *IncidentCommentAPI
+	IncidentCustomApprovalMock           *IncidentCustomApprovalAPI
+	IncidentExternalEntityMock           *IncidentExternalEntityAPI
+	IncidentLinkMock                     *IncidentLinkAPI
+	IncidentLinkedCIsCountersFromMock    *IncidentLinkedCIsCountersFromAPI
+	IncidentObserverMock                 *IncidentObserverAPI
+	IncidentReassignMock                 *IncidentReassignAPI
+	IncidentRejectMock                   *IncidentRejectAPI
+	IncidentReopenMock                   *IncidentReopenAPI
+	IncidentSolutionAcceptMock           *IncidentSolutionAcceptAPI
+	IncidentSolutionRejectMock           *IncidentSolutionRejectAPI
+	IncidentSpontaneousApprovalMock      *IncidentSpontaneousApprovalAPI
+	IncidentTasksMock                    *IncidentTasksAPI
+	IncidentWaitingForAgentMock          *IncidentWaitingForAgentAPI
+	IncidentWaitingForCustomerMock       *IncidentWaitingForCustomerAPI
+	IncidentWaitingForDateMock           *IncidentWaitingForDateAPI
+	IncidentWaitingForExternalEntityMock *IncidentWaitingForExternalEntityAPI
+	IncidentWaitingForIncidentMock       *IncidentWaitingForIncidentAPI
+	IncidentsMock                        *IncidentsAPI
+	IncidentsByAgentMock                 *IncidentsByAgentAPI
+	IncidentsByCIsMock                   *IncidentsByCIsAPI
+	IncidentsByCustomerMock              *IncidentsByCustomerAPI
+	IncidentsByHelpDeskMock              *IncidentsByHelpDeskAPI
+	IncidentsBySentimentMock             *IncidentsBySentimentAPI
+	IncidentsByStatusMock                *IncidentsByStatusAPI
+	IncidentsByViewMock                  *IncidentsByViewAPI
+	IncidentsDetailsByViewMock           *IncidentsDetailsByViewAPI
+	IncidentsLastHourMock                *IncidentsLastHourAPI
+	ServiceDeskVersionMock               *ServiceDeskVersionAPI
+	TimeTrackingMock                     *TimeTrackingAPI
+	TimeTrackingAttributesCategoryMock   *TimeTrackingAttributesCategoryAPI
+	TriggersMock                         *TriggersAPI
+	TriggersExecutionsMock               *TriggersExecutionsAPI
+	UserMock                             *UserAPI
+	UserByMock                           *UserByAPI
+	UserConvertMock                      *UserConvertAPI
+	UserDisableMock                      *UserDisableAPI
+	UserEnableMock                       *UserEnableAPI
+	UserPasswordMock                     *UserPasswordAPI
+	UserPasswordResetMock                *UserPasswordResetAPI
+	UserTokenMock                        *UserTokenAPI
+	UsersMock                            *UsersAPI
+	UsersByMock                          *UsersByAPI
+	UsersGroupsMock                      *UsersGroupsAPI
+	WorkflowDeployMock                   *WorkflowDeployAPI
+	WorkflowInitialFieldsByCategoryMock  *WorkflowInitialFieldsByCategoryAPI
+}
+
+var _ invgo.API = (*API)(nil)
+
+// New returns an API with a fake for every endpoint
+func New() *API {
+	return &API{
+		BreakingNewsMock:                     &BreakingNewsAPI{},
+		BreakingNewsAllMock:                  &BreakingNewsAllAPI{},
+		BreakingNewsStatusMock:               &BreakingNewsStatusAPI{},
+		BreakingNewsAttributesStatusMock:     &AttributesAPI{},
+		BreakingNewsAttributesTypeMock:       &AttributesAPI{},
+		CategoriesMock:                       &CategoriesAPI{},
+		CustomFieldsByCategoryMock:           &CustomFieldsByCategoryAPI{},
+		HelpDesksMock:                        &HelpDesksAPI{},
+		IncidentMock:                         &IncidentAPI{},
+		IncidentApprovalMock:                 &IncidentApprovalAPI{},
+		IncidentApprovalAcceptMock:           &IncidentApprovalAcceptAPI{},
+		IncidentApprovalAddVoterMock:         &IncidentApprovalAddVoterAPI{},
+		IncidentApprovalCancelMock:           &IncidentApprovalCancelAPI{},
+		IncidentApprovalPossibleVotersMock:   &IncidentApprovalPossibleVotersAPI{},
+		IncidentApprovalRejectMock:           &IncidentApprovalRejectAPI{},
+		IncidentApprovalStatusMock:           &IncidentApprovalStatusAPI{},
+		IncidentApprovalTypeMock:             &IncidentApprovalTypeAPI{},
+		IncidentApprovalVoteStatusMock:       &IncidentApprovalVoteStatusAPI{},
+		IncidentAttachmentMock:               &IncidentAttachmentAPI{},
+		IncidentAttributesPriorityMock:       &AttributesAPI{},
+		IncidentAttributesSourceMock:         &AttributesAPI{},
+		IncidentAttributesStatusMock:         &AttributesAPI{},
+		IncidentAttributesTypeMock:           &AttributesAPI{},
+		IncidentCancelMock:                   &IncidentCancelAPI{},
+		IncidentCollaboratorMock:             &IncidentCollaboratorAPI{},
+		IncidentCommentMock:                  &IncidentCommentAPI{},
+		IncidentCustomApprovalMock:           &IncidentCustomApprovalAPI{},
+		IncidentExternalEntityMock:           &IncidentExternalEntityAPI{},
+		IncidentLinkMock:                     &IncidentLinkAPI{},
+		IncidentLinkedCIsCountersFromMock:    &IncidentLinkedCIsCountersFromAPI{},
+		IncidentObserverMock:                 &IncidentObserverAPI{},
+		IncidentReassignMock:                 &IncidentReassignAPI{},
+		IncidentRejectMock:                   &IncidentRejectAPI{},
+		IncidentReopenMock:                   &IncidentReopenAPI{},
+		IncidentSolutionAcceptMock:           &IncidentSolutionAcceptAPI{},
+		IncidentSolutionRejectMock:           &IncidentSolutionRejectAPI{},
+		IncidentSpontaneousApprovalMock:      &IncidentSpontaneousApprovalAPI{},
+		IncidentTasksMock:                    &IncidentTasksAPI{},
+		IncidentWaitingForAgentMock:          &IncidentWaitingForAgentAPI{},
+		IncidentWaitingForCustomerMock:       &IncidentWaitingForCustomerAPI{},
+		IncidentWaitingForDateMock:           &IncidentWaitingForDateAPI{},
+		IncidentWaitingForExternalEntityMock: &IncidentWaitingForExternalEntityAPI{},
+		IncidentWaitingForIncidentMock:       &IncidentWaitingForIncidentAPI{},
+		IncidentsMock:                        &IncidentsAPI{},
+		IncidentsByAgentMock:                 &IncidentsByAgentAPI{},
+		IncidentsByCIsMock:                   &IncidentsByCIsAPI{},
+		IncidentsByCustomerMock:              &IncidentsByCustomerAPI{},
+		IncidentsByHelpDeskMock:              &IncidentsByHelpDeskAPI{},
+		IncidentsBySentimentMock:             &IncidentsBySentimentAPI{},
+		IncidentsByStatusMock:                &IncidentsByStatusAPI{},
+		IncidentsByViewMock:                  &IncidentsByViewAPI{},
+		IncidentsDetailsByViewMock:           &IncidentsDetailsByViewAPI{},
+		IncidentsLastHourMock:                &IncidentsLastHourAPI{},
+		ServiceDeskVersionMock:               &ServiceDeskVersionAPI{},
+		TimeTrackingMock:                     &TimeTrackingAPI{},
+		TimeTrackingAttributesCategoryMock:   &TimeTrackingAttributesCategoryAPI{},
+		TriggersMock:                         &TriggersAPI{},
+		TriggersExecutionsMock:               &TriggersExecutionsAPI{},
+		UserMock:                             &UserAPI{},
+		UserByMock:                           &UserByAPI{},
+		UserConvertMock:                      &UserConvertAPI{},
+		UserDisableMock:                      &UserDisableAPI{},
+		UserEnableMock:                       &UserEnableAPI{},
+		UserPasswordMock:                     &UserPasswordAPI{},
+		UserPasswordResetMock:                &UserPasswordResetAPI{},
+		UserTokenMock:                        &UserTokenAPI{},
+		UsersMock:                            &UsersAPI{},
+		UsersByMock:                          &UsersByAPI{},
+		UsersGroupsMock:                      &UsersGroupsAPI{},
+		WorkflowDeployMock:                   &WorkflowDeployAPI{},
+		WorkflowInitialFieldsByCategoryMock:  &WorkflowInitialFieldsByCategoryAPI{},
+	}
+}
+
+// BreakingNews returns BreakingNewsMock
+func (m *API) BreakingNews() endpoints.BreakingNewsAPI { return m.BreakingNewsMock }
+
+// BreakingNewsAll returns BreakingNewsAllMock
+func (m *API) BreakingNewsAll() endpoints.BreakingNewsAllAPI { return m.BreakingNewsAllMock }
+
+// BreakingNewsStatus returns BreakingNewsStatusMock
+func (m *API) BreakingNewsStatus() endpoints.BreakingNewsStatusAPI { return m.BreakingNewsStatusMock }
+
+// BreakingNewsAttributesStatus returns BreakingNewsAttributesStatusMock
+func (m *API) BreakingNewsAttributesStatus() endpoints.AttributesAPI {
+	return m.BreakingNewsAttributesStatusMock
+}
+
+// BreakingNewsAttributesType returns BreakingNewsAttributesTypeMock
+func (m *API) BreakingNewsAttributesType() endpoints.AttributesAPI {
+	return m.BreakingNewsAttributesTypeMock
+}
+
+// Categories returns CategoriesMock
+func (m *API) Categories() endpoints.CategoriesAPI { return m.CategoriesMock }
+
+// CustomFieldsByCategory returns CustomFieldsByCategoryMock
+func (m *API) CustomFieldsByCategory() endpoints.CustomFieldsByCategoryAPI {
+	return m.CustomFieldsByCategoryMock
+}
+
+// HelpDesks returns HelpDesksMock
+func (m *API) HelpDesks() endpoints.HelpDesksAPI { return m.HelpDesksMock }
+
+// Incident returns IncidentMock
+func (m *API) Incident() endpoints.IncidentAPI { return m.IncidentMock }
+
+// IncidentApproval returns IncidentApprovalMock
+func (m *API) IncidentApproval() endpoints.IncidentApprovalAPI { return m.IncidentApprovalMock }
+
+// IncidentApprovalAccept returns IncidentApprovalAcceptMock
+func (m *API) IncidentApprovalAccept() endpoints.IncidentApprovalAcceptAPI {
+	return m.IncidentApprovalAcceptMock
+}
+
+// IncidentApprovalAddVoter returns IncidentApprovalAddVoterMock
+func (m *API) IncidentApprovalAddVoter() endpoints.IncidentApprovalAddVoterAPI {
+	return m.IncidentApprovalAddVoterMock
+}
+
+// IncidentApprovalCancel returns IncidentApprovalCancelMock
+func (m *API) IncidentApprovalCancel() endpoints.IncidentApprovalCancelAPI {
+	return m.IncidentApprovalCancelMock
+}
+
+// IncidentApprovalPossibleVoters returns IncidentApprovalPossibleVotersMock
+func (m *API) IncidentApprovalPossibleVoters() endpoints.IncidentApprovalPossibleVotersAPI {
+	return m.IncidentApprovalPossibleVotersMock
+}
+
+// IncidentApprovalReject returns IncidentApprovalRejectMock
+func (m *API) IncidentApprovalReject() endpoints.IncidentApprovalRejectAPI {
+	return m.IncidentApprovalRejectMock
+}
+
+// IncidentApprovalStatus returns IncidentApprovalStatusMock
+func (m *API) IncidentApprovalStatus() endpoints.IncidentApprovalStatusAPI {
+	return m.IncidentApprovalStatusMock
+}
+
+// IncidentApprovalType returns IncidentApprovalTypeMock
+func (m *API) IncidentApprovalType() endpoints.IncidentApprovalTypeAPI {
+	return m.IncidentApprovalTypeMock
+}
+
+// IncidentApprovalVoteStatus returns IncidentApprovalVoteStatusMock
+func (m *API) IncidentApprovalVoteStatus() endpoints.IncidentApprovalVoteStatusAPI {
+	return m.IncidentApprovalVoteStatusMock
+}
+
+// IncidentAttachment returns IncidentAttachmentMock
+func (m *API) IncidentAttachment() endpoints.IncidentAttachmentAPI { return m.IncidentAttachmentMock }
+
+// IncidentAttributesPriority returns IncidentAttributesPriorityMock
+func (m *API) IncidentAttributesPriority() endpoints.AttributesAPI {
+	return m.IncidentAttributesPriorityMock
+}
+
+// IncidentAttributesSource returns IncidentAttributesSourceMock
+func (m *API) IncidentAttributesSource() endpoints.AttributesAPI {
+	return m.IncidentAttributesSourceMock
+}
+
+// IncidentAttributesStatus returns IncidentAttributesStatusMock
+func (m *API) IncidentAttributesStatus() endpoints.AttributesAPI {
+	return m.IncidentAttributesStatusMock
+}
+
+// IncidentAttributesType returns IncidentAttributesTypeMock
+func (m *API) IncidentAttributesType() endpoints.AttributesAPI { return m.IncidentAttributesTypeMock }
+
+// IncidentCancel returns IncidentCancelMock
+func (m *API) IncidentCancel() endpoints.IncidentCancelAPI { return m.IncidentCancelMock }
+
+// IncidentCollaborator returns IncidentCollaboratorMock
+func (m *API) IncidentCollaborator() endpoints.IncidentCollaboratorAPI {
+	return m.IncidentCollaboratorMock
+}
+
+// IncidentComment returns IncidentCommentMock
+func (m *API) IncidentComment() endpoints.IncidentCommentAPI { return m.IncidentCommentMock }
+
+// IncidentCustomApproval returns IncidentCustomApprovalMock
+func (m *API) IncidentCustomApproval() endpoints.IncidentCustomApprovalAPI {
+	return m.IncidentCustomApprovalMock
+}
+
+// IncidentExternalEntity returns IncidentExternalEntityMock
+func (m *API) IncidentExternalEntity() endpoints.IncidentExternalEntityAPI {
+	return m.IncidentExternalEntityMock
+}
+
+// IncidentLink returns IncidentLinkMock
+func (m *API) IncidentLink() endpoints.IncidentLinkAPI { return m.IncidentLinkMock }
+
+// IncidentLinkedCIsCountersFrom returns IncidentLinkedCIsCountersFromMock
+func (m *API) IncidentLinkedCIsCountersFrom() endpoints.IncidentLinkedCIsCountersFromAPI {
+	return m.IncidentLinkedCIsCountersFromMock
+}
+
+// IncidentObserver returns IncidentObserverMock
+func (m *API) IncidentObserver() endpoints.IncidentObserverAPI { return m.IncidentObserverMock }
+
+// IncidentReassign returns IncidentReassignMock
+func (m *API) IncidentReassign() endpoints.IncidentReassignAPI { return m.IncidentReassignMock }
+
+// IncidentReject returns IncidentRejectMock
+func (m *API) IncidentReject() endpoints.IncidentRejectAPI { return m.IncidentRejectMock }
+
+// IncidentReopen returns IncidentReopenMock
+func (m *API) IncidentReopen() endpoints.IncidentReopenAPI { return m.IncidentReopenMock }
+
+// IncidentSolutionAccept returns IncidentSolutionAcceptMock
+func (m *API) IncidentSolutionAccept() endpoints.IncidentSolutionAcceptAPI {
+	return m.IncidentSolutionAcceptMock
+}
+
+// IncidentSolutionReject returns IncidentSolutionRejectMock
+func (m *API) IncidentSolutionReject() endpoints.IncidentSolutionRejectAPI {
+	return m.IncidentSolutionRejectMock
+}
+
+// IncidentSpontaneousApproval returns IncidentSpontaneousApprovalMock
+func (m *API) IncidentSpontaneousApproval() endpoints.IncidentSpontaneousApprovalAPI {
+	return m.IncidentSpontaneousApprovalMock
+}
+
+// IncidentTasks returns IncidentTasksMock
+func (m *API) IncidentTasks() endpoints.IncidentTasksAPI { return m.IncidentTasksMock }
+
+// IncidentWaitingForAgent returns IncidentWaitingForAgentMock
+func (m *API) IncidentWaitingForAgent() endpoints.IncidentWaitingForAgentAPI {
+	return m.IncidentWaitingForAgentMock
+}
+
+// IncidentWaitingForCustomer returns IncidentWaitingForCustomerMock
+func (m *API) IncidentWaitingForCustomer() endpoints.IncidentWaitingForCustomerAPI {
+	return m.IncidentWaitingForCustomerMock
+}
+
+// IncidentWaitingForDate returns IncidentWaitingForDateMock
+func (m *API) IncidentWaitingForDate() endpoints.IncidentWaitingForDateAPI {
+	return m.IncidentWaitingForDateMock
+}
+
+// IncidentWaitingForExternalEntity returns IncidentWaitingForExternalEntityMock
+func (m *API) IncidentWaitingForExternalEntity() endpoints.IncidentWaitingForExternalEntityAPI {
+	return m.IncidentWaitingForExternalEntityMock
+}
+
+// IncidentWaitingForIncident returns IncidentWaitingForIncidentMock
+func (m *API) IncidentWaitingForIncident() endpoints.IncidentWaitingForIncidentAPI {
+	return m.IncidentWaitingForIncidentMock
+}
+
+// Incidents returns IncidentsMock
+func (m *API) Incidents() endpoints.IncidentsAPI { return m.IncidentsMock }
+
+// IncidentsByAgent returns IncidentsByAgentMock
+func (m *API) IncidentsByAgent() endpoints.IncidentsByAgentAPI { return m.IncidentsByAgentMock }
+
+// IncidentsByCIs returns IncidentsByCIsMock
+func (m *API) IncidentsByCIs() endpoints.IncidentsByCIsAPI { return m.IncidentsByCIsMock }
+
+// IncidentsByCustomer returns IncidentsByCustomerMock
+func (m *API) IncidentsByCustomer() endpoints.IncidentsByCustomerAPI {
+	return m.IncidentsByCustomerMock
+}
+
+// IncidentsByHelpDesk returns IncidentsByHelpDeskMock
+func (m *API) IncidentsByHelpDesk() endpoints.IncidentsByHelpDeskAPI {
+	return m.IncidentsByHelpDeskMock
+}
+
+// IncidentsBySentiment returns IncidentsBySentimentMock
+func (m *API) IncidentsBySentiment() endpoints.IncidentsBySentimentAPI {
+	return m.IncidentsBySentimentMock
+}
+
+// IncidentsByStatus returns IncidentsByStatusMock
+func (m *API) IncidentsByStatus() endpoints.IncidentsByStatusAPI { return m.IncidentsByStatusMock }
+
+// IncidentsByView returns IncidentsByViewMock
+func (m *API) IncidentsByView() endpoints.IncidentsByViewAPI { return m.IncidentsByViewMock }
+
+// IncidentsDetailsByView returns IncidentsDetailsByViewMock
+func (m *API) IncidentsDetailsByView() endpoints.IncidentsDetailsByViewAPI {
+	return m.IncidentsDetailsByViewMock
+}
+
+// IncidentsLastHour returns IncidentsLastHourMock
+func (m *API) IncidentsLastHour() endpoints.IncidentsLastHourAPI { return m.IncidentsLastHourMock }
+
+// ServiceDeskVersion returns ServiceDeskVersionMock
+func (m *API) ServiceDeskVersion() endpoints.ServiceDeskVersionAPI { return m.ServiceDeskVersionMock }
+
+// TimeTracking returns TimeTrackingMock
+func (m *API) TimeTracking() endpoints.TimeTrackingAPI { return m.TimeTrackingMock }
+
+// TimeTrackingAttributesCategory returns TimeTrackingAttributesCategoryMock
+func (m *API) TimeTrackingAttributesCategory() endpoints.TimeTrackingAttributesCategoryAPI {
+	return m.TimeTrackingAttributesCategoryMock
+}
+
+// Triggers returns TriggersMock
+func (m *API) Triggers() endpoints.TriggersAPI { return m.TriggersMock }
+
+// TriggersExecutions returns TriggersExecutionsMock
+func (m *API) TriggersExecutions() endpoints.TriggersExecutionsAPI { return m.TriggersExecutionsMock }
+
+// User returns UserMock
+func (m *API) User() endpoints.UserAPI { return m.UserMock }
+
+// UserBy returns UserByMock
+func (m *API) UserBy() endpoints.UserByAPI { return m.UserByMock }
+
+// UserConvert returns UserConvertMock
+func (m *API) UserConvert() endpoints.UserConvertAPI { return m.UserConvertMock }
+
+// UserDisable returns UserDisableMock
+func (m *API) UserDisable() endpoints.UserDisableAPI { return m.UserDisableMock }
+
+// UserEnable returns UserEnableMock
+func (m *API) UserEnable() endpoints.UserEnableAPI { return m.UserEnableMock }
+
+// UserPassword returns UserPasswordMock
+func (m *API) UserPassword() endpoints.UserPasswordAPI { return m.UserPasswordMock }
+
+// UserPasswordReset returns UserPasswordResetMock
+func (m *API) UserPasswordReset() endpoints.UserPasswordResetAPI { return m.UserPasswordResetMock }
+
+// UserToken returns UserTokenMock
+func (m *API) UserToken() endpoints.UserTokenAPI { return m.UserTokenMock }
+
+// Users returns UsersMock
+func (m *API) Users() endpoints.UsersAPI { return m.UsersMock }
+
+// UsersBy returns UsersByMock
+func (m *API) UsersBy() endpoints.UsersByAPI { return m.UsersByMock }
+
+// UsersGroups returns UsersGroupsMock
+func (m *API) UsersGroups() endpoints.UsersGroupsAPI { return m.UsersGroupsMock }
+
+// WorkflowDeploy returns WorkflowDeployMock
+func (m *API) WorkflowDeploy() endpoints.WorkflowDeployAPI { return m.WorkflowDeployMock }
+
+// WorkflowInitialFieldsByCategory returns WorkflowInitialFieldsByCategoryMock
+func (m *API) WorkflowInitialFieldsByCategory() endpoints.WorkflowInitialFieldsByCategoryAPI {
+	return m.WorkflowInitialFieldsByCategoryMock
+}
+
+// AttributesAPI is a fake endpoints.AttributesAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type AttributesAPI struct {
+	recorder
+	GetFunc func(p endpoints.AttributesGetParams) ([]endpoints.AttributesResponse, error)
+}
+
+var _ endpoints.AttributesAPI = (*AttributesAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *AttributesAPI) Get(p endpoints.AttributesGetParams) ([]endpoints.AttributesResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r []endpoints.AttributesResponse
+		return r, notImplemented("AttributesAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// BreakingNewsAllAPI is a fake endpoints.BreakingNewsAllAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type BreakingNewsAllAPI struct {
+	recorder
+	GetFunc func() ([]endpoints.BreakingNewsGetResponse, error)
+}
+
+var _ endpoints.BreakingNewsAllAPI = (*BreakingNewsAllAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *BreakingNewsAllAPI) Get() ([]endpoints.BreakingNewsGetResponse, error) {
+	m.record("Get")
+	if m.GetFunc == nil {
+		var r []endpoints.BreakingNewsGetResponse
+		return r, notImplemented("BreakingNewsAllAPI.Get")
+	}
+	return m.GetFunc()
+}
+
+// BreakingNewsAPI is a fake endpoints.BreakingNewsAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type BreakingNewsAPI struct {
+	recorder
+	GetFunc  func(p endpoints.BreakingNewsGetParams) (endpoints.BreakingNewsGetResponse, error)
+	PostFunc func(p endpoints.BreakingNewsPostParams) (endpoints.BreakingNewsInfoResponse, error)
+	PutFunc  func(p endpoints.BreakingNewsPutParams) (endpoints.BreakingNewsInfoResponse, error)
+}
+
+var _ endpoints.BreakingNewsAPI = (*BreakingNewsAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *BreakingNewsAPI) Get(p endpoints.BreakingNewsGetParams) (endpoints.BreakingNewsGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r endpoints.BreakingNewsGetResponse
+		return r, notImplemented("BreakingNewsAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// Post records the call and calls PostFunc
+func (m *BreakingNewsAPI) Post(p endpoints.BreakingNewsPostParams) (endpoints.BreakingNewsInfoResponse, error) {
+	m.record("Post", p)
+	if m.PostFunc == nil {
+		var r endpoints.BreakingNewsInfoResponse
+		return r, notImplemented("BreakingNewsAPI.Post")
+	}
+	return m.PostFunc(p)
+}
+
+// Put records the call and calls PutFunc
+func (m *BreakingNewsAPI) Put(p endpoints.BreakingNewsPutParams) (endpoints.BreakingNewsInfoResponse, error) {
+	m.record("Put", p)
+	if m.PutFunc == nil {
+		var r endpoints.BreakingNewsInfoResponse
+		return r, notImplemented("BreakingNewsAPI.Put")
+	}
+	return m.PutFunc(p)
+}
+
+// BreakingNewsStatusAPI is a fake endpoints.BreakingNewsStatusAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type BreakingNewsStatusAPI struct {
+	recorder
+	GetFunc  func(p endpoints.BreakingNewsStatusGetParams) ([]endpoints.BreakingNewsStatusGetResponse, error)
+	PostFunc func(p endpoints.BreakingNewsStatusPostParams) (endpoints.BreakingNewsInfoResponse, error)
+}
+
+var _ endpoints.BreakingNewsStatusAPI = (*BreakingNewsStatusAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *BreakingNewsStatusAPI) Get(p endpoints.BreakingNewsStatusGetParams) ([]endpoints.BreakingNewsStatusGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r []endpoints.BreakingNewsStatusGetResponse
+		return r, notImplemented("BreakingNewsStatusAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// Post records the call and calls PostFunc
+func (m *BreakingNewsStatusAPI) Post(p endpoints.BreakingNewsStatusPostParams) (endpoints.BreakingNewsInfoResponse, error) {
+	m.record("Post", p)
+	if m.PostFunc == nil {
+		var r endpoints.BreakingNewsInfoResponse
+		return r, notImplemented("BreakingNewsStatusAPI.Post")
+	}
+	return m.PostFunc(p)
+}
+
+// CategoriesAPI is a fake endpoints.CategoriesAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type CategoriesAPI struct {
+	recorder
+	GetFunc func(p endpoints.CategoriesGetParams) ([]endpoints.CategoriesGetResponse, error)
+}
+
+var _ endpoints.CategoriesAPI = (*CategoriesAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *CategoriesAPI) Get(p endpoints.CategoriesGetParams) ([]endpoints.CategoriesGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r []endpoints.CategoriesGetResponse
+		return r, notImplemented("CategoriesAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// CustomFieldsByCategoryAPI is a fake endpoints.CustomFieldsByCategoryAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type CustomFieldsByCategoryAPI struct {
+	recorder
+	GetFunc func(p endpoints.CustomFieldsByCategoryGetParams) ([]endpoints.CustomFieldDefinition, error)
+}
+
+var _ endpoints.CustomFieldsByCategoryAPI = (*CustomFieldsByCategoryAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *CustomFieldsByCategoryAPI) Get(p endpoints.CustomFieldsByCategoryGetParams) ([]endpoints.CustomFieldDefinition, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r []endpoints.CustomFieldDefinition
+		return r, notImplemented("CustomFieldsByCategoryAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// HelpDesksAPI is a fake endpoints.HelpDesksAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type HelpDesksAPI struct {
+	recorder
+	GetFunc func(p endpoints.HelpDeskGetParams) ([]endpoints.HelpDesksGetResponse, error)
+}
+
+var _ endpoints.HelpDesksAPI = (*HelpDesksAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *HelpDesksAPI) Get(p endpoints.HelpDeskGetParams) ([]endpoints.HelpDesksGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r []endpoints.HelpDesksGetResponse
+		return r, notImplemented("HelpDesksAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// IncidentApprovalAcceptAPI is a fake endpoints.IncidentApprovalAcceptAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentApprovalAcceptAPI struct {
+	recorder
+	PutFunc func(p endpoints.IncidentApprovalAcceptPutParams) (endpoints.IncidentApprovalAcceptPutResponse, error)
+}
+
+var _ endpoints.IncidentApprovalAcceptAPI = (*IncidentApprovalAcceptAPI)(nil)
+
+// Put records the call and calls PutFunc
+func (m *IncidentApprovalAcceptAPI) Put(p endpoints.IncidentApprovalAcceptPutParams) (endpoints.IncidentApprovalAcceptPutResponse, error) {
+	m.record("Put", p)
+	if m.PutFunc == nil {
+		var r endpoints.IncidentApprovalAcceptPutResponse
+		return r, notImplemented("IncidentApprovalAcceptAPI.Put")
+	}
+	return m.PutFunc(p)
+}
+
+// IncidentApprovalAddVoterAPI is a fake endpoints.IncidentApprovalAddVoterAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentApprovalAddVoterAPI struct {
+	recorder
+	PostFunc func(p endpoints.IncidentApprovalAddVoterPostParams) (endpoints.IncidentApprovalAddVoterPostResponse, error)
+}
+
+var _ endpoints.IncidentApprovalAddVoterAPI = (*IncidentApprovalAddVoterAPI)(nil)
+
+// Post records the call and calls PostFunc
+func (m *IncidentApprovalAddVoterAPI) Post(p endpoints.IncidentApprovalAddVoterPostParams) (endpoints.IncidentApprovalAddVoterPostResponse, error) {
+	m.record("Post", p)
+	if m.PostFunc == nil {
+		var r endpoints.IncidentApprovalAddVoterPostResponse
+		return r, notImplemented("IncidentApprovalAddVoterAPI.Post")
+	}
+	return m.PostFunc(p)
+}
+
+// IncidentApprovalCancelAPI is a fake endpoints.IncidentApprovalCancelAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentApprovalCancelAPI struct {
+	recorder
+	PutFunc func(p endpoints.IncidentApprovalCancelPutParams) (endpoints.IncidentApprovalCancelPutResponse, error)
+}
+
+var _ endpoints.IncidentApprovalCancelAPI = (*IncidentApprovalCancelAPI)(nil)
+
+// Put records the call and calls PutFunc
+func (m *IncidentApprovalCancelAPI) Put(p endpoints.IncidentApprovalCancelPutParams) (endpoints.IncidentApprovalCancelPutResponse, error) {
+	m.record("Put", p)
+	if m.PutFunc == nil {
+		var r endpoints.IncidentApprovalCancelPutResponse
+		return r, notImplemented("IncidentApprovalCancelAPI.Put")
+	}
+	return m.PutFunc(p)
+}
+
+// IncidentApprovalAPI is a fake endpoints.IncidentApprovalAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentApprovalAPI struct {
+	recorder
+	GetFunc func(p endpoints.IncidentApprovalGetParams) ([]endpoints.IncidentApprovalGetResponse, error)
+}
+
+var _ endpoints.IncidentApprovalAPI = (*IncidentApprovalAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentApprovalAPI) Get(p endpoints.IncidentApprovalGetParams) ([]endpoints.IncidentApprovalGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r []endpoints.IncidentApprovalGetResponse
+		return r, notImplemented("IncidentApprovalAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// IncidentApprovalPossibleVotersAPI is a fake endpoints.IncidentApprovalPossibleVotersAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentApprovalPossibleVotersAPI struct {
+	recorder
+	GetFunc func(p endpoints.IncidentApprovalPossibleVotersGetParams) ([]endpoints.IncidentApprovalPossibleVotersGetResponse, error)
+}
+
+var _ endpoints.IncidentApprovalPossibleVotersAPI = (*IncidentApprovalPossibleVotersAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentApprovalPossibleVotersAPI) Get(p endpoints.IncidentApprovalPossibleVotersGetParams) ([]endpoints.IncidentApprovalPossibleVotersGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r []endpoints.IncidentApprovalPossibleVotersGetResponse
+		return r, notImplemented("IncidentApprovalPossibleVotersAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// IncidentApprovalRejectAPI is a fake endpoints.IncidentApprovalRejectAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentApprovalRejectAPI struct {
+	recorder
+	PutFunc func(p endpoints.IncidentApprovalRejectPutParams) (endpoints.IncidentApprovalRejectPutResponse, error)
+}
+
+var _ endpoints.IncidentApprovalRejectAPI = (*IncidentApprovalRejectAPI)(nil)
+
+// Put records the call and calls PutFunc
+func (m *IncidentApprovalRejectAPI) Put(p endpoints.IncidentApprovalRejectPutParams) (endpoints.IncidentApprovalRejectPutResponse, error) {
+	m.record("Put", p)
+	if m.PutFunc == nil {
+		var r endpoints.IncidentApprovalRejectPutResponse
+		return r, notImplemented("IncidentApprovalRejectAPI.Put")
+	}
+	return m.PutFunc(p)
+}
+
+// IncidentApprovalStatusAPI is a fake endpoints.IncidentApprovalStatusAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentApprovalStatusAPI struct {
+	recorder
+	GetFunc func() ([]endpoints.IncidentApprovalStatusGetResponse, error)
+}
+
+var _ endpoints.IncidentApprovalStatusAPI = (*IncidentApprovalStatusAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentApprovalStatusAPI) Get() ([]endpoints.IncidentApprovalStatusGetResponse, error) {
+	m.record("Get")
+	if m.GetFunc == nil {
+		var r []endpoints.IncidentApprovalStatusGetResponse
+		return r, notImplemented("IncidentApprovalStatusAPI.Get")
+	}
+	return m.GetFunc()
+}
+
+// IncidentApprovalTypeAPI is a fake endpoints.IncidentApprovalTypeAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentApprovalTypeAPI struct {
+	recorder
+	GetFunc func() ([]endpoints.IncidentApprovalTypeGetResponse, error)
+}
+
+var _ endpoints.IncidentApprovalTypeAPI = (*IncidentApprovalTypeAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentApprovalTypeAPI) Get() ([]endpoints.IncidentApprovalTypeGetResponse, error) {
+	m.record("Get")
+	if m.GetFunc == nil {
+		var r []endpoints.IncidentApprovalTypeGetResponse
+		return r, notImplemented("IncidentApprovalTypeAPI.Get")
+	}
+	return m.GetFunc()
+}
+
+// IncidentApprovalVoteStatusAPI is a fake endpoints.IncidentApprovalVoteStatusAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentApprovalVoteStatusAPI struct {
+	recorder
+	GetFunc func() ([]endpoints.IncidentApprovalVoteStatusGetResponse, error)
+}
+
+var _ endpoints.IncidentApprovalVoteStatusAPI = (*IncidentApprovalVoteStatusAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentApprovalVoteStatusAPI) Get() ([]endpoints.IncidentApprovalVoteStatusGetResponse, error) {
+	m.record("Get")
+	if m.GetFunc == nil {
+		var r []endpoints.IncidentApprovalVoteStatusGetResponse
+		return r, notImplemented("IncidentApprovalVoteStatusAPI.Get")
+	}
+	return m.GetFunc()
+}
+
+// IncidentAttachmentAPI is a fake endpoints.IncidentAttachmentAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentAttachmentAPI struct {
+	recorder
+	GetFunc func(p endpoints.IncidentAttachmentGetParams) (endpoints.IncidentAttachmentGetResponse, error)
+}
+
+var _ endpoints.IncidentAttachmentAPI = (*IncidentAttachmentAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentAttachmentAPI) Get(p endpoints.IncidentAttachmentGetParams) (endpoints.IncidentAttachmentGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r endpoints.IncidentAttachmentGetResponse
+		return r, notImplemented("IncidentAttachmentAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// IncidentCancelAPI is a fake endpoints.IncidentCancelAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentCancelAPI struct {
+	recorder
+	PostFunc func(p endpoints.IncidentCancelPostParams) (endpoints.IncidentCancelPostResponse, error)
+}
+
+var _ endpoints.IncidentCancelAPI = (*IncidentCancelAPI)(nil)
+
+// Post records the call and calls PostFunc
+func (m *IncidentCancelAPI) Post(p endpoints.IncidentCancelPostParams) (endpoints.IncidentCancelPostResponse, error) {
+	m.record("Post", p)
+	if m.PostFunc == nil {
+		var r endpoints.IncidentCancelPostResponse
+		return r, notImplemented("IncidentCancelAPI.Post")
+	}
+	return m.PostFunc(p)
+}
+
+// IncidentCollaboratorAPI is a fake endpoints.IncidentCollaboratorAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentCollaboratorAPI struct {
+	recorder
+	GetFunc  func(p endpoints.IncidentCollaboratorGetParams) (endpoints.IncidentCollaboratorGetResponse, error)
+	PostFunc func(p endpoints.IncidentCollaboratorPostParams) (endpoints.IncidentCollaboratorPostResponse, error)
+}
+
+var _ endpoints.IncidentCollaboratorAPI = (*IncidentCollaboratorAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentCollaboratorAPI) Get(p endpoints.IncidentCollaboratorGetParams) (endpoints.IncidentCollaboratorGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r endpoints.IncidentCollaboratorGetResponse
+		return r, notImplemented("IncidentCollaboratorAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// Post records the call and calls PostFunc
+func (m *IncidentCollaboratorAPI) Post(p endpoints.IncidentCollaboratorPostParams) (endpoints.IncidentCollaboratorPostResponse, error) {
+	m.record("Post", p)
+	if m.PostFunc == nil {
+		var r endpoints.IncidentCollaboratorPostResponse
+		return r, notImplemented("IncidentCollaboratorAPI.Post")
+	}
+	return m.PostFunc(p)
+}
+
+// IncidentCommentAPI is a fake endpoints.IncidentCommentAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentCommentAPI struct {
+	recorder
+	GetFunc  func(p endpoints.IncidentCommentGetParams) ([]endpoints.IncidentCommentGetResponse, error)
+	PostFunc func(p endpoints.IncidentCommentPostParams) (endpoints.IncidentCommentPostResponse, error)
+}
+
+var _ endpoints.IncidentCommentAPI = (*IncidentCommentAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentCommentAPI) Get(p endpoints.IncidentCommentGetParams) ([]endpoints.IncidentCommentGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r []endpoints.IncidentCommentGetResponse
+		return r, notImplemented("IncidentCommentAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// Post records the call and calls PostFunc
+func (m *IncidentCommentAPI) Post(p endpoints.IncidentCommentPostParams) (endpoints.IncidentCommentPostResponse, error) {
+	m.record("Post", p)
+	if m.PostFunc == nil {
+		var r endpoints.IncidentCommentPostResponse
+		return r, notImplemented("IncidentCommentAPI.Post")
+	}
+	return m.PostFunc(p)
+}
+
+// IncidentCustomApprovalAPI is a fake endpoints.IncidentCustomApprovalAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentCustomApprovalAPI struct {
+	recorder
+	GetFunc  func(p endpoints.IncidentCustomApprovalGetParams) ([]endpoints.IncidentCustomApprovalGetResponse, error)
+	PostFunc func(p endpoints.IncidentCustomApprovalPostParams) (endpoints.IncidentCustomApprovalPostResponse, error)
+}
+
+var _ endpoints.IncidentCustomApprovalAPI = (*IncidentCustomApprovalAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentCustomApprovalAPI) Get(p endpoints.IncidentCustomApprovalGetParams) ([]endpoints.IncidentCustomApprovalGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r []endpoints.IncidentCustomApprovalGetResponse
+		return r, notImplemented("IncidentCustomApprovalAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// Post records the call and calls PostFunc
+func (m *IncidentCustomApprovalAPI) Post(p endpoints.IncidentCustomApprovalPostParams) (endpoints.IncidentCustomApprovalPostResponse, error) {
+	m.record("Post", p)
+	if m.PostFunc == nil {
+		var r endpoints.IncidentCustomApprovalPostResponse
+		return r, notImplemented("IncidentCustomApprovalAPI.Post")
+	}
+	return m.PostFunc(p)
+}
+
+// IncidentExternalEntityAPI is a fake endpoints.IncidentExternalEntityAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentExternalEntityAPI struct {
+	recorder
+	GetFunc  func(p endpoints.IncidentExternalEntityGetParams) ([]endpoints.IncidentExternalEntityGetResponse, error)
+	PostFunc func(p endpoints.IncidentExternalEntityPostParams) (endpoints.IncidentExternalEntityPostResponse, error)
+}
+
+var _ endpoints.IncidentExternalEntityAPI = (*IncidentExternalEntityAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentExternalEntityAPI) Get(p endpoints.IncidentExternalEntityGetParams) ([]endpoints.IncidentExternalEntityGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r []endpoints.IncidentExternalEntityGetResponse
+		return r, notImplemented("IncidentExternalEntityAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// Post records the call and calls PostFunc
+func (m *IncidentExternalEntityAPI) Post(p endpoints.IncidentExternalEntityPostParams) (endpoints.IncidentExternalEntityPostResponse, error) {
+	m.record("Post", p)
+	if m.PostFunc == nil {
+		var r endpoints.IncidentExternalEntityPostResponse
+		return r, notImplemented("IncidentExternalEntityAPI.Post")
+	}
+	return m.PostFunc(p)
+}
+
+// IncidentLinkAPI is a fake endpoints.IncidentLinkAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentLinkAPI struct {
+	recorder
+	GetFunc  func(p endpoints.IncidentLinkGetParams) ([]endpoints.IncidentLinkGetResponse, error)
+	PostFunc func(p endpoints.IncidentLinkPostParams) (endpoints.IncidentLinkPostResponse, error)
+}
+
+var _ endpoints.IncidentLinkAPI = (*IncidentLinkAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentLinkAPI) Get(p endpoints.IncidentLinkGetParams) ([]endpoints.IncidentLinkGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r []endpoints.IncidentLinkGetResponse
+		return r, notImplemented("IncidentLinkAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// Post records the call and calls PostFunc
+func (m *IncidentLinkAPI) Post(p endpoints.IncidentLinkPostParams) (endpoints.IncidentLinkPostResponse, error) {
+	m.record("Post", p)
+	if m.PostFunc == nil {
+		var r endpoints.IncidentLinkPostResponse
+		return r, notImplemented("IncidentLinkAPI.Post")
+	}
+	return m.PostFunc(p)
+}
+
+// IncidentLinkedCIsCountersFromAPI is a fake endpoints.IncidentLinkedCIsCountersFromAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentLinkedCIsCountersFromAPI struct {
+	recorder
+	GetFunc func(p endpoints.IncidentLinkedCIsCountersFromGetParams) ([]endpoints.IncidentLinkedCIsCountersFromGetResponse, error)
+}
+
+var _ endpoints.IncidentLinkedCIsCountersFromAPI = (*IncidentLinkedCIsCountersFromAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentLinkedCIsCountersFromAPI) Get(p endpoints.IncidentLinkedCIsCountersFromGetParams) ([]endpoints.IncidentLinkedCIsCountersFromGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r []endpoints.IncidentLinkedCIsCountersFromGetResponse
+		return r, notImplemented("IncidentLinkedCIsCountersFromAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// IncidentAPI is a fake endpoints.IncidentAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentAPI struct {
+	recorder
+	GetFunc  func(p endpoints.IncidentGetParams) ([]endpoints.Incident, error)
+	PostFunc func(p endpoints.IncidentPostParams) (endpoints.IncidentPostResponse, error)
+	PutFunc  func(p endpoints.IncidentPutParams) ([]endpoints.Incident, error)
+}
+
+var _ endpoints.IncidentAPI = (*IncidentAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentAPI) Get(p endpoints.IncidentGetParams) ([]endpoints.Incident, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r []endpoints.Incident
+		return r, notImplemented("IncidentAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// Post records the call and calls PostFunc
+func (m *IncidentAPI) Post(p endpoints.IncidentPostParams) (endpoints.IncidentPostResponse, error) {
+	m.record("Post", p)
+	if m.PostFunc == nil {
+		var r endpoints.IncidentPostResponse
+		return r, notImplemented("IncidentAPI.Post")
+	}
+	return m.PostFunc(p)
+}
+
+// Put records the call and calls PutFunc
+func (m *IncidentAPI) Put(p endpoints.IncidentPutParams) ([]endpoints.Incident, error) {
+	m.record("Put", p)
+	if m.PutFunc == nil {
+		var r []endpoints.Incident
+		return r, notImplemented("IncidentAPI.Put")
+	}
+	return m.PutFunc(p)
+}
+
+// IncidentObserverAPI is a fake endpoints.IncidentObserverAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentObserverAPI struct {
+	recorder
+	GetFunc  func(p endpoints.IncidentObserverGetParams) (endpoints.IncidentObserverGetResponse, error)
+	PostFunc func(p endpoints.IncidentObserverPostParams) (endpoints.IncidentObserverPostResponse, error)
+}
+
+var _ endpoints.IncidentObserverAPI = (*IncidentObserverAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentObserverAPI) Get(p endpoints.IncidentObserverGetParams) (endpoints.IncidentObserverGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r endpoints.IncidentObserverGetResponse
+		return r, notImplemented("IncidentObserverAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// Post records the call and calls PostFunc
+func (m *IncidentObserverAPI) Post(p endpoints.IncidentObserverPostParams) (endpoints.IncidentObserverPostResponse, error) {
+	m.record("Post", p)
+	if m.PostFunc == nil {
+		var r endpoints.IncidentObserverPostResponse
+		return r, notImplemented("IncidentObserverAPI.Post")
+	}
+	return m.PostFunc(p)
+}
+
+// IncidentReassignAPI is a fake endpoints.IncidentReassignAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentReassignAPI struct {
+	recorder
+	PostFunc func(p endpoints.IncidentReassignPostParams) (endpoints.IncidentReassignPostResponse, error)
+}
+
+var _ endpoints.IncidentReassignAPI = (*IncidentReassignAPI)(nil)
+
+// Post records the call and calls PostFunc
+func (m *IncidentReassignAPI) Post(p endpoints.IncidentReassignPostParams) (endpoints.IncidentReassignPostResponse, error) {
+	m.record("Post", p)
+	if m.PostFunc == nil {
+		var r endpoints.IncidentReassignPostResponse
+		return r, notImplemented("IncidentReassignAPI.Post")
+	}
+	return m.PostFunc(p)
+}
+
+// IncidentRejectAPI is a fake endpoints.IncidentRejectAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentRejectAPI struct {
+	recorder
+	PostFunc func(p endpoints.IncidentRejectPostParams) (endpoints.IncidentRejectPostResponse, error)
+}
+
+var _ endpoints.IncidentRejectAPI = (*IncidentRejectAPI)(nil)
+
+// Post records the call and calls PostFunc
+func (m *IncidentRejectAPI) Post(p endpoints.IncidentRejectPostParams) (endpoints.IncidentRejectPostResponse, error) {
+	m.record("Post", p)
+	if m.PostFunc == nil {
+		var r endpoints.IncidentRejectPostResponse
+		return r, notImplemented("IncidentRejectAPI.Post")
+	}
+	return m.PostFunc(p)
+}
+
+// IncidentReopenAPI is a fake endpoints.IncidentReopenAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentReopenAPI struct {
+	recorder
+	PutFunc func(p endpoints.IncidentReopenPutParams) (endpoints.IncidentReopenPutResponse, error)
+}
+
+var _ endpoints.IncidentReopenAPI = (*IncidentReopenAPI)(nil)
+
+// Put records the call and calls PutFunc
+func (m *IncidentReopenAPI) Put(p endpoints.IncidentReopenPutParams) (endpoints.IncidentReopenPutResponse, error) {
+	m.record("Put", p)
+	if m.PutFunc == nil {
+		var r endpoints.IncidentReopenPutResponse
+		return r, notImplemented("IncidentReopenAPI.Put")
+	}
+	return m.PutFunc(p)
+}
+
+// IncidentSolutionAcceptAPI is a fake endpoints.IncidentSolutionAcceptAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentSolutionAcceptAPI struct {
+	recorder
+	PutFunc func(p endpoints.IncidentSolutionAcceptPutParams) (endpoints.IncidentSolutionAcceptPutResponse, error)
+}
+
+var _ endpoints.IncidentSolutionAcceptAPI = (*IncidentSolutionAcceptAPI)(nil)
+
+// Put records the call and calls PutFunc
+func (m *IncidentSolutionAcceptAPI) Put(p endpoints.IncidentSolutionAcceptPutParams) (endpoints.IncidentSolutionAcceptPutResponse, error) {
+	m.record("Put", p)
+	if m.PutFunc == nil {
+		var r endpoints.IncidentSolutionAcceptPutResponse
+		return r, notImplemented("IncidentSolutionAcceptAPI.Put")
+	}
+	return m.PutFunc(p)
+}
+
+// IncidentSolutionRejectAPI is a fake endpoints.IncidentSolutionRejectAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentSolutionRejectAPI struct {
+	recorder
+	PutFunc func(p endpoints.IncidentSolutionRejectPutParams) (endpoints.IncidentSolutionRejectPutResponse, error)
+}
+
+var _ endpoints.IncidentSolutionRejectAPI = (*IncidentSolutionRejectAPI)(nil)
+
+// Put records the call and calls PutFunc
+func (m *IncidentSolutionRejectAPI) Put(p endpoints.IncidentSolutionRejectPutParams) (endpoints.IncidentSolutionRejectPutResponse, error) {
+	m.record("Put", p)
+	if m.PutFunc == nil {
+		var r endpoints.IncidentSolutionRejectPutResponse
+		return r, notImplemented("IncidentSolutionRejectAPI.Put")
+	}
+	return m.PutFunc(p)
+}
+
+// IncidentSpontaneousApprovalAPI is a fake endpoints.IncidentSpontaneousApprovalAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentSpontaneousApprovalAPI struct {
+	recorder
+	PostFunc func(p endpoints.IncidentSpontaneousApprovalPostParams) (endpoints.IncidentSpontaneousApprovalPostResponse, error)
+}
+
+var _ endpoints.IncidentSpontaneousApprovalAPI = (*IncidentSpontaneousApprovalAPI)(nil)
+
+// Post records the call and calls PostFunc
+func (m *IncidentSpontaneousApprovalAPI) Post(p endpoints.IncidentSpontaneousApprovalPostParams) (endpoints.IncidentSpontaneousApprovalPostResponse, error) {
+	m.record("Post", p)
+	if m.PostFunc == nil {
+		var r endpoints.IncidentSpontaneousApprovalPostResponse
+		return r, notImplemented("IncidentSpontaneousApprovalAPI.Post")
+	}
+	return m.PostFunc(p)
+}
+
+// IncidentTasksAPI is a fake endpoints.IncidentTasksAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentTasksAPI struct {
+	recorder
+	GetFunc func(p endpoints.IncidentTasksGetParams) ([]endpoints.IncidentTasksGetResponse, error)
+}
+
+var _ endpoints.IncidentTasksAPI = (*IncidentTasksAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentTasksAPI) Get(p endpoints.IncidentTasksGetParams) ([]endpoints.IncidentTasksGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r []endpoints.IncidentTasksGetResponse
+		return r, notImplemented("IncidentTasksAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// IncidentWaitingForAgentAPI is a fake endpoints.IncidentWaitingForAgentAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentWaitingForAgentAPI struct {
+	recorder
+	PostFunc func(p endpoints.IncidentWaitingForAgentPostParams) (endpoints.IncidentWaitingForAgentPostResponse, error)
+}
+
+var _ endpoints.IncidentWaitingForAgentAPI = (*IncidentWaitingForAgentAPI)(nil)
+
+// Post records the call and calls PostFunc
+func (m *IncidentWaitingForAgentAPI) Post(p endpoints.IncidentWaitingForAgentPostParams) (endpoints.IncidentWaitingForAgentPostResponse, error) {
+	m.record("Post", p)
+	if m.PostFunc == nil {
+		var r endpoints.IncidentWaitingForAgentPostResponse
+		return r, notImplemented("IncidentWaitingForAgentAPI.Post")
+	}
+	return m.PostFunc(p)
+}
+
+// IncidentWaitingForCustomerAPI is a fake endpoints.IncidentWaitingForCustomerAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentWaitingForCustomerAPI struct {
+	recorder
+	PostFunc func(p endpoints.IncidentWaitingForCustomerPostParams) (endpoints.IncidentWaitingForCustomerPostResponse, error)
+}
+
+var _ endpoints.IncidentWaitingForCustomerAPI = (*IncidentWaitingForCustomerAPI)(nil)
+
+// Post records the call and calls PostFunc
+func (m *IncidentWaitingForCustomerAPI) Post(p endpoints.IncidentWaitingForCustomerPostParams) (endpoints.IncidentWaitingForCustomerPostResponse, error) {
+	m.record("Post", p)
+	if m.PostFunc == nil {
+		var r endpoints.IncidentWaitingForCustomerPostResponse
+		return r, notImplemented("IncidentWaitingForCustomerAPI.Post")
+	}
+	return m.PostFunc(p)
+}
+
+// IncidentWaitingForDateAPI is a fake endpoints.IncidentWaitingForDateAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentWaitingForDateAPI struct {
+	recorder
+	PostFunc func(p endpoints.IncidentWaitingForDatePostParams) (endpoints.IncidentWaitingForDatePostResponse, error)
+}
+
+var _ endpoints.IncidentWaitingForDateAPI = (*IncidentWaitingForDateAPI)(nil)
+
+// Post records the call and calls PostFunc
+func (m *IncidentWaitingForDateAPI) Post(p endpoints.IncidentWaitingForDatePostParams) (endpoints.IncidentWaitingForDatePostResponse, error) {
+	m.record("Post", p)
+	if m.PostFunc == nil {
+		var r endpoints.IncidentWaitingForDatePostResponse
+		return r, notImplemented("IncidentWaitingForDateAPI.Post")
+	}
+	return m.PostFunc(p)
+}
+
+// IncidentWaitingForExternalEntityAPI is a fake endpoints.IncidentWaitingForExternalEntityAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentWaitingForExternalEntityAPI struct {
+	recorder
+	PostFunc func(p endpoints.IncidentWaitingForExternalEntityPostParams) (endpoints.IncidentWaitingForExternalEntityPostResponse, error)
+}
+
+var _ endpoints.IncidentWaitingForExternalEntityAPI = (*IncidentWaitingForExternalEntityAPI)(nil)
+
+// Post records the call and calls PostFunc
+func (m *IncidentWaitingForExternalEntityAPI) Post(p endpoints.IncidentWaitingForExternalEntityPostParams) (endpoints.IncidentWaitingForExternalEntityPostResponse, error) {
+	m.record("Post", p)
+	if m.PostFunc == nil {
+		var r endpoints.IncidentWaitingForExternalEntityPostResponse
+		return r, notImplemented("IncidentWaitingForExternalEntityAPI.Post")
+	}
+	return m.PostFunc(p)
+}
+
+// IncidentWaitingForIncidentAPI is a fake endpoints.IncidentWaitingForIncidentAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentWaitingForIncidentAPI struct {
+	recorder
+	PostFunc func(p endpoints.IncidentWaitingForIncidentPostParams) (endpoints.IncidentWaitingForIncidentPostResponse, error)
+}
+
+var _ endpoints.IncidentWaitingForIncidentAPI = (*IncidentWaitingForIncidentAPI)(nil)
+
+// Post records the call and calls PostFunc
+func (m *IncidentWaitingForIncidentAPI) Post(p endpoints.IncidentWaitingForIncidentPostParams) (endpoints.IncidentWaitingForIncidentPostResponse, error) {
+	m.record("Post", p)
+	if m.PostFunc == nil {
+		var r endpoints.IncidentWaitingForIncidentPostResponse
+		return r, notImplemented("IncidentWaitingForIncidentAPI.Post")
+	}
+	return m.PostFunc(p)
+}
+
+// IncidentsByAgentAPI is a fake endpoints.IncidentsByAgentAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentsByAgentAPI struct {
+	recorder
+	GetFunc func(p endpoints.IncidentsByAgentGetParams) (endpoints.IncidentsByAgentGetResponse, error)
+}
+
+var _ endpoints.IncidentsByAgentAPI = (*IncidentsByAgentAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentsByAgentAPI) Get(p endpoints.IncidentsByAgentGetParams) (endpoints.IncidentsByAgentGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r endpoints.IncidentsByAgentGetResponse
+		return r, notImplemented("IncidentsByAgentAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// IncidentsByCIsAPI is a fake endpoints.IncidentsByCIsAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentsByCIsAPI struct {
+	recorder
+	GetFunc func(p endpoints.IncidentsByCIsGetParams) (endpoints.IncidentsByCIsGetResponse, error)
+}
+
+var _ endpoints.IncidentsByCIsAPI = (*IncidentsByCIsAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentsByCIsAPI) Get(p endpoints.IncidentsByCIsGetParams) (endpoints.IncidentsByCIsGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r endpoints.IncidentsByCIsGetResponse
+		return r, notImplemented("IncidentsByCIsAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// IncidentsByCustomerAPI is a fake endpoints.IncidentsByCustomerAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentsByCustomerAPI struct {
+	recorder
+	GetFunc func(p endpoints.IncidentsByCustomerGetParams) (endpoints.IncidentsByCustomerGetResponse, error)
+}
+
+var _ endpoints.IncidentsByCustomerAPI = (*IncidentsByCustomerAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentsByCustomerAPI) Get(p endpoints.IncidentsByCustomerGetParams) (endpoints.IncidentsByCustomerGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r endpoints.IncidentsByCustomerGetResponse
+		return r, notImplemented("IncidentsByCustomerAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// IncidentsByHelpDeskAPI is a fake endpoints.IncidentsByHelpDeskAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentsByHelpDeskAPI struct {
+	recorder
+	GetFunc func(p endpoints.IncidentsByHelpDeskGetParams) (endpoints.IncidentsByHelpDeskGetResponse, error)
+}
+
+var _ endpoints.IncidentsByHelpDeskAPI = (*IncidentsByHelpDeskAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentsByHelpDeskAPI) Get(p endpoints.IncidentsByHelpDeskGetParams) (endpoints.IncidentsByHelpDeskGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r endpoints.IncidentsByHelpDeskGetResponse
+		return r, notImplemented("IncidentsByHelpDeskAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// IncidentsBySentimentAPI is a fake endpoints.IncidentsBySentimentAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentsBySentimentAPI struct {
+	recorder
+	GetFunc func(p endpoints.IncidentsBySentimentGetParams) (endpoints.IncidentsBySentimentGetResponse, error)
+}
+
+var _ endpoints.IncidentsBySentimentAPI = (*IncidentsBySentimentAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentsBySentimentAPI) Get(p endpoints.IncidentsBySentimentGetParams) (endpoints.IncidentsBySentimentGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r endpoints.IncidentsBySentimentGetResponse
+		return r, notImplemented("IncidentsBySentimentAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// IncidentsByStatusAPI is a fake endpoints.IncidentsByStatusAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentsByStatusAPI struct {
+	recorder
+	GetFunc func(p endpoints.IncidentsByStatusGetParams) (endpoints.IncidentsByStatusGetResponse, error)
+}
+
+var _ endpoints.IncidentsByStatusAPI = (*IncidentsByStatusAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentsByStatusAPI) Get(p endpoints.IncidentsByStatusGetParams) (endpoints.IncidentsByStatusGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r endpoints.IncidentsByStatusGetResponse
+		return r, notImplemented("IncidentsByStatusAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// IncidentsByViewAPI is a fake endpoints.IncidentsByViewAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentsByViewAPI struct {
+	recorder
+	GetFunc func(p endpoints.IncidentsByViewGetParams) (endpoints.IncidentsByViewGetResponse, error)
+}
+
+var _ endpoints.IncidentsByViewAPI = (*IncidentsByViewAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentsByViewAPI) Get(p endpoints.IncidentsByViewGetParams) (endpoints.IncidentsByViewGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r endpoints.IncidentsByViewGetResponse
+		return r, notImplemented("IncidentsByViewAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// IncidentsDetailsByViewAPI is a fake endpoints.IncidentsDetailsByViewAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentsDetailsByViewAPI struct {
+	recorder
+	GetFunc func(p endpoints.IncidentsDetailsByViewGetParams) (endpoints.IncidentsDetailsByViewGetResponse, error)
+}
+
+var _ endpoints.IncidentsDetailsByViewAPI = (*IncidentsDetailsByViewAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentsDetailsByViewAPI) Get(p endpoints.IncidentsDetailsByViewGetParams) (endpoints.IncidentsDetailsByViewGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r endpoints.IncidentsDetailsByViewGetResponse
+		return r, notImplemented("IncidentsDetailsByViewAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// IncidentsLastHourAPI is a fake endpoints.IncidentsLastHourAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentsLastHourAPI struct {
+	recorder
+	GetFunc func(p endpoints.IncidentsLastHourGetParams) ([]endpoints.IncidentsLastHourGetResponse, error)
+}
+
+var _ endpoints.IncidentsLastHourAPI = (*IncidentsLastHourAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentsLastHourAPI) Get(p endpoints.IncidentsLastHourGetParams) ([]endpoints.IncidentsLastHourGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r []endpoints.IncidentsLastHourGetResponse
+		return r, notImplemented("IncidentsLastHourAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// IncidentsAPI is a fake endpoints.IncidentsAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type IncidentsAPI struct {
+	recorder
+	GetFunc func(p endpoints.IncidentsGetParams) ([]endpoints.Incident, error)
+}
+
+var _ endpoints.IncidentsAPI = (*IncidentsAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *IncidentsAPI) Get(p endpoints.IncidentsGetParams) ([]endpoints.Incident, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r []endpoints.Incident
+		return r, notImplemented("IncidentsAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// ServiceDeskVersionAPI is a fake endpoints.ServiceDeskVersionAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type ServiceDeskVersionAPI struct {
+	recorder
+	GetFunc func() (string, error)
+}
+
+var _ endpoints.ServiceDeskVersionAPI = (*ServiceDeskVersionAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *ServiceDeskVersionAPI) Get() (string, error) {
+	m.record("Get")
+	if m.GetFunc == nil {
+		var r string
+		return r, notImplemented("ServiceDeskVersionAPI.Get")
+	}
+	return m.GetFunc()
+}
+
+// TimeTrackingAttributesCategoryAPI is a fake endpoints.TimeTrackingAttributesCategoryAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type TimeTrackingAttributesCategoryAPI struct {
+	recorder
+	GetFunc func(p endpoints.TimeTrackingAttributesCategoryGetParams) ([]endpoints.TimeTrackingAttributesCategoryGetResponse, error)
+}
+
+var _ endpoints.TimeTrackingAttributesCategoryAPI = (*TimeTrackingAttributesCategoryAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *TimeTrackingAttributesCategoryAPI) Get(p endpoints.TimeTrackingAttributesCategoryGetParams) ([]endpoints.TimeTrackingAttributesCategoryGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r []endpoints.TimeTrackingAttributesCategoryGetResponse
+		return r, notImplemented("TimeTrackingAttributesCategoryAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// TimeTrackingAPI is a fake endpoints.TimeTrackingAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type TimeTrackingAPI struct {
+	recorder
+	DeleteFunc func(p endpoints.TimeTrackingDeleteParams) (endpoints.TimeTrackingDeleteResponse, error)
+	GetFunc    func(p endpoints.TimeTrackingGetParams) ([]endpoints.TimeTrackingGetResponse, error)
+	PostFunc   func(p endpoints.TimeTrackingPostParams) (endpoints.TimeTrackingPostResponse, error)
+}
+
+var _ endpoints.TimeTrackingAPI = (*TimeTrackingAPI)(nil)
+
+// Delete records the call and calls DeleteFunc
+func (m *TimeTrackingAPI) Delete(p endpoints.TimeTrackingDeleteParams) (endpoints.TimeTrackingDeleteResponse, error) {
+	m.record("Delete", p)
+	if m.DeleteFunc == nil {
+		var r endpoints.TimeTrackingDeleteResponse
+		return r, notImplemented("TimeTrackingAPI.Delete")
+	}
+	return m.DeleteFunc(p)
+}
+
+// Get records the call and calls GetFunc
+func (m *TimeTrackingAPI) Get(p endpoints.TimeTrackingGetParams) ([]endpoints.TimeTrackingGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r []endpoints.TimeTrackingGetResponse
+		return r, notImplemented("TimeTrackingAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// Post records the call and calls PostFunc
+func (m *TimeTrackingAPI) Post(p endpoints.TimeTrackingPostParams) (endpoints.TimeTrackingPostResponse, error) {
+	m.record("Post", p)
+	if m.PostFunc == nil {
+		var r endpoints.TimeTrackingPostResponse
+		return r, notImplemented("TimeTrackingAPI.Post")
+	}
+	return m.PostFunc(p)
+}
+
+// TriggersExecutionsAPI is a fake endpoints.TriggersExecutionsAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type TriggersExecutionsAPI struct {
+	recorder
+	GetFunc func(p endpoints.TriggersGetParams) ([]endpoints.TriggersExecutionsGetResponse, error)
+}
+
+var _ endpoints.TriggersExecutionsAPI = (*TriggersExecutionsAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *TriggersExecutionsAPI) Get(p endpoints.TriggersGetParams) ([]endpoints.TriggersExecutionsGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r []endpoints.TriggersExecutionsGetResponse
+		return r, notImplemented("TriggersExecutionsAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// TriggersAPI is a fake endpoints.TriggersAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type TriggersAPI struct {
+	recorder
+	GetFunc func(p endpoints.TriggersGetParams) ([]endpoints.TriggersGetResponse, error)
+}
+
+var _ endpoints.TriggersAPI = (*TriggersAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *TriggersAPI) Get(p endpoints.TriggersGetParams) ([]endpoints.TriggersGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r []endpoints.TriggersGetResponse
+		return r, notImplemented("TriggersAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// UserByAPI is a fake endpoints.UserByAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type UserByAPI struct {
+	recorder
+	GetFunc func(p endpoints.UserByGetParams) (endpoints.UserByGetResponse, error)
+}
+
+var _ endpoints.UserByAPI = (*UserByAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *UserByAPI) Get(p endpoints.UserByGetParams) (endpoints.UserByGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r endpoints.UserByGetResponse
+		return r, notImplemented("UserByAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// UserConvertAPI is a fake endpoints.UserConvertAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type UserConvertAPI struct {
+	recorder
+	PostFunc func(p endpoints.UserConvertPostParams) (endpoints.UserConvertPostResponse, error)
+}
+
+var _ endpoints.UserConvertAPI = (*UserConvertAPI)(nil)
+
+// Post records the call and calls PostFunc
+func (m *UserConvertAPI) Post(p endpoints.UserConvertPostParams) (endpoints.UserConvertPostResponse, error) {
+	m.record("Post", p)
+	if m.PostFunc == nil {
+		var r endpoints.UserConvertPostResponse
+		return r, notImplemented("UserConvertAPI.Post")
+	}
+	return m.PostFunc(p)
+}
+
+// UserDisableAPI is a fake endpoints.UserDisableAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type UserDisableAPI struct {
+	recorder
+	PutFunc func(p endpoints.UserDisablePutParams) (endpoints.UserDisablePutResponse, error)
+}
+
+var _ endpoints.UserDisableAPI = (*UserDisableAPI)(nil)
+
+// Put records the call and calls PutFunc
+func (m *UserDisableAPI) Put(p endpoints.UserDisablePutParams) (endpoints.UserDisablePutResponse, error) {
+	m.record("Put", p)
+	if m.PutFunc == nil {
+		var r endpoints.UserDisablePutResponse
+		return r, notImplemented("UserDisableAPI.Put")
+	}
+	return m.PutFunc(p)
+}
+
+// UserEnableAPI is a fake endpoints.UserEnableAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type UserEnableAPI struct {
+	recorder
+	PutFunc func(p endpoints.UserEnablePutParams) (endpoints.UserEnablePutResponse, error)
+}
+
+var _ endpoints.UserEnableAPI = (*UserEnableAPI)(nil)
+
+// Put records the call and calls PutFunc
+func (m *UserEnableAPI) Put(p endpoints.UserEnablePutParams) (endpoints.UserEnablePutResponse, error) {
+	m.record("Put", p)
+	if m.PutFunc == nil {
+		var r endpoints.UserEnablePutResponse
+		return r, notImplemented("UserEnableAPI.Put")
+	}
+	return m.PutFunc(p)
+}
+
+// UserAPI is a fake endpoints.UserAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type UserAPI struct {
+	recorder
+	DeleteFunc func(p endpoints.UserDeleteParams) ([]endpoints.UserDeleteResponse, error)
+	GetFunc    func(p endpoints.UserGetParams) (endpoints.UserGetResponse, error)
+	PostFunc   func(p endpoints.UserPostParams) (endpoints.UserPostResponse, error)
+	PutFunc    func(p endpoints.UserPutParams) (endpoints.UserPutResponse, error)
+}
+
+var _ endpoints.UserAPI = (*UserAPI)(nil)
+
+// Delete records the call and calls DeleteFunc
+func (m *UserAPI) Delete(p endpoints.UserDeleteParams) ([]endpoints.UserDeleteResponse, error) {
+	m.record("Delete", p)
+	if m.DeleteFunc == nil {
+		var r []endpoints.UserDeleteResponse
+		return r, notImplemented("UserAPI.Delete")
+	}
+	return m.DeleteFunc(p)
+}
+
+// Get records the call and calls GetFunc
+func (m *UserAPI) Get(p endpoints.UserGetParams) (endpoints.UserGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r endpoints.UserGetResponse
+		return r, notImplemented("UserAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// Post records the call and calls PostFunc
+func (m *UserAPI) Post(p endpoints.UserPostParams) (endpoints.UserPostResponse, error) {
+	m.record("Post", p)
+	if m.PostFunc == nil {
+		var r endpoints.UserPostResponse
+		return r, notImplemented("UserAPI.Post")
+	}
+	return m.PostFunc(p)
+}
+
+// Put records the call and calls PutFunc
+func (m *UserAPI) Put(p endpoints.UserPutParams) (endpoints.UserPutResponse, error) {
+	m.record("Put", p)
+	if m.PutFunc == nil {
+		var r endpoints.UserPutResponse
+		return r, notImplemented("UserAPI.Put")
+	}
+	return m.PutFunc(p)
+}
+
+// UserPasswordAPI is a fake endpoints.UserPasswordAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type UserPasswordAPI struct {
+	recorder
+	PutFunc func(p endpoints.UserPasswordPutParams) (endpoints.UserPasswordPutResponse, error)
+}
+
+var _ endpoints.UserPasswordAPI = (*UserPasswordAPI)(nil)
+
+// Put records the call and calls PutFunc
+func (m *UserPasswordAPI) Put(p endpoints.UserPasswordPutParams) (endpoints.UserPasswordPutResponse, error) {
+	m.record("Put", p)
+	if m.PutFunc == nil {
+		var r endpoints.UserPasswordPutResponse
+		return r, notImplemented("UserPasswordAPI.Put")
+	}
+	return m.PutFunc(p)
+}
+
+// UserPasswordResetAPI is a fake endpoints.UserPasswordResetAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type UserPasswordResetAPI struct {
+	recorder
+	PostFunc func(p endpoints.UserPasswordResetPostParams) (endpoints.UserPasswordResetPostResponse, error)
+}
+
+var _ endpoints.UserPasswordResetAPI = (*UserPasswordResetAPI)(nil)
+
+// Post records the call and calls PostFunc
+func (m *UserPasswordResetAPI) Post(p endpoints.UserPasswordResetPostParams) (endpoints.UserPasswordResetPostResponse, error) {
+	m.record("Post", p)
+	if m.PostFunc == nil {
+		var r endpoints.UserPasswordResetPostResponse
+		return r, notImplemented("UserPasswordResetAPI.Post")
+	}
+	return m.PostFunc(p)
+}
+
+// UserTokenAPI is a fake endpoints.UserTokenAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type UserTokenAPI struct {
+	recorder
+	PostFunc func(p endpoints.UserTokenPostParams) (endpoints.UserTokenPostResponse, error)
+}
+
+var _ endpoints.UserTokenAPI = (*UserTokenAPI)(nil)
+
+// Post records the call and calls PostFunc
+func (m *UserTokenAPI) Post(p endpoints.UserTokenPostParams) (endpoints.UserTokenPostResponse, error) {
+	m.record("Post", p)
+	if m.PostFunc == nil {
+		var r endpoints.UserTokenPostResponse
+		return r, notImplemented("UserTokenAPI.Post")
+	}
+	return m.PostFunc(p)
+}
+
+// UsersByAPI is a fake endpoints.UsersByAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type UsersByAPI struct {
+	recorder
+	GetFunc func(p endpoints.UsersByGetParams) (endpoints.UsersByGetResponse, error)
+}
+
+var _ endpoints.UsersByAPI = (*UsersByAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *UsersByAPI) Get(p endpoints.UsersByGetParams) (endpoints.UsersByGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r endpoints.UsersByGetResponse
+		return r, notImplemented("UsersByAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// UsersGroupsAPI is a fake endpoints.UsersGroupsAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type UsersGroupsAPI struct {
+	recorder
+	GetFunc func(p endpoints.UsersGroupsGetParams) ([]endpoints.UsersGroupsGetResponse, error)
+}
+
+var _ endpoints.UsersGroupsAPI = (*UsersGroupsAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *UsersGroupsAPI) Get(p endpoints.UsersGroupsGetParams) ([]endpoints.UsersGroupsGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r []endpoints.UsersGroupsGetResponse
+		return r, notImplemented("UsersGroupsAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// UsersAPI is a fake endpoints.UsersAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type UsersAPI struct {
+	recorder
+	GetFunc func(p endpoints.UsersGetParams) ([]endpoints.UsersGetResponse, error)
+}
+
+var _ endpoints.UsersAPI = (*UsersAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *UsersAPI) Get(p endpoints.UsersGetParams) ([]endpoints.UsersGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r []endpoints.UsersGetResponse
+		return r, notImplemented("UsersAPI.Get")
+	}
+	return m.GetFunc(p)
+}
+
+// WorkflowDeployAPI is a fake endpoints.WorkflowDeployAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type WorkflowDeployAPI struct {
+	recorder
+	PutFunc func(p endpoints.WorkflowDeployPutParams) (endpoints.WorkflowDeployPutResponse, error)
+}
+
+var _ endpoints.WorkflowDeployAPI = (*WorkflowDeployAPI)(nil)
+
+// Put records the call and calls PutFunc
+func (m *WorkflowDeployAPI) Put(p endpoints.WorkflowDeployPutParams) (endpoints.WorkflowDeployPutResponse, error) {
+	m.record("Put", p)
+	if m.PutFunc == nil {
+		var r endpoints.WorkflowDeployPutResponse
+		return r, notImplemented("WorkflowDeployAPI.Put")
+	}
+	return m.PutFunc(p)
+}
+
+// WorkflowInitialFieldsByCategoryAPI is a fake endpoints.WorkflowInitialFieldsByCategoryAPI. Methods call their Func field
+// or return ErrNotImplemented if it is nil.
+type WorkflowInitialFieldsByCategoryAPI struct {
+	recorder
+	GetFunc func(p endpoints.WorkflowInitialFieldsByCategoryGetParams) (endpoints.WorkflowInitialFieldsByCategoryGetResponse, error)
+}
+
+var _ endpoints.WorkflowInitialFieldsByCategoryAPI = (*WorkflowInitialFieldsByCategoryAPI)(nil)
+
+// Get records the call and calls GetFunc
+func (m *WorkflowInitialFieldsByCategoryAPI) Get(p endpoints.WorkflowInitialFieldsByCategoryGetParams) (endpoints.WorkflowInitialFieldsByCategoryGetResponse, error) {
+	m.record("Get", p)
+	if m.GetFunc == nil {
+		var r endpoints.WorkflowInitialFieldsByCategoryGetResponse
+		return r, notImplemented("WorkflowInitialFieldsByCategoryAPI.Get")
+	}
+	return m.GetFunc(p)
+}
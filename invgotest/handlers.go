@@ -0,0 +1,936 @@
+package invgotest
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tmstorm/invgo/endpoints"
+)
+
+// ok is the response returned by endpoints that only report a status
+var ok = map[string]string{"status": "OK"}
+
+// routes maps each supported "METHOD /path" to its handler
+var routes = map[string]handler{
+	"GET /sd.version": func(*Server, url.Values) (any, error) {
+		return endpoints.ServiceDeskVersionResponse{Version: "invgotest"}, nil
+	},
+
+	"GET /incident":                 (*Server).getIncident,
+	"POST /incident":                (*Server).postIncident,
+	"PUT /incident":                 (*Server).putIncident,
+	"GET /incidents":                (*Server).getIncidents,
+	"GET /incidents.by.status":      (*Server).getIncidentsByStatus,
+	"GET /incidents.by.customer":    (*Server).getIncidentsByCustomer,
+	"GET /incidents.by.agent":       (*Server).getIncidentsByAgent,
+	"GET /incidents.by.helpdesk":    (*Server).getIncidentsByHelpDesk,
+	"GET /incidents.last.hour":      (*Server).getIncidentsLastHour,
+	"GET /incident.comment":         (*Server).getIncidentComment,
+	"POST /incident.comment":        (*Server).postIncidentComment,
+	"POST /incident.reassign":       (*Server).postIncidentReassign,
+	"POST /incident.cancel":         (*Server).postIncidentCancel,
+	"POST /incident.reject":         (*Server).postIncidentReject,
+	"PUT /incident.reopen":          (*Server).putIncidentReopen,
+	"PUT /incident.solution.accept": (*Server).putIncidentSolutionAccept,
+	"PUT /incident.solution.reject": (*Server).putIncidentSolutionReject,
+
+	"POST /incident.waitingfor.agent":           waitingFor[endpoints.IncidentWaitingForAgentPostParams](),
+	"POST /incident.waitingfor.customer":        waitingFor[endpoints.IncidentWaitingForCustomerPostParams](),
+	"POST /incident.waitingfor.date":            waitingFor[endpoints.IncidentWaitingForDatePostParams](),
+	"POST /incident.waitingfor.external_entity": waitingFor[endpoints.IncidentWaitingForExternalEntityPostParams](),
+	"POST /incident.waitingfor.incident":        waitingFor[endpoints.IncidentWaitingForIncidentPostParams](),
+
+	"GET /user":                 (*Server).getUser,
+	"POST /user":                (*Server).postUser,
+	"PUT /user":                 (*Server).putUser,
+	"GET /user.by":              (*Server).getUserBy,
+	"PUT /user.disable":         (*Server).putUserDisable,
+	"PUT /user.enable":          (*Server).putUserEnable,
+	"POST /user.password.reset": (*Server).postUserPasswordReset,
+	"GET /users":                (*Server).getUsers,
+	"GET /users.groups":         (*Server).getUsersGroups,
+
+	"GET /helpdesks":  (*Server).getHelpDesks,
+	"GET /categories": (*Server).getCategories,
+
+	"GET /breakingnews":         (*Server).getBreakingNews,
+	"POST /breakingnews":        (*Server).postBreakingNews,
+	"PUT /breakingnews":         (*Server).putBreakingNews,
+	"GET /breakingnews.all":     (*Server).getBreakingNewsAll,
+	"GET /breakingnews.status":  (*Server).getBreakingNewsStatus,
+	"POST /breakingnews.status": (*Server).postBreakingNewsStatus,
+
+	"GET /timetracking":                     (*Server).getTimeTracking,
+	"POST /timetracking":                    (*Server).postTimeTracking,
+	"DELETE /timetracking":                  (*Server).deleteTimeTracking,
+	"GET /timetracking.attributes.category": (*Server).getTimeTrackingCategories,
+
+	"GET /triggers.executions": (*Server).getTriggersExecutions,
+
+	"GET /incident.attributes.status":     attributes("/incident.attributes.status"),
+	"GET /incident.attributes.priority":   attributes("/incident.attributes.priority"),
+	"GET /incident.attributes.type":       attributes("/incident.attributes.type"),
+	"GET /incident.attributes.source":     attributes("/incident.attributes.source"),
+	"GET /breakingnews.attributes.status": attributes("/breakingnews.attributes.status"),
+	"GET /breakingnews.attributes.type":   attributes("/breakingnews.attributes.type"),
+}
+
+func (s *Server) incident(id int) (*endpoints.Incident, error) {
+	inc, found := s.incidents[id]
+	if !found {
+		return nil, notFound("incident", id)
+	}
+	return inc, nil
+}
+
+// withComments returns a copy of inc with its comments in the format used by /incident
+func (s *Server) withComments(inc *endpoints.Incident) endpoints.Incident {
+	i := *inc
+	for _, c := range s.comments[inc.ID] {
+		visible := 0
+		if c.CustomerVisible {
+			visible = 1
+		}
+		i.Comments = append(i.Comments, endpoints.IncidentCommentResponse{
+			AuthorID:        c.AuthorID,
+			Reference:       c.Reference,
+			IsSolution:      c.IsSolution,
+			ID:              c.ID,
+			CreatedAt:       c.CreatedAt,
+			CustomerVisible: visible,
+			Attachments:     c.Attachments,
+			MsgNum:          c.MsgNum,
+			IncidentID:      c.IncidentID,
+			Message:         c.Message,
+		})
+	}
+	return i
+}
+
+// sortedIncidents returns every incident matching keep sorted by ID
+func (s *Server) sortedIncidents(keep func(*endpoints.Incident) bool) []*endpoints.Incident {
+	var incs []*endpoints.Incident
+	for _, inc := range s.incidents {
+		if keep(inc) {
+			incs = append(incs, inc)
+		}
+	}
+	slices.SortFunc(incs, func(a, b *endpoints.Incident) int { return a.ID - b.ID })
+	return incs
+}
+
+func (s *Server) getIncident(q url.Values) (any, error) {
+	var p endpoints.IncidentGetParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+	inc, err := s.incident(p.ID)
+	if err != nil {
+		return nil, err
+	}
+	if p.Comments {
+		return s.withComments(inc), nil
+	}
+	return inc, nil
+}
+
+func (s *Server) postIncident(q url.Values) (any, error) {
+	var p endpoints.IncidentPostParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	inc := &endpoints.Incident{
+		ID:          s.id(),
+		Title:       p.Title,
+		TypeID:      p.TypeID,
+		CreatorID:   p.CreatorID,
+		PriorityID:  p.PriorityID,
+		UserID:      p.CustomerID,
+		CategoryID:  p.CategoryID,
+		SourceID:    p.SourceID,
+		Description: p.Description,
+		StatusID:    StatusNew,
+		CreatedAt:   now,
+		LastUpdate:  now,
+	}
+	inc.PrettyID = "#" + strconv.Itoa(inc.ID)
+	s.incidents[inc.ID] = inc
+
+	return endpoints.IncidentPostResponse{
+		RequestID: strconv.Itoa(inc.ID),
+		Info:      "Request created",
+		Status:    "OK",
+	}, nil
+}
+
+func (s *Server) putIncident(q url.Values) (any, error) {
+	var p endpoints.IncidentPutParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+	inc, err := s.incident(p.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	setNonZero(&inc.PriorityID, p.PriorityID)
+	setNonZero(&inc.TypeID, p.TypeID)
+	setNonZero(&inc.SourceID, p.SourceID)
+	setNonZero(&inc.Title, p.Title)
+	setNonZero(&inc.CategoryID, p.CategoryID)
+	setNonZero(&inc.Description, p.Description)
+	setNonZero(&inc.UserID, p.CustomerID)
+	inc.LastUpdate = s.now()
+	return inc, nil
+}
+
+func (s *Server) getIncidents(q url.Values) (any, error) {
+	var p endpoints.IncidentsGetParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+
+	incs := map[int]endpoints.Incident{}
+	for _, id := range p.IDs {
+		if inc, found := s.incidents[id]; found {
+			if p.IncludeComments {
+				incs[id] = s.withComments(inc)
+			} else {
+				incs[id] = *inc
+			}
+		}
+	}
+	return incs, nil
+}
+
+func (s *Server) getIncidentsByStatus(q url.Values) (any, error) {
+	var p endpoints.IncidentsByStatusGetParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	for _, inc := range s.sortedIncidents(func(i *endpoints.Incident) bool {
+		return len(p.StatusIDs) == 0 || slices.Contains(p.StatusIDs, i.StatusID)
+	}) {
+		ids = append(ids, inc.ID)
+	}
+
+	total := len(ids)
+	ids = ids[min(p.Offset, total):]
+	if p.Limit > 0 {
+		ids = ids[:min(p.Limit, len(ids))]
+	}
+
+	return endpoints.IncidentsByStatusGetResponse{
+		Status:     "OK",
+		Limit:      p.Limit,
+		Offset:     p.Offset,
+		RequestIDs: ids,
+		Total:      total,
+	}, nil
+}
+
+// incidentsByUser returns the incidents where field returns the ID of the user
+// matching the id, email or username in q
+func (s *Server) incidentsByUser(q url.Values, field func(*endpoints.Incident) int) (map[int]endpoints.Incident, error) {
+	var p endpoints.IncidentsByCustomerGetParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+
+	id := p.ID
+	if id == 0 {
+		u := s.findUser(p.Email, p.Username)
+		if u == nil {
+			return nil, &apiError{status: http.StatusNotFound, msg: "user not found"}
+		}
+		id = u.ID
+	}
+
+	incs := map[int]endpoints.Incident{}
+	for _, inc := range s.incidents {
+		if field(inc) == id {
+			if p.Comments {
+				incs[inc.ID] = s.withComments(inc)
+			} else {
+				incs[inc.ID] = *inc
+			}
+		}
+	}
+	return incs, nil
+}
+
+func (s *Server) getIncidentsByCustomer(q url.Values) (any, error) {
+	incs, err := s.incidentsByUser(q, func(i *endpoints.Incident) int { return i.UserID })
+	if err != nil {
+		return nil, err
+	}
+	return endpoints.IncidentsByCustomerGetResponse{Status: "OK", Requests: incs}, nil
+}
+
+func (s *Server) getIncidentsByAgent(q url.Values) (any, error) {
+	incs, err := s.incidentsByUser(q, func(i *endpoints.Incident) int { return i.AssignedID })
+	if err != nil {
+		return nil, err
+	}
+	return endpoints.IncidentsByAgentGetResponse{Status: "OK", Requests: incs}, nil
+}
+
+func (s *Server) getIncidentsByHelpDesk(q url.Values) (any, error) {
+	var p endpoints.IncidentsByHelpDeskGetParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	for _, inc := range s.sortedIncidents(func(i *endpoints.Incident) bool {
+		return slices.Contains(p.HelpdeskIDs, i.AssignedGroupID)
+	}) {
+		ids = append(ids, inc.ID)
+	}
+	return endpoints.IncidentsByHelpDeskGetResponse{Status: "OK", RequestIDs: ids}, nil
+}
+
+func (s *Server) getIncidentsLastHour(q url.Values) (any, error) {
+	var p endpoints.IncidentsLastHourGetParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+
+	since := int(s.Now().Add(-time.Hour).Unix())
+	incs := []endpoints.IncidentsLastHourGetResponse{}
+	for _, inc := range s.sortedIncidents(func(i *endpoints.Incident) bool { return i.LastUpdate >= since }) {
+		incs = append(incs, endpoints.IncidentsLastHourGetResponse{Incident: *inc})
+	}
+	if p.Limit > 0 {
+		incs = incs[:min(p.Limit, len(incs))]
+	}
+	return incs, nil
+}
+
+func (s *Server) getIncidentComment(q url.Values) (any, error) {
+	var p endpoints.IncidentCommentGetParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+	if _, err := s.incident(p.RequestID); err != nil {
+		return nil, err
+	}
+
+	comments := []endpoints.IncidentCommentGetResponse{}
+	for _, c := range s.comments[p.RequestID] {
+		if !p.IsSolution || c.IsSolution {
+			comments = append(comments, c)
+		}
+	}
+	return comments, nil
+}
+
+func (s *Server) postIncidentComment(q url.Values) (any, error) {
+	var p endpoints.IncidentCommentPostParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+	inc, err := s.incident(p.RequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.addComment(endpoints.IncidentCommentGetResponse{
+		AuthorID:        p.AuthorID,
+		IsSolution:      p.IsSolution,
+		CustomerVisible: p.CustomerVisible,
+		Attachments:     p.Attachments,
+		IncidentID:      p.RequestID,
+		Message:         p.Comment,
+	})
+	if p.IsSolution {
+		inc.StatusID = StatusSolved
+		inc.SolvedAt = s.now()
+	}
+	return endpoints.IncidentCommentPostResponse{Status: "OK"}, nil
+}
+
+func (s *Server) postIncidentReassign(q url.Values) (any, error) {
+	var p endpoints.IncidentReassignPostParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+	inc, err := s.incident(p.RequestID)
+	if err != nil {
+		return nil, err
+	}
+	if _, found := s.helpDesks[p.GroupID]; !found {
+		return nil, notFound("help desk", p.GroupID)
+	}
+
+	inc.AssignedGroupID = p.GroupID
+	inc.AssignedID = p.AgentID
+	if inc.StatusID == StatusNew {
+		inc.StatusID = StatusOpen
+	}
+	inc.LastUpdate = s.now()
+	return ok, nil
+}
+
+// transition moves the incident in q to status
+func (s *Server) transition(q url.Values, key string, status int, closed bool) (any, error) {
+	id, err := strconv.Atoi(q.Get(key))
+	if err != nil {
+		return nil, &apiError{status: http.StatusBadRequest, msg: key + " is required"}
+	}
+	inc, err := s.incident(id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	inc.StatusID = status
+	inc.LastUpdate = now
+	if closed {
+		inc.ClosedAt = now
+	} else {
+		inc.ClosedAt = 0
+		inc.SolvedAt = 0
+	}
+	return ok, nil
+}
+
+func (s *Server) postIncidentCancel(q url.Values) (any, error) {
+	var p endpoints.IncidentCancelPostParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+	return s.transition(q, "request_id", StatusCancelled, true)
+}
+
+func (s *Server) postIncidentReject(q url.Values) (any, error) {
+	var p endpoints.IncidentRejectPostParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+	return s.transition(q, "request_id", StatusRejected, true)
+}
+
+func (s *Server) putIncidentReopen(q url.Values) (any, error) {
+	var p endpoints.IncidentReopenPutParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+	return s.transition(q, "request_id", StatusOpen, false)
+}
+
+// waitingFor returns a handler that sets the incident to waiting once P is decoded
+func waitingFor[P any]() handler {
+	return func(s *Server, q url.Values) (any, error) {
+		var p P
+		if err := decodeQuery(q, &p); err != nil {
+			return nil, err
+		}
+		return s.transition(q, "request_id", StatusWaiting, false)
+	}
+}
+
+func (s *Server) putIncidentSolutionAccept(q url.Values) (any, error) {
+	var p endpoints.IncidentSolutionAcceptPutParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+	inc, err := s.incident(p.ID)
+	if err != nil {
+		return nil, err
+	}
+	if inc.StatusID != StatusSolved {
+		return map[string]string{"status": "ERROR"}, nil
+	}
+	inc.Rating = p.Rating
+	return s.transition(q, "id", StatusClosed, true)
+}
+
+func (s *Server) putIncidentSolutionReject(q url.Values) (any, error) {
+	var p endpoints.IncidentSolutionRejectPutParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+	inc, err := s.incident(p.ID)
+	if err != nil {
+		return nil, err
+	}
+	if inc.StatusID != StatusSolved {
+		return map[string]string{"status": "ERROR"}, nil
+	}
+	return s.transition(q, "id", StatusOpen, false)
+}
+
+func (s *Server) user(id int, includeDisabled bool) (*endpoints.UserGetResponse, error) {
+	u, found := s.users[id]
+	if !found || u.IsDeleted || (u.IsDisabled && !includeDisabled) {
+		return nil, notFound("user", id)
+	}
+	return u, nil
+}
+
+// findUser returns the user with the email or username
+func (s *Server) findUser(email, username string) *endpoints.UserGetResponse {
+	for _, u := range s.users {
+		if (email != "" && strings.EqualFold(u.Email, email)) || (username != "" && u.UserName == username) {
+			return u
+		}
+	}
+	return nil
+}
+
+func (s *Server) getUser(q url.Values) (any, error) {
+	var p endpoints.UserGetParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+	return s.user(p.ID, p.IncludeDisabled)
+}
+
+func (s *Server) postUser(q url.Values) (any, error) {
+	var p endpoints.UserPostParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+	if s.findUser(p.Email, p.UserName) != nil {
+		return nil, &apiError{status: http.StatusConflict, msg: "user already exists"}
+	}
+
+	u := &endpoints.UserGetResponse{
+		ID:       s.id(),
+		Email:    p.Email,
+		Name:     p.Name,
+		LastName: p.LastName,
+		UserBase: p.UserBase,
+	}
+	s.users[u.ID] = u
+	return endpoints.UserPostResponse{UserGetResponse: *u}, nil
+}
+
+func (s *Server) putUser(q url.Values) (any, error) {
+	var p endpoints.UserPutParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+	u, err := s.user(p.ID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	setNonZero(&u.Email, p.Email)
+	setNonZero(&u.Name, p.Name)
+	setNonZero(&u.LastName, p.LastName)
+	mergeNonZero(&u.UserBase, p.UserBase)
+	return endpoints.UserPutResponse{Status: "OK"}, nil
+}
+
+func (s *Server) getUserBy(q url.Values) (any, error) {
+	var p endpoints.UserByGetParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+	u := s.findUser(p.Email, p.Username)
+	if u == nil || u.IsDeleted {
+		return nil, &apiError{status: http.StatusNotFound, msg: "user not found"}
+	}
+	return u, nil
+}
+
+func (s *Server) setUserDisabled(q url.Values, disabled bool) (any, error) {
+	id, err := strconv.Atoi(q.Get("id"))
+	if err != nil {
+		return nil, &apiError{status: http.StatusBadRequest, msg: "id is required"}
+	}
+	u, err := s.user(id, true)
+	if err != nil {
+		return nil, err
+	}
+	u.IsDisabled = disabled
+	return ok, nil
+}
+
+func (s *Server) putUserDisable(q url.Values) (any, error) { return s.setUserDisabled(q, true) }
+
+func (s *Server) putUserEnable(q url.Values) (any, error) { return s.setUserDisabled(q, false) }
+
+func (s *Server) postUserPasswordReset(q url.Values) (any, error) {
+	var p endpoints.UserPasswordResetPostParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+	if p.Type != "NEW_USER" && p.Type != "RESET_PASSWORD" {
+		return nil, &apiError{status: http.StatusBadRequest, msg: "invalid type " + p.Type}
+	}
+	if _, err := s.user(p.ID, true); err != nil {
+		return nil, err
+	}
+	return ok, nil
+}
+
+func (s *Server) getUsers(q url.Values) (any, error) {
+	var p endpoints.UsersGetParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+
+	ids := p.IDs
+	if len(ids) == 0 {
+		for id := range s.users {
+			ids = append(ids, id)
+		}
+		slices.Sort(ids)
+	}
+
+	users := []endpoints.UsersGetResponse{}
+	for _, id := range ids {
+		if u, err := s.user(id, p.IncludeDisabled); err == nil {
+			users = append(users, endpoints.UsersGetResponse{UserGetResponse: *u})
+		}
+	}
+	return users, nil
+}
+
+func (s *Server) getUsersGroups(q url.Values) (any, error) {
+	var p endpoints.UsersGroupsGetParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+
+	resp := []endpoints.UsersGroupsGetResponse{}
+	for _, id := range p.IDs {
+		u, found := s.users[id]
+		if !found {
+			continue
+		}
+
+		groups := endpoints.CollectionMap{}
+		for deskID, members := range s.members {
+			if slices.Contains(members, id) {
+				d := s.helpDesks[deskID]
+				groups[deskID] = endpoints.Collection{ID: d.ID, Name: d.Name, ParentID: d.ParentID, StatusID: d.StatusID}
+			}
+		}
+		resp = append(resp, endpoints.UsersGroupsGetResponse{
+			ID:        u.ID,
+			Username:  u.UserName,
+			Email:     u.Email,
+			Groups:    groups,
+			Helpdesks: groups,
+		})
+	}
+	return resp, nil
+}
+
+func (s *Server) getHelpDesks(q url.Values) (any, error) {
+	var p endpoints.HelpDeskGetParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+
+	if p.ID > 0 {
+		d, found := s.helpDesks[p.ID]
+		if !found {
+			return nil, notFound("help desk", p.ID)
+		}
+		return d, nil
+	}
+
+	var desks []*endpoints.HelpDesksGetResponse
+	for _, d := range s.helpDesks {
+		desks = append(desks, d)
+	}
+	slices.SortFunc(desks, func(a, b *endpoints.HelpDesksGetResponse) int { return a.ID - b.ID })
+
+	if p.Name != "" {
+		for _, d := range desks {
+			if strings.EqualFold(d.Name, p.Name) {
+				return d, nil
+			}
+		}
+		return nil, &apiError{status: http.StatusNotFound, msg: "help desk not found"}
+	}
+	return desks, nil
+}
+
+func (s *Server) getCategories(q url.Values) (any, error) {
+	var p endpoints.CategoriesGetParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+
+	cats := []endpoints.CategoriesGetResponse{}
+	for _, c := range s.categories {
+		if p.ID == 0 || c.ID == strconv.Itoa(p.ID) {
+			cats = append(cats, c)
+		}
+	}
+	return cats, nil
+}
+
+func (s *Server) breakingNews(id int) (*endpoints.BreakingNewsGetResponse, error) {
+	n, found := s.news[id]
+	if !found {
+		return nil, notFound("breaking news", id)
+	}
+	return n, nil
+}
+
+func (s *Server) getBreakingNews(q url.Values) (any, error) {
+	var p endpoints.BreakingNewsGetParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+	return s.breakingNews(p.ID)
+}
+
+func (s *Server) postBreakingNews(q url.Values) (any, error) {
+	var p endpoints.BreakingNewsPostParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+
+	n := &endpoints.BreakingNewsGetResponse{
+		ID:               s.id(),
+		TypeID:           p.TypeID,
+		Title:            p.Title,
+		Body:             p.Body,
+		BreakingNewsBase: p.BreakingNewsBase,
+	}
+	n.CreatedByID = p.CreatorID
+	n.CreatedAt = s.now()
+	if n.StatusID == 0 {
+		n.StatusID = 1
+	}
+	s.news[n.ID] = n
+	return endpoints.BreakingNewsInfoResponse{ID: strconv.Itoa(n.ID), Info: "Breaking news created", Status: "OK"}, nil
+}
+
+func (s *Server) putBreakingNews(q url.Values) (any, error) {
+	var p endpoints.BreakingNewsPutParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+	n, err := s.breakingNews(p.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	setNonZero(&n.TypeID, p.TypeID)
+	setNonZero(&n.Title, p.Title)
+	setNonZero(&n.Body, p.Body)
+	mergeNonZero(&n.BreakingNewsBase, p.BreakingNewsBase)
+	return endpoints.BreakingNewsInfoResponse{ID: strconv.Itoa(n.ID), Info: "Breaking news updated", Status: "OK"}, nil
+}
+
+func (s *Server) getBreakingNewsAll(url.Values) (any, error) {
+	news := []endpoints.BreakingNewsGetResponse{}
+	for _, n := range s.news {
+		news = append(news, *n)
+	}
+	slices.SortFunc(news, func(a, b endpoints.BreakingNewsGetResponse) int { return a.ID - b.ID })
+	return news, nil
+}
+
+func (s *Server) getBreakingNewsStatus(q url.Values) (any, error) {
+	var p endpoints.BreakingNewsStatusGetParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+	if _, err := s.breakingNews(p.ID); err != nil {
+		return nil, err
+	}
+
+	updates := []endpoints.BreakingNewsStatusGetResponse{}
+	return append(updates, s.newsStatus[p.ID]...), nil
+}
+
+func (s *Server) postBreakingNewsStatus(q url.Values) (any, error) {
+	var p endpoints.BreakingNewsStatusPostParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+	n, err := s.breakingNews(p.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.newsStatus[p.ID] = append(s.newsStatus[p.ID], endpoints.BreakingNewsStatusGetResponse{
+		CreatedAt: s.now(),
+		Body:      p.Body,
+		CreatorID: p.CreatorID,
+	})
+	if p.IsSolutions {
+		n.StatusID = 2
+		n.ResolutionTime = s.now()
+	}
+	return endpoints.BreakingNewsInfoResponse{ID: strconv.Itoa(n.ID), Info: "Status added", Status: "OK"}, nil
+}
+
+func (s *Server) getTimeTracking(q url.Values) (any, error) {
+	var p endpoints.TimeTrackingGetParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+
+	from, err := parseTimeTrackingDate(p.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseTimeTrackingDate(p.To)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*timeRecord
+	for _, r := range s.timeTracking {
+		if p.RequestID != 0 && r.Incident != p.RequestID {
+			continue
+		}
+		if (from != 0 && r.to < from) || (to != 0 && r.from > to) {
+			continue
+		}
+		records = append(records, r)
+	}
+	slices.SortFunc(records, func(a, b *timeRecord) int { return a.TimetrackingID - b.TimetrackingID })
+
+	resp := []endpoints.TimeTrackingGetResponse{}
+	for _, r := range records {
+		tt := r.TimeTrackingGetResponse
+		tt.From = formatTimeTrackingDate(r.from, p.DateFormat)
+		tt.To = formatTimeTrackingDate(r.to, p.DateFormat)
+		resp = append(resp, tt)
+	}
+	return resp, nil
+}
+
+// timeTrackingLayouts are the ISO 8601 formats accepted by /timetracking from and to
+var timeTrackingLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02 15:04", time.DateOnly}
+
+// parseTimeTrackingDate parses an epoch or ISO 8601 date in the server location.
+// An empty string returns 0.
+func parseTimeTrackingDate(v string) (int64, error) {
+	if v == "" {
+		return 0, nil
+	}
+	if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return n, nil
+	}
+	for _, layout := range timeTrackingLayouts {
+		if t, err := time.ParseInLocation(layout, v, time.UTC); err == nil {
+			return t.Unix(), nil
+		}
+	}
+	return 0, &apiError{status: http.StatusBadRequest, msg: "invalid date " + v}
+}
+
+// formatTimeTrackingDate formats an epoch in UTC as iso8601 or the default iso8601noT
+func formatTimeTrackingDate(epoch int64, format string) string {
+	t := time.Unix(epoch, 0).UTC()
+	if format == "iso8601" {
+		return t.Format(time.RFC3339)
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+func (s *Server) postTimeTracking(q url.Values) (any, error) {
+	var p endpoints.TimeTrackingPostParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+	if _, err := s.incident(p.RequestID); err != nil {
+		return nil, err
+	}
+
+	id := s.addTimeTracking(p)
+	return endpoints.TimeTrackingPostResponse{Status: "OK", TimetrackingID: id}, nil
+}
+
+func (s *Server) deleteTimeTracking(q url.Values) (any, error) {
+	var p endpoints.TimeTrackingDeleteParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+
+	r, found := s.timeTracking[p.TimetrackingID]
+	if !found || r.Incident != p.RequestID || r.UserID != p.UserID {
+		return endpoints.TimeTrackingDeleteResponse{Status: "ERROR"}, nil
+	}
+	delete(s.timeTracking, p.TimetrackingID)
+	return endpoints.TimeTrackingDeleteResponse{Status: "OK"}, nil
+}
+
+func (s *Server) getTimeTrackingCategories(q url.Values) (any, error) {
+	var p endpoints.TimeTrackingAttributesCategoryGetParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+
+	cats := []endpoints.TimeTrackingAttributesCategoryGetResponse{}
+	for _, c := range s.ttCategories {
+		if p.ID == 0 || c.ID == p.ID {
+			cats = append(cats, c)
+		}
+	}
+	return cats, nil
+}
+
+// attributes returns a handler for the attribute endpoint at path
+func attributes(path string) handler {
+	return func(s *Server, q url.Values) (any, error) {
+		var p endpoints.AttributesGetParams
+		if err := decodeQuery(q, &p); err != nil {
+			return nil, err
+		}
+
+		attrs := []endpoints.AttributesResponse{}
+		for _, a := range s.attributes[path] {
+			if p.ID == 0 || a.ID == p.ID {
+				attrs = append(attrs, a)
+			}
+		}
+		return attrs, nil
+	}
+}
+
+// setNonZero sets dst to v if v is not the zero value
+func setNonZero[T comparable](dst *T, v T) {
+	var zero T
+	if v != zero {
+		*dst = v
+	}
+}
+
+// mergeNonZero sets every field of the struct pointed to by dst to the field in src if it is not the zero value
+func mergeNonZero[T any](dst *T, src T) {
+	dv := reflect.ValueOf(dst).Elem()
+	sv := reflect.ValueOf(src)
+	for i := range sv.NumField() {
+		if f := sv.Field(i); !f.IsZero() && dv.Field(i).CanSet() {
+			dv.Field(i).Set(f)
+		}
+	}
+}
+
+func (s *Server) getTriggersExecutions(q url.Values) (any, error) {
+	var p endpoints.TriggersGetParams
+	if err := decodeQuery(q, &p); err != nil {
+		return nil, err
+	}
+
+	execs := []endpoints.TriggersExecutionsGetResponse{}
+	for _, e := range s.executions {
+		if p.TriggerID == 0 || e.TriggerID == p.TriggerID {
+			execs = append(execs, e)
+		}
+	}
+	return execs, nil
+}
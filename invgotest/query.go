@@ -0,0 +1,112 @@
+package invgotest
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// decodeQuery sets the fields of the struct pointed to by dst from q using the `url` tag.
+// It is the reverse of the encoding used by invgo so the endpoints param types can be used directly.
+// An error is returned if a field tagged as required is missing.
+func decodeQuery(q url.Values, dst any) error {
+	rv := reflect.ValueOf(dst).Elem()
+	rt := rv.Type()
+
+	for i := range rv.NumField() {
+		field := rv.Field(i)
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		tag := sf.Tag.Get("url")
+		if sf.Anonymous && field.Kind() == reflect.Struct && tag == "" {
+			if err := decodeQuery(q, field.Addr().Interface()); err != nil {
+				return err
+			}
+			continue
+		}
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		key := strings.TrimSpace(parts[0])
+		required := len(parts) > 1 && strings.TrimSpace(parts[1]) == "required"
+
+		values := queryValues(q, key)
+		if len(values) == 0 {
+			if required {
+				return fmt.Errorf("%s is required", key)
+			}
+			continue
+		}
+
+		if field.Kind() == reflect.Slice {
+			slice := reflect.MakeSlice(field.Type(), len(values), len(values))
+			for j, v := range values {
+				if err := setValue(slice.Index(j), v); err != nil {
+					return fmt.Errorf("%s: %w", key, err)
+				}
+			}
+			field.Set(slice)
+			continue
+		}
+
+		if err := setValue(field, values[0]); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// queryValues returns the values for key including values sent as key[], key[0], key[1]...
+func queryValues(q url.Values, key string) []string {
+	values := append([]string{}, q[key]...)
+	values = append(values, q[key+"[]"]...)
+	for i := 0; ; i++ {
+		v, ok := q[fmt.Sprintf("%s[%d]", key, i)]
+		if !ok {
+			break
+		}
+		values = append(values, v...)
+	}
+	return values
+}
+
+func setValue(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetUint(i)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	default:
+		// types that can not be sent as a single query value are ignored
+	}
+	return nil
+}
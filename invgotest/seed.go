@@ -0,0 +1,252 @@
+package invgotest
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/tmstorm/invgo/endpoints"
+)
+
+// defaultAttributes returns the attributes every Server is seeded with
+func defaultAttributes() map[string][]endpoints.AttributesResponse {
+	return map[string][]endpoints.AttributesResponse{
+		"/incident.attributes.status": {
+			{ID: StatusNew, Name: "New"},
+			{ID: StatusOpen, Name: "Open"},
+			{ID: StatusInProgress, Name: "In progress"},
+			{ID: StatusWaiting, Name: "Waiting"},
+			{ID: StatusSolved, Name: "Solved"},
+			{ID: StatusClosed, Name: "Closed"},
+			{ID: StatusRejected, Name: "Rejected"},
+			{ID: StatusCancelled, Name: "Cancelled"},
+		},
+		"/incident.attributes.priority": {
+			{ID: 1, Name: "Low"},
+			{ID: 2, Name: "Medium"},
+			{ID: 3, Name: "High"},
+			{ID: 4, Name: "Urgent"},
+			{ID: 5, Name: "Critical"},
+		},
+		"/incident.attributes.type": {
+			{ID: 1, Name: "Incident"},
+			{ID: 2, Name: "Request"},
+			{ID: 3, Name: "Problem"},
+		},
+		"/incident.attributes.source": {
+			{ID: 1, Name: "Email"},
+			{ID: 2, Name: "Portal"},
+			{ID: 3, Name: "Phone"},
+			{ID: 4, Name: "API"},
+		},
+		"/breakingnews.attributes.status": {
+			{ID: 1, Name: "Active"},
+			{ID: 2, Name: "Resolved"},
+		},
+		"/breakingnews.attributes.type": {
+			{ID: 1, Name: "Information"},
+			{ID: 2, Name: "Outage"},
+		},
+	}
+}
+
+// SetAttributes replaces the attributes returned by an attribute endpoint e.g. /incident.attributes.status
+func (s *Server) SetAttributes(path string, attrs []endpoints.AttributesResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attributes[path] = slices.Clone(attrs)
+}
+
+// AddIncident stores inc and returns its ID. If inc.ID is 0 an ID is assigned.
+// StatusID defaults to StatusNew and CreatedAt and LastUpdate default to Now.
+func (s *Server) AddIncident(inc endpoints.Incident) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if inc.ID == 0 {
+		inc.ID = s.id()
+	}
+	if inc.StatusID == 0 {
+		inc.StatusID = StatusNew
+	}
+	if inc.CreatedAt == 0 {
+		inc.CreatedAt = s.now()
+	}
+	if inc.LastUpdate == 0 {
+		inc.LastUpdate = inc.CreatedAt
+	}
+	if inc.PrettyID == "" {
+		inc.PrettyID = "#" + strconv.Itoa(inc.ID)
+	}
+	s.incidents[inc.ID] = &inc
+	return inc.ID
+}
+
+// Incident returns the current state of an incident
+func (s *Server) Incident(id int) (endpoints.Incident, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	inc, ok := s.incidents[id]
+	if !ok {
+		return endpoints.Incident{}, false
+	}
+	return *inc, true
+}
+
+// AddComment adds a comment to an incident and returns the comment ID
+func (s *Server) AddComment(c endpoints.IncidentCommentGetResponse) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addComment(c)
+}
+
+// Comments returns the comments of an incident
+func (s *Server) Comments(incidentID int) []endpoints.IncidentCommentGetResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.comments[incidentID])
+}
+
+// AddUser stores u and returns its ID. If u.ID is 0 an ID is assigned.
+func (s *Server) AddUser(u endpoints.UserGetResponse) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if u.ID == 0 {
+		u.ID = s.id()
+	}
+	s.users[u.ID] = &u
+	return u.ID
+}
+
+// User returns the current state of a user
+func (s *Server) User(id int) (endpoints.UserGetResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.users[id]
+	if !ok {
+		return endpoints.UserGetResponse{}, false
+	}
+	return *u, true
+}
+
+// AddHelpDesk stores a help desk (group) and returns its ID. If d.ID is 0 an ID is assigned.
+// Members are added to the help desk and returned by /users.groups.
+func (s *Server) AddHelpDesk(d endpoints.HelpDesksGetResponse, members ...int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d.ID == 0 {
+		d.ID = s.id()
+	}
+	s.helpDesks[d.ID] = &d
+	for _, m := range members {
+		if !slices.Contains(s.members[d.ID], m) {
+			s.members[d.ID] = append(s.members[d.ID], m)
+		}
+	}
+	d.TotalMembers = len(s.members[d.ID])
+	return d.ID
+}
+
+// AddCategory stores a category and returns its ID. If c.ID is empty an ID is assigned.
+func (s *Server) AddCategory(c endpoints.CategoriesGetResponse) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c.ID == "" {
+		c.ID = strconv.Itoa(s.id())
+	}
+	s.categories = append(s.categories, c)
+	id, _ := strconv.Atoi(c.ID)
+	return id
+}
+
+// AddBreakingNews stores a breaking news item and returns its ID. If n.ID is 0 an ID is assigned.
+func (s *Server) AddBreakingNews(n endpoints.BreakingNewsGetResponse) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if n.ID == 0 {
+		n.ID = s.id()
+	}
+	if n.CreatedAt == 0 {
+		n.CreatedAt = s.now()
+	}
+	s.news[n.ID] = &n
+	return n.ID
+}
+
+// AddTimeTrackingCategory stores a time tracking category and returns its ID. If c.ID is 0 an ID is assigned.
+func (s *Server) AddTimeTrackingCategory(c endpoints.TimeTrackingAttributesCategoryGetResponse) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c.ID == 0 {
+		c.ID = s.id()
+	}
+	s.ttCategories = append(s.ttCategories, c)
+	return c.ID
+}
+
+// AddTriggerExecution stores an execution of a trigger and returns its ID. If e.ID is 0 an ID is
+// assigned and ExecutedAt defaults to Now.
+func (s *Server) AddTriggerExecution(e endpoints.TriggersExecutionsGetResponse) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e.ID == 0 {
+		e.ID = s.id()
+	}
+	if e.ExecutedAt == 0 {
+		e.ExecutedAt = s.now()
+	}
+	s.executions = append(s.executions, e)
+	return e.ID
+}
+
+// AddTimeTracking logs time on an incident from and to the given epochs and returns the record ID
+func (s *Server) AddTimeTracking(p endpoints.TimeTrackingPostParams) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addTimeTracking(p)
+}
+
+func (s *Server) addComment(c endpoints.IncidentCommentGetResponse) int {
+	if c.ID == 0 {
+		c.ID = s.id()
+	}
+	if c.CreatedAt == 0 {
+		c.CreatedAt = s.now()
+	}
+	c.MsgNum = len(s.comments[c.IncidentID]) + 1
+	s.comments[c.IncidentID] = append(s.comments[c.IncidentID], c)
+
+	if inc, ok := s.incidents[c.IncidentID]; ok {
+		inc.LastUpdate = c.CreatedAt
+	}
+	return c.ID
+}
+
+func (s *Server) addTimeTracking(p endpoints.TimeTrackingPostParams) int {
+	from := int64(p.From)
+	if from == 0 {
+		from = s.Now().Unix()
+	}
+	to := int64(p.To)
+
+	rec := &timeRecord{
+		TimeTrackingGetResponse: endpoints.TimeTrackingGetResponse{
+			Status:                 1,
+			Comment:                p.Comment,
+			TimetrackingCategoryID: p.CategoryID,
+			Incident:               p.RequestID,
+			TimetrackingID:         s.id(),
+			UserID:                 p.UserID,
+			Total:                  int(to - from),
+		},
+		from: from,
+		to:   to,
+	}
+	s.timeTracking[rec.TimetrackingID] = rec
+	return rec.TimetrackingID
+}
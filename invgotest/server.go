@@ -0,0 +1,379 @@
+/*
+Package invgotest provides an in-memory fake Invgate instance for testing code that uses invgo.
+
+The fake keeps state between requests so an incident created with Incident().Post can be read
+back with Incident().Get or found with IncidentsByStatus(). It issues OAuth2 tokens, enforces the
+scopes granted to each token and can inject errors and latency into responses.
+
+Example:
+
+	func TestCreateIncident(t *testing.T) {
+		srv := invgotest.NewServer(t)
+		customer := srv.AddUser(endpoints.UserGetResponse{Email: "jane@example.com"})
+
+		c := srv.Client(t, scopes.IncidentPost, scopes.IncidentGet)
+		resp, err := c.Incident().Post(endpoints.IncidentPostParams{
+			Title:      "Printer on fire",
+			TypeID:     1,
+			CreatorID:  customer,
+			CustomerID: customer,
+			PriorityID: 1,
+		})
+		...
+	}
+
+Supported endpoints are incidents and their comments, users, groups (help desks), categories,
+breaking news, time tracking, trigger executions and attributes. Requests to any other endpoint
+return a 404.
+*/
+package invgotest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/endpoints"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+// TokenPath is the path of the OAuth2 token endpoint served by Server
+const TokenPath = "/oauth/token"
+
+// Default incident status IDs seeded in /incident.attributes.status
+const (
+	StatusNew = iota + 1
+	StatusOpen
+	StatusInProgress
+	StatusWaiting
+	StatusSolved
+	StatusClosed
+	StatusRejected
+	StatusCancelled
+)
+
+type (
+	// Server is a fake Invgate instance. Create it with NewServer.
+	Server struct {
+		*httptest.Server
+
+		// ClientID and ClientSecret are required by the token endpoint if set
+		ClientID     string
+		ClientSecret string
+		// AllowedScopes limits the scopes granted to tokens. If nil every requested scope is granted.
+		AllowedScopes []scopes.ScopeType
+		// Now is used for all timestamps. It defaults to time.Now.
+		Now func() time.Time
+
+		mu       sync.Mutex
+		nextID   int
+		tokens   map[string][]scopes.ScopeType
+		faults   []*Fault
+		requests []Request
+
+		incidents    map[int]*endpoints.Incident
+		comments     map[int][]endpoints.IncidentCommentGetResponse
+		users        map[int]*endpoints.UserGetResponse
+		helpDesks    map[int]*endpoints.HelpDesksGetResponse
+		members      map[int][]int
+		categories   []endpoints.CategoriesGetResponse
+		news         map[int]*endpoints.BreakingNewsGetResponse
+		newsStatus   map[int][]endpoints.BreakingNewsStatusGetResponse
+		timeTracking map[int]*timeRecord
+		ttCategories []endpoints.TimeTrackingAttributesCategoryGetResponse
+		executions   []endpoints.TriggersExecutionsGetResponse
+		attributes   map[string][]endpoints.AttributesResponse
+	}
+
+	// Fault injects an error status or latency into matching requests
+	Fault struct {
+		// Path of the endpoint e.g. /incident. If empty every endpoint matches.
+		Path string
+		// Method is the HTTP method. If empty every method matches.
+		Method string
+		// Status is returned instead of the normal response if it is not 0
+		Status int
+		// Latency delays the response
+		Latency time.Duration
+		// Times is how many requests the fault applies to. If 0 it applies to every request.
+		Times int
+		// Lost handles the request before Status is returned so the change is made but the
+		// client receives an error, like a response lost to a timeout
+		Lost bool
+
+		hits int
+	}
+
+	// Request is a request received by the Server
+	Request struct {
+		Method string
+		Path   string
+		Query  url.Values
+	}
+
+	// apiError is returned by a handler to respond with an Invgate error
+	apiError struct {
+		status int
+		msg    string
+	}
+
+	// timeRecord is a time tracking record with its times kept as epochs
+	timeRecord struct {
+		endpoints.TimeTrackingGetResponse
+		from int64
+		to   int64
+	}
+
+	handler func(s *Server, q url.Values) (any, error)
+)
+
+// NewServer starts a fake Invgate instance seeded with default attributes.
+// The server is closed when the test finishes.
+func NewServer(t testing.TB) *Server {
+	s := &Server{
+		Now:          time.Now,
+		tokens:       map[string][]scopes.ScopeType{},
+		incidents:    map[int]*endpoints.Incident{},
+		comments:     map[int][]endpoints.IncidentCommentGetResponse{},
+		users:        map[int]*endpoints.UserGetResponse{},
+		helpDesks:    map[int]*endpoints.HelpDesksGetResponse{},
+		members:      map[int][]int{},
+		news:         map[int]*endpoints.BreakingNewsGetResponse{},
+		newsStatus:   map[int][]endpoints.BreakingNewsStatusGetResponse{},
+		timeTracking: map[int]*timeRecord{},
+		attributes:   defaultAttributes(),
+	}
+	s.Server = httptest.NewServer(s)
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Client creates an invgo.Client for the server that requests scps
+func (s *Server) Client(t testing.TB, scps ...scopes.ScopeType) *invgo.Client {
+	t.Helper()
+	c, err := invgo.New(s.Config(scps...))
+	if err != nil {
+		t.Fatalf("unable to create invgotest client: %s", err)
+	}
+	return c
+}
+
+// Config returns an invgo.Invgate config for the server that requests scps
+func (s *Server) Config(scps ...scopes.ScopeType) *invgo.Invgate {
+	id, secret := s.ClientID, s.ClientSecret
+	if id == "" {
+		id = "invgotest"
+	}
+	if secret == "" {
+		secret = "invgotest"
+	}
+	return &invgo.Invgate{
+		BaseURL:      s.URL,
+		TokenURL:     s.URL + TokenPath,
+		ClientID:     id,
+		ClientSecret: secret,
+		AllowHTTP:    true,
+		Scopes:       scps,
+	}
+}
+
+// InjectFault adds a fault. Faults are checked in the order they were added.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes every fault
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns every API request received by the server in order.
+// Requests to the token endpoint are not included.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// ServeHTTP handles token and API requests
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == TokenPath {
+		s.serveToken(w, r)
+		return
+	}
+
+	path, ok := strings.CutPrefix(r.URL.Path, invgo.InvgateAPIPath)
+	if !ok {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: path, Query: r.URL.Query()})
+	f := s.fault(path, r.Method)
+	s.mu.Unlock()
+
+	if f != nil {
+		if f.Latency > 0 {
+			select {
+			case <-time.After(f.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if f.Status != 0 && !f.Lost {
+			writeError(w, f.Status, http.StatusText(f.Status))
+			return
+		}
+	}
+
+	h, ok := routes[r.Method+" "+path]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	if err := s.authorize(r, path); err != nil {
+		var apiErr *apiError
+		errors.As(err, &apiErr)
+		writeError(w, apiErr.status, apiErr.msg)
+		return
+	}
+
+	s.mu.Lock()
+	resp, err := h(s, r.URL.Query())
+	s.mu.Unlock()
+	if err != nil {
+		var apiErr *apiError
+		if !errors.As(err, &apiErr) {
+			apiErr = &apiError{status: http.StatusBadRequest, msg: err.Error()}
+		}
+		writeError(w, apiErr.status, apiErr.msg)
+		return
+	}
+	if f != nil && f.Lost && f.Status != 0 {
+		writeError(w, f.Status, http.StatusText(f.Status))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// fault returns the first fault matching the request and counts the hit
+func (s *Server) fault(path, method string) *Fault {
+	for i, f := range s.faults {
+		if f.Path != "" && f.Path != path {
+			continue
+		}
+		if f.Method != "" && !strings.EqualFold(f.Method, method) {
+			continue
+		}
+		f.hits++
+		if f.Times > 0 && f.hits >= f.Times {
+			s.faults = slices.Delete(s.faults, i, i+1)
+		}
+		return f
+	}
+	return nil
+}
+
+// serveToken issues a token for the client credentials grant
+func (s *Server) serveToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if r.PostForm.Get("grant_type") != "client_credentials" {
+		writeError(w, http.StatusBadRequest, "unsupported grant type")
+		return
+	}
+
+	id, secret, ok := r.BasicAuth()
+	if !ok {
+		id, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if (s.ClientID != "" && id != s.ClientID) || (s.ClientSecret != "" && secret != s.ClientSecret) {
+		writeError(w, http.StatusUnauthorized, "invalid client")
+		return
+	}
+
+	var granted []scopes.ScopeType
+	for _, raw := range strings.Fields(r.PostForm.Get("scope")) {
+		scp := scopes.ScopeType(raw)
+		if s.AllowedScopes == nil || slices.Contains(s.AllowedScopes, scp) {
+			granted = append(granted, scp)
+		}
+	}
+
+	s.mu.Lock()
+	token := "invgotest-" + strconv.Itoa(len(s.tokens)+1)
+	s.tokens[token] = granted
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"access_token": token,
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"scope":        strings.Join(scopes.CreateScopes(granted), " "),
+	})
+}
+
+// authorize checks the request has a valid token with the scope required by the endpoint
+func (s *Server) authorize(r *http.Request, path string) error {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return &apiError{status: http.StatusUnauthorized, msg: "missing bearer token"}
+	}
+
+	s.mu.Lock()
+	granted, ok := s.tokens[token]
+	s.mu.Unlock()
+	if !ok {
+		return &apiError{status: http.StatusUnauthorized, msg: "invalid token"}
+	}
+
+	required, ok := scopes.Lookup(path, r.Method)
+	if !ok || !slices.Contains(granted, required) {
+		return &apiError{status: http.StatusForbidden, msg: fmt.Sprintf("token does not have scope %s", required)}
+	}
+	return nil
+}
+
+func (e *apiError) Error() string { return e.msg }
+
+func notFound(kind string, id int) error {
+	return &apiError{status: http.StatusNotFound, msg: fmt.Sprintf("%s %d not found", kind, id)}
+}
+
+// writeError writes an error in the format returned by Invgate
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{"error": msg, "status": status})
+}
+
+// id returns the next ID for seeded and created records
+func (s *Server) id() int {
+	s.nextID++
+	return s.nextID
+}
+
+func (s *Server) now() int {
+	return int(s.Now().Unix())
+}
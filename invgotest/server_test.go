@@ -0,0 +1,186 @@
+package invgotest_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmstorm/invgo/endpoints"
+	"github.com/tmstorm/invgo/invgotest"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+func TestServerIncidents(t *testing.T) {
+	a := assert.New(t)
+	srv := invgotest.NewServer(t)
+	customer := srv.AddUser(endpoints.UserGetResponse{Email: "jane@example.com"})
+
+	c := srv.Client(t, scopes.IncidentPost, scopes.IncidentGet, scopes.IncidentsByStatusGet,
+		scopes.IncidentCommentPost, scopes.IncidentCommentGet, scopes.IncidentsByCustomerGet)
+
+	created, err := c.Incident().Post(endpoints.IncidentPostParams{
+		Title:      "Printer on fire",
+		TypeID:     1,
+		CreatorID:  customer,
+		CustomerID: customer,
+		PriorityID: 2,
+	})
+	a.NoError(err)
+	a.Equal("OK", created.Status)
+	id, err := strconv.Atoi(created.RequestID)
+	a.NoError(err)
+
+	incs, err := c.Incident().Get(endpoints.IncidentGetParams{ID: id})
+	a.NoError(err)
+	if a.Len(incs, 1) {
+		a.Equal("Printer on fire", incs[0].Title)
+		a.Equal(invgotest.StatusNew, incs[0].StatusID)
+		a.Equal(customer, incs[0].UserID)
+	}
+
+	byStatus, err := c.IncidentsByStatus().Get(endpoints.IncidentsByStatusGetParams{StatusIDs: []int{invgotest.StatusNew}})
+	a.NoError(err)
+	a.Equal([]int{id}, byStatus.RequestIDs)
+
+	byStatus, err = c.IncidentsByStatus().Get(endpoints.IncidentsByStatusGetParams{StatusIDs: []int{invgotest.StatusClosed}})
+	a.NoError(err)
+	a.Empty(byStatus.RequestIDs)
+
+	_, err = c.IncidentComment().Post(endpoints.IncidentCommentPostParams{
+		AuthorID:   customer,
+		RequestID:  id,
+		Comment:    "Fixed",
+		IsSolution: true,
+	})
+	a.NoError(err)
+
+	comments, err := c.IncidentComment().Get(endpoints.IncidentCommentGetParams{RequestID: id})
+	a.NoError(err)
+	if a.Len(comments, 1) {
+		a.Equal("Fixed", comments[0].Message)
+	}
+	inc, _ := srv.Incident(id)
+	a.Equal(invgotest.StatusSolved, inc.StatusID)
+
+	byCustomer, err := c.IncidentsByCustomer().Get(endpoints.IncidentsByCustomerGetParams{Email: "jane@example.com"})
+	a.NoError(err)
+	a.Contains(byCustomer.Requests, id)
+
+	_, err = c.Incident().Get(endpoints.IncidentGetParams{ID: id + 100})
+	a.Error(err)
+}
+
+func TestServerScopes(t *testing.T) {
+	a := assert.New(t)
+	srv := invgotest.NewServer(t)
+	id := srv.AddIncident(endpoints.Incident{Title: "test"})
+
+	c := srv.Client(t, scopes.IncidentGet)
+	_, err := c.Incident().Get(endpoints.IncidentGetParams{ID: id})
+	a.NoError(err)
+
+	// the token is only granted scopes the server allows
+	srv.AllowedScopes = []scopes.ScopeType{scopes.IncidentsByStatusGet}
+	c = srv.Client(t, scopes.IncidentGet)
+	_, err = c.Incident().Get(endpoints.IncidentGetParams{ID: id})
+	a.ErrorContains(err, "403")
+}
+
+func TestServerFaults(t *testing.T) {
+	a := assert.New(t)
+	srv := invgotest.NewServer(t)
+	id := srv.AddIncident(endpoints.Incident{Title: "test"})
+	c := srv.Client(t, scopes.IncidentGet)
+
+	srv.InjectFault(invgotest.Fault{Path: "/incident", Status: 503, Times: 1})
+	_, err := c.Incident().Get(endpoints.IncidentGetParams{ID: id})
+	a.ErrorContains(err, "503")
+
+	_, err = c.Incident().Get(endpoints.IncidentGetParams{ID: id})
+	a.NoError(err)
+
+	srv.InjectFault(invgotest.Fault{Latency: 50 * time.Millisecond})
+	start := time.Now()
+	_, err = c.Incident().Get(endpoints.IncidentGetParams{ID: id})
+	a.NoError(err)
+	a.GreaterOrEqual(time.Since(start), 50*time.Millisecond)
+
+	srv.ClearFaults()
+	a.Len(srv.Requests(), 3)
+
+	// a lost response still makes the change
+	c = srv.Client(t, scopes.IncidentPost)
+	srv.InjectFault(invgotest.Fault{Path: "/incident", Method: "POST", Status: 504, Times: 1, Lost: true})
+	_, err = c.Incident().Post(endpoints.IncidentPostParams{Title: "lost", TypeID: 1, CreatorID: 1, PriorityID: 1, CustomerID: 1})
+	a.ErrorContains(err, "504")
+	_, ok := srv.Incident(id + 1)
+	a.True(ok)
+}
+
+func TestServerUsers(t *testing.T) {
+	a := assert.New(t)
+	srv := invgotest.NewServer(t)
+	group := srv.AddHelpDesk(endpoints.HelpDesksGetResponse{Name: "Support"})
+
+	c := srv.Client(t, scopes.UserPost, scopes.UserByGet, scopes.UserDisablePut, scopes.UsersGet, scopes.UsersGroupsGet)
+	created, err := c.User().Post(endpoints.UserPostParams{Email: "john@example.com", Name: "John", LastName: "Doe"})
+	a.NoError(err)
+	srv.AddHelpDesk(endpoints.HelpDesksGetResponse{ID: group, Name: "Support"}, created.ID)
+
+	u, err := c.UserBy().Get(endpoints.UserByGetParams{Email: "john@example.com"})
+	a.NoError(err)
+	a.Equal(created.ID, u.ID)
+
+	groups, err := c.UsersGroups().Get(endpoints.UsersGroupsGetParams{IDs: []int{created.ID}})
+	a.NoError(err)
+	if a.Len(groups, 1) {
+		a.Contains(groups[0].Groups, group)
+	}
+
+	_, err = c.UserDisable().Put(endpoints.UserDisablePutParams{ID: created.ID})
+	a.NoError(err)
+	users, err := c.Users().Get(endpoints.UsersGetParams{IDs: []int{created.ID}})
+	a.NoError(err)
+	a.Empty(users)
+}
+
+func TestServerBreakingNews(t *testing.T) {
+	a := assert.New(t)
+	srv := invgotest.NewServer(t)
+	c := srv.Client(t, scopes.BreakingNewsPost, scopes.BreakingNewsGet, scopes.BreakingNewsAll)
+
+	created, err := c.BreakingNews().Post(endpoints.BreakingNewsPostParams{TypeID: 2, Title: "Outage", Body: "Email is down"})
+	a.NoError(err)
+	id, _ := strconv.Atoi(created.ID)
+
+	n, err := c.BreakingNews().Get(endpoints.BreakingNewsGetParams{ID: id})
+	a.NoError(err)
+	a.Equal("Outage", n.Title)
+
+	all, err := c.BreakingNewsAll().Get()
+	a.NoError(err)
+	a.Len(all, 1)
+}
+
+func TestServerTimeTracking(t *testing.T) {
+	a := assert.New(t)
+	srv := invgotest.NewServer(t)
+	inc := srv.AddIncident(endpoints.Incident{Title: "test"})
+	c := srv.Client(t, scopes.TimeTrackingPost, scopes.TimeTrackingGet, scopes.TimeTrackingDelete)
+
+	created, err := c.TimeTracking().Post(endpoints.TimeTrackingPostParams{UserID: 1, RequestID: inc, From: 1000, To: 1600})
+	a.NoError(err)
+
+	records, err := c.TimeTracking().Get(endpoints.TimeTrackingGetParams{RequestID: inc})
+	a.NoError(err)
+	if a.Len(records, 1) {
+		a.Equal(600, records[0].Total)
+	}
+
+	_, err = c.TimeTracking().Delete(endpoints.TimeTrackingDeleteParams{UserID: 1, RequestID: inc, TimetrackingID: created.TimetrackingID})
+	a.NoError(err)
+	records, err = c.TimeTracking().Get(endpoints.TimeTrackingGetParams{RequestID: inc})
+	a.NoError(err)
+	a.Empty(records)
+}
@@ -0,0 +1,359 @@
+package invgo
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tmstorm/invgo/endpoints"
+)
+
+// ErrIllegalTransition is wrapped by a TransitionError when the incident is not in a
+// status the transition can be made from
+var ErrIllegalTransition = errors.New("illegal incident transition")
+
+// ErrInvalidWaitFor is returned by IncidentLifecycle.WaitFor when WaitForParams is missing
+// a field required by its reason or the reason is unknown
+var ErrInvalidWaitFor = errors.New("invalid wait for params")
+
+// incidentStatusNames are the names each status is found by in /incident.attributes.status.
+// Names are matched case insensitively.
+var incidentStatusNames = []struct {
+	names []string
+	field func(*IncidentStatuses) *int
+}{
+	{[]string{"new"}, func(s *IncidentStatuses) *int { return &s.New }},
+	{[]string{"open"}, func(s *IncidentStatuses) *int { return &s.Open }},
+	{[]string{"in progress"}, func(s *IncidentStatuses) *int { return &s.InProgress }},
+	{[]string{"waiting", "waiting for"}, func(s *IncidentStatuses) *int { return &s.Waiting }},
+	{[]string{"solved"}, func(s *IncidentStatuses) *int { return &s.Solved }},
+	{[]string{"closed"}, func(s *IncidentStatuses) *int { return &s.Closed }},
+	{[]string{"rejected"}, func(s *IncidentStatuses) *int { return &s.Rejected }},
+	{[]string{"cancelled", "canceled"}, func(s *IncidentStatuses) *int { return &s.Cancelled }},
+}
+
+// Transition is a change of incident status made by IncidentLifecycle
+type Transition string
+
+const (
+	TransitionSolve          Transition = "solve"
+	TransitionClose          Transition = "close"
+	TransitionRejectSolution Transition = "reject solution"
+	TransitionReopen         Transition = "reopen"
+	TransitionWaitFor        Transition = "wait for"
+	TransitionReassign       Transition = "reassign"
+	TransitionCancel         Transition = "cancel"
+	TransitionReject         Transition = "reject"
+)
+
+// WaitReason is what an incident waits for, see IncidentLifecycle.WaitFor
+type WaitReason string
+
+const (
+	WaitForCustomer WaitReason = "customer"
+	WaitForAgent    WaitReason = "agent"
+	WaitForDate     WaitReason = "date"
+	WaitForIncident WaitReason = "incident"
+	WaitForEntity   WaitReason = "entity"
+)
+
+type (
+	// IncidentStatuses are the status IDs used by an instance. They can be loaded by name
+	// with LoadIncidentStatuses or found with IncidentAttributesStatus or Catalog.
+	IncidentStatuses struct {
+		New        int
+		Open       int
+		InProgress int
+		Waiting    int
+		Solved     int
+		Closed     int
+		Rejected   int
+		Cancelled  int
+	}
+
+	// TransitionError is returned by IncidentLifecycle when a transition fails.
+	// It wraps ErrIllegalTransition if the incident was in the wrong status, otherwise
+	// the error returned by Invgate.
+	TransitionError struct {
+		IncidentID int
+		Transition Transition
+		// StatusID is the status of the incident before the transition
+		StatusID int
+		Err      error
+	}
+
+	// WaitForParams is used by IncidentLifecycle.WaitFor
+	WaitForParams struct {
+		Reason WaitReason
+		// Until is required for WaitForDate
+		Until time.Time
+		// EntityLinkID is required for WaitForEntity
+		EntityLinkID int
+	}
+
+	// IncidentLifecycle changes the status of incidents using the endpoint for each transition.
+	// The current status is requested first and a TransitionError wrapping ErrIllegalTransition
+	// is returned without calling the endpoint if the transition is not allowed from it.
+	//
+	// Requires scopes: IncidentGet, the scope of each transition used and
+	// IncidentAttributesStatusGet if the statuses are loaded
+	IncidentLifecycle struct {
+		client *Client
+
+		mu       sync.Mutex
+		statuses IncidentStatuses
+	}
+)
+
+// NewIncidentLifecycle creates an IncidentLifecycle for client.
+// If statuses is empty they are loaded with LoadIncidentStatuses before the first transition.
+func NewIncidentLifecycle(client *Client, statuses IncidentStatuses) *IncidentLifecycle {
+	return &IncidentLifecycle{client: client, statuses: statuses}
+}
+
+// LoadIncidentStatuses finds the ID of every status in IncidentStatuses by its name in
+// /incident.attributes.status. An error is returned if a status can not be found, the
+// statuses must then be provided to NewIncidentLifecycle.
+//
+// Requires scope: IncidentAttributesStatusGet
+func LoadIncidentStatuses(client *Client) (IncidentStatuses, error) {
+	attrs, err := client.IncidentAttributesStatus().Get(endpoints.AttributesGetParams{})
+	if err != nil {
+		return IncidentStatuses{}, fmt.Errorf("unable to load incident statuses: %w", err)
+	}
+
+	ids := make(map[string]int, len(attrs))
+	for _, a := range attrs {
+		ids[normalizeName(a.Name)] = a.ID
+	}
+
+	var s IncidentStatuses
+	var missing []string
+	for _, st := range incidentStatusNames {
+		found := false
+		for _, name := range st.names {
+			if id, ok := ids[name]; ok {
+				*st.field(&s) = id
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, strconv.Quote(st.names[0]))
+		}
+	}
+	if len(missing) > 0 {
+		return IncidentStatuses{}, fmt.Errorf("incident statuses %s were not found", strings.Join(missing, ", "))
+	}
+	return s, nil
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("unable to %s incident %d in status %d: %s", e.Transition, e.IncidentID, e.StatusID, e.Err)
+}
+
+func (e *TransitionError) Unwrap() error { return e.Err }
+
+// From returns the statuses t can be made from
+func (s IncidentStatuses) From(t Transition) []int {
+	active := []int{s.New, s.Open, s.InProgress, s.Waiting}
+	switch t {
+	case TransitionSolve, TransitionWaitFor, TransitionReassign, TransitionCancel, TransitionReject:
+		return active
+	case TransitionClose, TransitionRejectSolution:
+		return []int{s.Solved}
+	case TransitionReopen:
+		return []int{s.Solved, s.Closed}
+	default:
+		return nil
+	}
+}
+
+// Solve adds solution as the solution comment of the incident
+//
+// Requires scope: IncidentCommentPost
+func (l *IncidentLifecycle) Solve(id, authorID int, solution string) error {
+	return l.transition(id, TransitionSolve, func() (string, string, error) {
+		r, err := l.client.IncidentComment().Post(endpoints.IncidentCommentPostParams{
+			RequestID:       id,
+			AuthorID:        authorID,
+			Comment:         solution,
+			IsSolution:      true,
+			CustomerVisible: true,
+		})
+		return r.Status, r.Error, err
+	})
+}
+
+// Close accepts the solution of a solved incident with a rating
+//
+// Requires scope: IncidentSolutionAcceptPut
+func (l *IncidentLifecycle) Close(id, rating int, comment string) error {
+	return l.transition(id, TransitionClose, func() (string, string, error) {
+		r, err := l.client.IncidentSolutionAccept().Put(endpoints.IncidentSolutionAcceptPutParams{ID: id, Rating: rating, Comment: comment})
+		return r.Status, "", err
+	})
+}
+
+// RejectSolution rejects the solution of a solved incident and reopens it
+//
+// Requires scope: IncidentSolutionRejectPut
+func (l *IncidentLifecycle) RejectSolution(id int, comment string) error {
+	return l.transition(id, TransitionRejectSolution, func() (string, string, error) {
+		r, err := l.client.IncidentSolutionReject().Put(endpoints.IncidentSolutionRejectPutParams{ID: id, Comment: comment})
+		return r.Status, "", err
+	})
+}
+
+// Reopen reopens a solved or closed incident
+//
+// Requires scope: IncidentReopenPut
+func (l *IncidentLifecycle) Reopen(id, authorID int) error {
+	return l.transition(id, TransitionReopen, func() (string, string, error) {
+		r, err := l.client.IncidentReopen().Put(endpoints.IncidentReopenPutParams{RequestID: id, AuthorID: authorID})
+		return r.Status, r.Error, err
+	})
+}
+
+// WaitFor sets the incident as waiting for the reason in p
+//
+// Requires scope: the IncidentWaitingFor scope for the reason
+func (l *IncidentLifecycle) WaitFor(id int, p WaitForParams) error {
+	var call func() (string, string, error)
+	switch p.Reason {
+	case WaitForCustomer:
+		call = func() (string, string, error) {
+			r, err := l.client.IncidentWaitingForCustomer().Post(endpoints.IncidentWaitingForCustomerPostParams{RequestID: id})
+			return r.Status, r.Info, err
+		}
+	case WaitForAgent:
+		call = func() (string, string, error) {
+			r, err := l.client.IncidentWaitingForAgent().Post(endpoints.IncidentWaitingForAgentPostParams{RequestID: id})
+			return r.Status, r.Info, err
+		}
+	case WaitForDate:
+		if p.Until.IsZero() {
+			return fmt.Errorf("%w: waiting for a date requires Until", ErrInvalidWaitFor)
+		}
+		call = func() (string, string, error) {
+			r, err := l.client.IncidentWaitingForDate().Post(endpoints.IncidentWaitingForDatePostParams{
+				RequestID: id,
+				Timestamp: strconv.FormatInt(p.Until.Unix(), 10),
+			})
+			return r.Status, r.Info, err
+		}
+	case WaitForIncident:
+		call = func() (string, string, error) {
+			r, err := l.client.IncidentWaitingForIncident().Post(endpoints.IncidentWaitingForIncidentPostParams{RequestID: id})
+			return r.Status, r.Info, err
+		}
+	case WaitForEntity:
+		if p.EntityLinkID == 0 {
+			return fmt.Errorf("%w: waiting for an external entity requires EntityLinkID", ErrInvalidWaitFor)
+		}
+		call = func() (string, string, error) {
+			r, err := l.client.IncidentWaitingForExternalEntity().Post(endpoints.IncidentWaitingForExternalEntityPostParams{
+				RequestID:    id,
+				EntityLinkID: p.EntityLinkID,
+			})
+			return r.Status, r.Info, err
+		}
+	default:
+		return fmt.Errorf("%w: unknown wait reason %q", ErrInvalidWaitFor, p.Reason)
+	}
+	return l.transition(id, TransitionWaitFor, call)
+}
+
+// Reassign assigns the incident to a help desk and optionally an agent in it
+//
+// Requires scope: IncidentReassignPost
+func (l *IncidentLifecycle) Reassign(id, authorID, groupID, agentID int) error {
+	return l.transition(id, TransitionReassign, func() (string, string, error) {
+		r, err := l.client.IncidentReassign().Post(endpoints.IncidentReassignPostParams{
+			RequestID: id,
+			AuthorID:  authorID,
+			GroupID:   groupID,
+			AgentID:   agentID,
+		})
+		return r.Status, "", err
+	})
+}
+
+// Cancel cancels the incident
+//
+// Requires scope: IncidentCancelPost
+func (l *IncidentLifecycle) Cancel(id, authorID int, comment string) error {
+	return l.transition(id, TransitionCancel, func() (string, string, error) {
+		r, err := l.client.IncidentCancel().Post(endpoints.IncidentCancelPostParams{RequestID: id, AuthorID: authorID, Comment: comment})
+		return r.Status, "", err
+	})
+}
+
+// Reject rejects the incident
+//
+// Requires scope: IncidentRejectPost
+func (l *IncidentLifecycle) Reject(id, authorID int) error {
+	return l.transition(id, TransitionReject, func() (string, string, error) {
+		r, err := l.client.IncidentReject().Post(endpoints.IncidentRejectPostParams{RequestID: id, AuthorID: authorID})
+		return r.Status, "", err
+	})
+}
+
+// transition checks the incident can make t from its current status and runs call.
+// call returns the status field of the response, which is an error if it is ERROR, and the
+// message Invgate returned with it.
+func (l *IncidentLifecycle) transition(id int, t Transition, call func() (string, string, error)) error {
+	statuses, err := l.loadStatuses()
+	if err != nil {
+		return err
+	}
+
+	incs, err := l.client.Incident().Get(endpoints.IncidentGetParams{ID: id})
+	if err != nil {
+		return err
+	}
+	if len(incs) == 0 {
+		return fmt.Errorf("incident %d not found", id)
+	}
+
+	status := incs[0].StatusID
+	if !slices.Contains(statuses.From(t), status) {
+		return &TransitionError{IncidentID: id, Transition: t, StatusID: status, Err: ErrIllegalTransition}
+	}
+
+	s, msg, err := call()
+	if s == "ERROR" {
+		// some endpoint methods already return an error for ERROR but none include the message
+		if err == nil {
+			err = errors.New("invgate returned ERROR")
+		}
+		if msg != "" {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+	}
+	if err != nil {
+		return &TransitionError{IncidentID: id, Transition: t, StatusID: status, Err: err}
+	}
+	return nil
+}
+
+// loadStatuses returns the statuses of the lifecycle loading them the first time if none were provided.
+// If loading fails it is retried on the next transition.
+func (l *IncidentLifecycle) loadStatuses() (IncidentStatuses, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.statuses != (IncidentStatuses{}) {
+		return l.statuses, nil
+	}
+
+	s, err := LoadIncidentStatuses(l.client)
+	if err != nil {
+		return IncidentStatuses{}, err
+	}
+	l.statuses = s
+	return s, nil
+}
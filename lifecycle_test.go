@@ -0,0 +1,107 @@
+package invgo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/endpoints"
+	"github.com/tmstorm/invgo/invgotest"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+func TestIncidentLifecycle(t *testing.T) {
+	a := assert.New(t)
+
+	srv := invgotest.NewServer(t)
+	id := srv.AddIncident(endpoints.Incident{Title: "Printer on fire"})
+	desk := srv.AddHelpDesk(endpoints.HelpDesksGetResponse{Name: "Service Desk"})
+
+	c := srv.Client(t, scopes.IncidentGet, scopes.IncidentAttributesStatusGet, scopes.IncidentCommentPost, scopes.IncidentSolutionAcceptPut,
+		scopes.IncidentReopenPut, scopes.IncidentReassignPost, scopes.IncidentWaitingForDatePost, scopes.IncidentCancelPost)
+	l := invgo.NewIncidentLifecycle(c, invgo.IncidentStatuses{})
+
+	status := func() int {
+		incs, err := c.Incident().Get(endpoints.IncidentGetParams{ID: id})
+		a.NoError(err)
+		return incs[0].StatusID
+	}
+
+	// only solved incidents can be closed
+	err := l.Close(id, 5, "")
+	a.ErrorIs(err, invgo.ErrIllegalTransition)
+	var te *invgo.TransitionError
+	a.ErrorAs(err, &te)
+	a.Equal(invgo.TransitionClose, te.Transition)
+	a.Equal(invgotest.StatusNew, te.StatusID)
+
+	a.NoError(l.Reassign(id, 1, desk, 2))
+	a.Equal(invgotest.StatusOpen, status())
+
+	a.NoError(l.WaitFor(id, invgo.WaitForParams{Reason: invgo.WaitForDate, Until: time.Now().Add(time.Hour)}))
+	a.Equal(invgotest.StatusWaiting, status())
+	a.ErrorIs(l.WaitFor(id, invgo.WaitForParams{Reason: invgo.WaitForEntity}), invgo.ErrInvalidWaitFor)
+
+	a.NoError(l.Solve(id, 2, "Put it out"))
+	a.Equal(invgotest.StatusSolved, status())
+	a.ErrorIs(l.Cancel(id, 1, ""), invgo.ErrIllegalTransition)
+
+	a.NoError(l.Close(id, 5, "Thanks"))
+	a.Equal(invgotest.StatusClosed, status())
+
+	a.NoError(l.Reopen(id, 1))
+	a.Equal(invgotest.StatusOpen, status())
+
+	a.NoError(l.Cancel(id, 1, "Duplicate"))
+	a.Equal(invgotest.StatusCancelled, status())
+	a.ErrorIs(l.Reopen(id, 1), invgo.ErrIllegalTransition)
+}
+
+func TestLoadIncidentStatuses(t *testing.T) {
+	a := assert.New(t)
+
+	srv := invgotest.NewServer(t)
+	srv.SetAttributes("/incident.attributes.status", []endpoints.AttributesResponse{
+		{ID: 11, Name: "New"},
+		{ID: 12, Name: "Open"},
+		{ID: 13, Name: "In Progress"},
+		{ID: 14, Name: "Waiting for"},
+		{ID: 15, Name: "Solved"},
+		{ID: 16, Name: "Closed"},
+		{ID: 17, Name: "Rejected"},
+		{ID: 18, Name: "Canceled"},
+	})
+	c := srv.Client(t, scopes.IncidentAttributesStatusGet)
+
+	statuses, err := invgo.LoadIncidentStatuses(c)
+	a.NoError(err)
+	a.Equal(invgo.IncidentStatuses{
+		New: 11, Open: 12, InProgress: 13, Waiting: 14, Solved: 15, Closed: 16, Rejected: 17, Cancelled: 18,
+	}, statuses)
+
+	srv.SetAttributes("/incident.attributes.status", []endpoints.AttributesResponse{{ID: 1, Name: "New"}})
+	_, err = invgo.LoadIncidentStatuses(c)
+	a.ErrorContains(err, `"open"`)
+}
+
+func TestIncidentLifecycleErrorResponse(t *testing.T) {
+	a := assert.New(t)
+
+	server := newRoutedServer(t, map[string]any{
+		"/incident":                     []endpoints.Incident{{ID: 1, StatusID: 2}},
+		"/incident.waitingfor.customer": map[string]string{"status": "ERROR", "info": "customer has no email"},
+	})
+	defer server.Close()
+
+	c := newTestClient(t, server, scopes.IncidentGet, scopes.IncidentWaitingForCustomerPost)
+	statuses := invgo.IncidentStatuses{New: 1, Open: 2, InProgress: 3, Waiting: 4, Solved: 5, Closed: 6, Rejected: 7, Cancelled: 8}
+	l := invgo.NewIncidentLifecycle(c, statuses)
+
+	err := l.WaitFor(1, invgo.WaitForParams{Reason: invgo.WaitForCustomer})
+	var te *invgo.TransitionError
+	if a.ErrorAs(err, &te) {
+		a.Equal(invgo.TransitionWaitFor, te.Transition)
+	}
+	a.ErrorContains(err, "customer has no email")
+}
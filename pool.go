@@ -0,0 +1,266 @@
+package invgo
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownInstance is returned when a Pool has no instance with the requested name
+var ErrUnknownInstance = errors.New("unknown instance")
+
+type (
+	// Pool holds the configs of multiple named Invgate instances and creates their clients
+	// the first time they are used.
+	//
+	// Example:
+	//
+	//	pool, err := invgo.NewPool(map[string]*invgo.Invgate{
+	//		"tenant-a": cfgA,
+	//		"tenant-b": cfgB,
+	//	})
+	//
+	//	results, err := invgo.PoolRun(pool, func(name string, c *invgo.Client) ([]endpoints.IncidentsLastHourGetResponse, error) {
+	//		return c.IncidentsLastHour().Get(endpoints.IncidentsLastHourGetParams{})
+	//	})
+	Pool struct {
+		// Concurrency is the max amount of instances PoolRun calls at once. If 0 all instances are called at once.
+		Concurrency int
+
+		mu      sync.Mutex
+		configs map[string]*Invgate
+		clients map[string]*Client
+		// creating holds the clients being created so New is called once per instance at a time
+		creating map[string]*poolCall
+	}
+
+	// poolCall is a client being created for an instance. done is closed once c or err is set.
+	poolCall struct {
+		done chan struct{}
+		c    *Client
+		err  error
+	}
+
+	// InstanceResult labels a value with the name of the instance it was returned from
+	InstanceResult[T any] struct {
+		Instance string `json:"instance"`
+		Value    T      `json:"value"`
+	}
+
+	// PoolError maps the name of each instance that failed to its error
+	PoolError map[string]error
+)
+
+// NewPool creates a Pool from the configs keyed by instance name.
+// An error is returned if a name is empty or a config is nil.
+func NewPool(configs map[string]*Invgate) (*Pool, error) {
+	p := &Pool{}
+	for name, cfg := range configs {
+		if err := p.Add(name, cfg); err != nil {
+			return nil, err
+		}
+	}
+	p.init()
+	return p, nil
+}
+
+// Add adds an instance to the Pool. An error is returned if the name is already used.
+func (p *Pool) Add(name string, cfg *Invgate) error {
+	if name == "" {
+		return errors.New("instance name can not be empty")
+	}
+	if cfg == nil {
+		return fmt.Errorf("instance %s: no config was provided", name)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.init()
+	if _, ok := p.configs[name]; ok {
+		return fmt.Errorf("instance %s already exists in pool", name)
+	}
+	p.configs[name] = cfg
+	return nil
+}
+
+// Remove removes an instance and its client from the Pool
+func (p *Pool) Remove(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.configs, name)
+	delete(p.clients, name)
+}
+
+// Names returns the names of every instance in the Pool sorted alphabetically
+func (p *Pool) Names() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	names := make([]string, 0, len(p.configs))
+	for name := range p.configs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Client returns the client for the named instance creating it with New if needed.
+// If creating the client fails it will be retried on the next call.
+// Creating a client does not block the other instances in the Pool.
+func (p *Pool) Client(name string) (*Client, error) {
+	p.mu.Lock()
+	p.init()
+
+	if c, ok := p.clients[name]; ok {
+		p.mu.Unlock()
+		return c, nil
+	}
+
+	// another caller is already creating the client
+	if call, ok := p.creating[name]; ok {
+		p.mu.Unlock()
+		<-call.done
+		return call.c, call.err
+	}
+
+	cfg, ok := p.configs[name]
+	if !ok {
+		p.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s", ErrUnknownInstance, name)
+	}
+	call := &poolCall{done: make(chan struct{})}
+	p.creating[name] = call
+	p.mu.Unlock()
+
+	call.c, call.err = New(cfg)
+	if call.err != nil {
+		call.c = nil
+		call.err = fmt.Errorf("unable to create client for instance %s: %w", name, call.err)
+	}
+
+	p.mu.Lock()
+	delete(p.creating, name)
+	// the instance may have been removed or replaced while its client was created
+	if call.err == nil && p.configs[name] == cfg {
+		p.clients[name] = call.c
+	}
+	p.mu.Unlock()
+	close(call.done)
+
+	return call.c, call.err
+}
+
+// init allows a zero value Pool to be used
+func (p *Pool) init() {
+	if p.configs == nil {
+		p.configs = map[string]*Invgate{}
+	}
+	if p.clients == nil {
+		p.clients = map[string]*Client{}
+	}
+	if p.creating == nil {
+		p.creating = map[string]*poolCall{}
+	}
+}
+
+// PoolRun calls fn for every instance in the Pool concurrently.
+// The results of the instances that succeeded are returned sorted by instance name.
+// If any instance fails, including creating its client, a PoolError is also returned.
+func PoolRun[T any](p *Pool, fn func(instance string, c *Client) (T, error)) ([]InstanceResult[T], error) {
+	names := p.Names()
+
+	concurrency := p.Concurrency
+	if concurrency <= 0 {
+		concurrency = len(names)
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make([]InstanceResult[T], 0, len(names))
+		errs    = PoolError{}
+		sem     = make(chan struct{}, max(concurrency, 1))
+	)
+	for _, name := range names {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			v, err := runInstance(p, name, fn)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[name] = err
+				return
+			}
+			results = append(results, InstanceResult[T]{Instance: name, Value: v})
+		}()
+	}
+	wg.Wait()
+
+	slices.SortFunc(results, func(a, b InstanceResult[T]) int {
+		return strings.Compare(a.Instance, b.Instance)
+	})
+
+	if len(errs) > 0 {
+		return results, errs
+	}
+	return results, nil
+}
+
+// runInstance calls fn for a single instance recovering from any panic so one
+// instance can not stop the rest of the Pool
+func runInstance[T any](p *Pool, name string, fn func(string, *Client) (T, error)) (v T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	c, err := p.Client(name)
+	if err != nil {
+		return v, err
+	}
+	return fn(name, c)
+}
+
+// PoolMerge flattens the slices returned by PoolRun into a single slice where every
+// value is labeled with its instance
+func PoolMerge[T any](results []InstanceResult[[]T]) []InstanceResult[T] {
+	var merged []InstanceResult[T]
+	for _, r := range results {
+		for _, v := range r.Value {
+			merged = append(merged, InstanceResult[T]{Instance: r.Instance, Value: v})
+		}
+	}
+	return merged
+}
+
+// Error lists every failed instance and its error sorted by instance name
+func (e PoolError) Error() string {
+	names := make([]string, 0, len(e))
+	for name := range e {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	msgs := make([]string, 0, len(names))
+	for _, name := range names {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", name, e[name]))
+	}
+	return "instances failed: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the errors of every instance so errors.Is and errors.As can be used
+func (e PoolError) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
@@ -0,0 +1,162 @@
+package invgo_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/endpoints"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+// newInstanceServer returns a server that issues tokens and responds to /incidents.last.hour with incidents
+func newInstanceServer(t *testing.T, incidents string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/oauth/token":
+			w.Write([]byte(`{"access_token":"token","token_type":"bearer","expires_in":3600}`))
+		case invgo.InvgateAPIPath + "/incidents.last.hour":
+			w.Write([]byte(incidents))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"not found","status":404}`))
+		}
+	}))
+}
+
+func newInstanceConfig(server *httptest.Server, scps ...scopes.ScopeType) *invgo.Invgate {
+	return &invgo.Invgate{
+		BaseURL:      server.URL,
+		TokenURL:     server.URL + "/oauth/token",
+		ClientID:     "12345",
+		ClientSecret: "clientSecret",
+		AllowHTTP:    true,
+		Scopes:       scps,
+	}
+}
+
+func TestPool(t *testing.T) {
+	a := assert.New(t)
+
+	serverA := newInstanceServer(t, `[{"id":1},{"id":2}]`)
+	defer serverA.Close()
+	serverB := newInstanceServer(t, `[{"id":3}]`)
+	defer serverB.Close()
+
+	pool, err := invgo.NewPool(map[string]*invgo.Invgate{
+		"b": newInstanceConfig(serverB, scopes.IncidentsLastHourGet),
+		"a": newInstanceConfig(serverA, scopes.IncidentsLastHourGet),
+	})
+	a.NoError(err)
+	a.NoError(pool.Add("broken", newInstanceConfig(serverA)))
+	a.Error(pool.Add("a", newInstanceConfig(serverA)))
+	a.Equal([]string{"a", "b", "broken"}, pool.Names())
+
+	c1, err := pool.Client("a")
+	a.NoError(err)
+	c2, err := pool.Client("a")
+	a.NoError(err)
+	a.Same(c1, c2)
+
+	_, err = pool.Client("missing")
+	a.ErrorIs(err, invgo.ErrUnknownInstance)
+
+	results, err := invgo.PoolRun(pool, func(_ string, c *invgo.Client) ([]endpoints.IncidentsLastHourGetResponse, error) {
+		return c.IncidentsLastHour().Get(endpoints.IncidentsLastHourGetParams{})
+	})
+	var poolErr invgo.PoolError
+	a.True(errors.As(err, &poolErr))
+	a.Len(poolErr, 1)
+	a.Contains(poolErr, "broken")
+
+	a.Len(results, 2)
+	a.Equal("a", results[0].Instance)
+	a.Equal("b", results[1].Instance)
+
+	merged := invgo.PoolMerge(results)
+	a.Len(merged, 3)
+	a.Equal("a", merged[0].Instance)
+	a.Equal(1, merged[0].Value.ID)
+	a.Equal("b", merged[2].Instance)
+	a.Equal(3, merged[2].Value.ID)
+
+	pool.Remove("broken")
+	pool.Concurrency = 1
+	names, err := invgo.PoolRun(pool, func(name string, _ *invgo.Client) (string, error) {
+		if name == "b" {
+			panic("instance b")
+		}
+		return name, nil
+	})
+	a.ErrorContains(err, "b: panic: instance b")
+	a.Equal([]invgo.InstanceResult[string]{{Instance: "a", Value: "a"}}, names)
+}
+
+func TestPoolClientDoesNotBlock(t *testing.T) {
+	a := assert.New(t)
+
+	_, err := invgo.NewPool(map[string]*invgo.Invgate{"nil": nil})
+	a.Error(err)
+
+	fast := newInstanceServer(t, `[]`)
+	defer fast.Close()
+
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"token","token_type":"bearer","expires_in":3600}`))
+	}))
+	defer slow.Close()
+
+	// ValidateScopes requests a token while the client is created
+	slowCfg := newInstanceConfig(slow, scopes.IncidentsLastHourGet)
+	slowCfg.ValidateScopes = true
+
+	pool, err := invgo.NewPool(map[string]*invgo.Invgate{
+		"fast": newInstanceConfig(fast, scopes.IncidentsLastHourGet),
+		"slow": slowCfg,
+	})
+	a.NoError(err)
+
+	type created struct {
+		c   *invgo.Client
+		err error
+	}
+	slowDone := make(chan created, 2)
+	for range 2 {
+		go func() {
+			c, err := pool.Client("slow")
+			slowDone <- created{c, err}
+		}()
+	}
+
+	// the fast instance is created while the slow one is waiting on its token
+	fastDone := make(chan error, 1)
+	go func() {
+		_, err := pool.Client("fast")
+		fastDone <- err
+	}()
+	select {
+	case err := <-fastDone:
+		a.NoError(err)
+	case <-time.After(5 * time.Second):
+		a.Fail("creating a client blocked the pool")
+	}
+	select {
+	case <-slowDone:
+		a.Fail("slow client was created before its token was issued")
+	default:
+	}
+
+	close(release)
+	first, second := <-slowDone, <-slowDone
+	a.NoError(first.err)
+	a.NoError(second.err)
+	a.Same(first.c, second.c)
+}
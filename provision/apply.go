@@ -0,0 +1,137 @@
+package provision
+
+import (
+	"errors"
+	"time"
+
+	"github.com/tmstorm/invgo/endpoints"
+)
+
+// Apply makes the changes in plan. A change that fails does not stop the others and the
+// errors are returned joined as ChangeErrors. If Options.DryRun is set the changes are counted
+// without making them. Updates that only change groups are counted as unchanged when
+// Options.SetGroups is nil since they are not applied.
+func (s *Syncer) Apply(plan *Plan) (Result, error) {
+	var res Result
+	if s.opts.DryRun {
+		for _, c := range plan.Changes {
+			res.add(s.action(c))
+		}
+		return res, nil
+	}
+
+	var errs []error
+	for i := range plan.Changes {
+		c := &plan.Changes[i]
+		if err := s.apply(c); err != nil {
+			res.Failed++
+			errs = append(errs, &ChangeError{Change: *c, Err: err})
+			continue
+		}
+		res.add(s.action(*c))
+	}
+	return res, errors.Join(errs...)
+}
+
+// action returns the action Apply makes for c
+func (s *Syncer) action(c Change) Action {
+	if c.Action == ActionUpdate && len(c.Fields) == 0 && s.opts.SetGroups == nil {
+		return ActionUnchanged
+	}
+	return c.Action
+}
+
+// apply makes a single change. The ID of created users is set on c.
+func (s *Syncer) apply(c *Change) error {
+	base := c.User.UserBase
+	base.IsDisabled, base.IsDeleted = false, false
+
+	switch c.Action {
+	case ActionUnchanged:
+		return nil
+	case ActionCreate:
+		s.wait()
+		u, err := s.client.User().Post(endpoints.UserPostParams{
+			Email:    c.User.Email,
+			Name:     c.User.Name,
+			LastName: c.User.LastName,
+			UserBase: base,
+		})
+		if err != nil {
+			return err
+		}
+		c.UserID = u.ID
+
+		if s.opts.NotifyNew {
+			s.wait()
+			_, err = s.client.UserPasswordReset().Post(endpoints.UserPasswordResetPostParams{ID: c.UserID, Type: "NEW_USER"})
+			if err != nil {
+				return err
+			}
+		}
+		if c.User.IsDisabled {
+			s.wait()
+			if _, err := s.client.UserDisable().Put(endpoints.UserDisablePutParams{ID: c.UserID}); err != nil {
+				return err
+			}
+		}
+	default:
+		if len(c.Fields) > 0 {
+			s.wait()
+			_, err := s.client.User().Put(endpoints.UserPutParams{
+				ID:       c.UserID,
+				Email:    c.User.Email,
+				Name:     c.User.Name,
+				LastName: c.User.LastName,
+				UserBase: base,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		switch c.Action {
+		case ActionEnable:
+			s.wait()
+			if _, err := s.client.UserEnable().Put(endpoints.UserEnablePutParams{ID: c.UserID}); err != nil {
+				return err
+			}
+		case ActionDisable:
+			s.wait()
+			if _, err := s.client.UserDisable().Put(endpoints.UserDisablePutParams{ID: c.UserID}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if s.opts.SetGroups != nil && len(c.AddGroups)+len(c.RemoveGroups) > 0 {
+		s.wait()
+		return s.opts.SetGroups(c.UserID, c.AddGroups, c.RemoveGroups)
+	}
+	return nil
+}
+
+// wait sleeps until Options.Interval has passed since the last request
+func (s *Syncer) wait() {
+	if s.opts.Interval > 0 && !s.last.IsZero() {
+		if d := s.opts.Interval - time.Since(s.last); d > 0 {
+			time.Sleep(d)
+		}
+	}
+	s.last = time.Now()
+}
+
+func (r *Result) add(a Action) {
+	switch a {
+	case ActionCreate:
+		r.Created++
+	case ActionUpdate:
+		r.Updated++
+	case ActionEnable:
+		r.Enabled++
+	case ActionDisable:
+		r.Disabled++
+	case ActionUnchanged:
+		r.Unchanged++
+	}
+}
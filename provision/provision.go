@@ -0,0 +1,377 @@
+/*
+Package provision syncs users from another system such as an HR system into Invgate.
+
+A Syncer compares the desired users with the users in Invgate and returns a Plan of the users
+to create, update, enable or disable and the groups to add them to or remove them from.
+Users are matched on their email, username or employee number. Apply makes the changes in
+the plan one request at a time and returns how many users were changed.
+
+	s := provision.NewSyncer(client, provision.Options{DisableMissing: true})
+	plan, err := s.Plan(users)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range plan.Changes {
+		log.Printf("%s %s %v", c.Action, c.User.Email, c.Fields)
+	}
+
+	res, err := s.Apply(plan)
+	log.Printf("created %d, updated %d, disabled %d", res.Created, res.Updated, res.Disabled)
+
+Invgate has no endpoint to change group membership so membership is only changed if
+Options.SetGroups is set. Otherwise the group changes are only reported in the plan.
+*/
+package provision
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/endpoints"
+)
+
+// Match is a field users are matched on
+type Match string
+
+const (
+	MatchEmail          Match = "email"
+	MatchUsername       Match = "username"
+	MatchEmployeeNumber Match = "employee_number"
+)
+
+// Action is the change made to a user
+type Action string
+
+const (
+	ActionCreate    Action = "create"
+	ActionUpdate    Action = "update"
+	ActionEnable    Action = "enable"
+	ActionDisable   Action = "disable"
+	ActionUnchanged Action = "unchanged"
+)
+
+// DefaultMatch is the order fields are matched in when Options.Match is not set
+var DefaultMatch = []Match{MatchEmail, MatchUsername, MatchEmployeeNumber}
+
+// DefaultInterval is the time between requests made by Apply when Options.Interval is not set
+var DefaultInterval = 200 * time.Millisecond
+
+// groupsBatch is the amount of users requested from /users.groups at once
+const groupsBatch = 100
+
+type (
+	// User is the desired state of a user. Fields with a zero value are not compared or updated.
+	// Set IsDisabled to disable the user.
+	User struct {
+		Email    string
+		Name     string
+		LastName string
+		endpoints.UserBase
+		// Groups are the IDs of the groups the user should be a member of.
+		// If nil the user's membership is not reconciled.
+		Groups []int
+	}
+
+	// Options is used to configure a Syncer
+	Options struct {
+		// Match are the fields users are matched on in order. If empty DefaultMatch is used.
+		Match []Match
+		// DisableMissing disables users in Invgate that do not match a desired user
+		DisableMissing bool
+		// Managed limits the users disabled by DisableMissing. If nil every user can be disabled.
+		Managed func(endpoints.UserGetResponse) bool
+		// DryRun makes Apply return the counts of the plan without changing anything
+		DryRun bool
+		// Interval is the minimum time between requests made by Apply. If 0 DefaultInterval is used.
+		// Set it to a negative value to not wait between requests.
+		Interval time.Duration
+		// NotifyNew sends created users the NEW_USER password email
+		NotifyNew bool
+		// SetGroups adds and removes a user from groups. If nil group changes are not applied.
+		SetGroups func(userID int, add, remove []int) error
+	}
+
+	// Change is the change Apply makes to a user
+	Change struct {
+		Action Action
+		// UserID is 0 for users that are created
+		UserID int
+		// User is the desired user. It is empty for users disabled by DisableMissing.
+		User User
+		// Current is the user in Invgate. It is nil for users that are created.
+		Current *endpoints.UserGetResponse
+		// Fields are the JSON names of the fields that are updated
+		Fields       []string
+		AddGroups    []int
+		RemoveGroups []int
+	}
+
+	// Plan is the changes needed to sync the desired users
+	Plan struct {
+		Changes []Change
+	}
+
+	// Result is the amount of users changed by Apply
+	Result struct {
+		Created   int
+		Updated   int
+		Enabled   int
+		Disabled  int
+		Unchanged int
+		Failed    int
+	}
+
+	// ChangeError is returned by Apply when a change fails
+	ChangeError struct {
+		Change Change
+		Err    error
+	}
+
+	// Syncer plans and applies changes to users. Create it with NewSyncer.
+	//
+	// Requires scopes: UsersGet, UsersGroupsGet, UserPost, UserPut, UserDisablePut, UserEnablePut
+	// and UserPasswordResetPost if Options.NotifyNew is set
+	Syncer struct {
+		client *invgo.Client
+		opts   Options
+		last   time.Time
+	}
+)
+
+func (e *ChangeError) Error() string {
+	name := e.Change.User.Email
+	if name == "" && e.Change.Current != nil {
+		name = e.Change.Current.Email
+	}
+	return fmt.Sprintf("unable to %s user %s (id: %d): %v", e.Change.Action, name, e.Change.UserID, e.Err)
+}
+
+func (e *ChangeError) Unwrap() error { return e.Err }
+
+// Counts returns the amount of users each action is made to
+func (p *Plan) Counts() Result {
+	var r Result
+	for _, c := range p.Changes {
+		r.add(c.Action)
+	}
+	return r
+}
+
+// NewSyncer creates a Syncer for client
+func NewSyncer(client *invgo.Client, opts Options) *Syncer {
+	if len(opts.Match) == 0 {
+		opts.Match = DefaultMatch
+	}
+	if opts.Interval == 0 {
+		opts.Interval = DefaultInterval
+	}
+	return &Syncer{client: client, opts: opts}
+}
+
+// Sync plans and applies the changes needed to sync desired
+func (s *Syncer) Sync(desired []User) (Result, error) {
+	plan, err := s.Plan(desired)
+	if err != nil {
+		return Result{}, err
+	}
+	return s.Apply(plan)
+}
+
+// Plan compares desired with the users in Invgate and returns the changes needed to sync them.
+// An error is returned if a desired user has no field to match on, can not be created or
+// matches the same user as another desired user.
+func (s *Syncer) Plan(desired []User) (*Plan, error) {
+	current, err := s.client.Users().Get(endpoints.UsersGetParams{IncludeDisabled: true})
+	if err != nil {
+		return nil, err
+	}
+
+	index := map[Match]map[string]*endpoints.UserGetResponse{}
+	for _, m := range s.opts.Match {
+		index[m] = map[string]*endpoints.UserGetResponse{}
+	}
+	for i := range current {
+		u := &current[i].UserGetResponse
+		if u.IsDeleted {
+			continue
+		}
+		for _, m := range s.opts.Match {
+			if k := key(m, u.Email, u.UserBase); k != "" {
+				index[m][k] = u
+			}
+		}
+	}
+
+	plan := &Plan{}
+	matched := map[int]int{}
+	keys := map[string]int{}
+	for i, d := range desired {
+		var cur *endpoints.UserGetResponse
+		found := false
+		for _, m := range s.opts.Match {
+			k := key(m, d.Email, d.UserBase)
+			if k == "" {
+				continue
+			}
+			found = true
+			if prev, dup := keys[string(m)+":"+k]; dup {
+				return nil, fmt.Errorf("users %d and %d have the same %s %s", prev, i, m, k)
+			}
+			keys[string(m)+":"+k] = i
+			if cur == nil {
+				cur = index[m][k]
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("user %d has no %v to match on", i, s.opts.Match)
+		}
+
+		if cur == nil {
+			if d.Email == "" || d.Name == "" || d.LastName == "" {
+				return nil, fmt.Errorf("user %d (%s) can not be created without an email, name and last name", i, d.Email)
+			}
+			c := Change{Action: ActionCreate, User: d, AddGroups: sorted(d.Groups)}
+			plan.Changes = append(plan.Changes, c)
+			continue
+		}
+
+		if prev, dup := matched[cur.ID]; dup {
+			return nil, fmt.Errorf("users %d and %d both match user %d", prev, i, cur.ID)
+		}
+		matched[cur.ID] = i
+		plan.Changes = append(plan.Changes, change(d, cur))
+	}
+
+	if err := s.planGroups(plan); err != nil {
+		return nil, err
+	}
+
+	if s.opts.DisableMissing {
+		for i := range current {
+			u := &current[i].UserGetResponse
+			if _, ok := matched[u.ID]; ok || u.IsDeleted || u.IsDisabled {
+				continue
+			}
+			if s.opts.Managed != nil && !s.opts.Managed(*u) {
+				continue
+			}
+			plan.Changes = append(plan.Changes, Change{Action: ActionDisable, UserID: u.ID, Current: u})
+		}
+	}
+	return plan, nil
+}
+
+// planGroups adds the group changes of matched users with desired groups
+func (s *Syncer) planGroups(plan *Plan) error {
+	var ids []int
+	for _, c := range plan.Changes {
+		if c.Current != nil && c.User.Groups != nil {
+			ids = append(ids, c.UserID)
+		}
+	}
+
+	groups := map[int][]int{}
+	for batch := range slices.Chunk(ids, groupsBatch) {
+		resp, err := s.client.UsersGroups().Get(endpoints.UsersGroupsGetParams{IDs: batch})
+		if err != nil {
+			return err
+		}
+		for _, r := range resp {
+			for id := range r.Groups {
+				groups[r.ID] = append(groups[r.ID], id)
+			}
+		}
+	}
+
+	for i := range plan.Changes {
+		c := &plan.Changes[i]
+		if c.Current == nil || c.User.Groups == nil {
+			continue
+		}
+		have := groups[c.UserID]
+		for _, g := range sorted(c.User.Groups) {
+			if !slices.Contains(have, g) {
+				c.AddGroups = append(c.AddGroups, g)
+			}
+		}
+		for _, g := range sorted(have) {
+			if !slices.Contains(c.User.Groups, g) {
+				c.RemoveGroups = append(c.RemoveGroups, g)
+			}
+		}
+		if c.Action == ActionUnchanged && len(c.AddGroups)+len(c.RemoveGroups) > 0 {
+			c.Action = ActionUpdate
+		}
+	}
+	return nil
+}
+
+// change returns the change needed to make cur match d
+func change(d User, cur *endpoints.UserGetResponse) Change {
+	c := Change{Action: ActionUnchanged, UserID: cur.ID, User: d, Current: cur, Fields: diff(d, *cur)}
+	switch {
+	case d.IsDisabled && !cur.IsDisabled:
+		c.Action = ActionDisable
+	case !d.IsDisabled && cur.IsDisabled:
+		c.Action = ActionEnable
+	case len(c.Fields) > 0:
+		c.Action = ActionUpdate
+	}
+	return c
+}
+
+// diff returns the JSON names of the non zero fields of d that are different in cur.
+// IsDisabled and IsDeleted are not compared.
+func diff(d User, cur endpoints.UserGetResponse) []string {
+	var fields []string
+	if d.Email != "" && !strings.EqualFold(d.Email, cur.Email) {
+		fields = append(fields, "email")
+	}
+	if d.Name != "" && d.Name != cur.Name {
+		fields = append(fields, "name")
+	}
+	if d.LastName != "" && d.LastName != cur.LastName {
+		fields = append(fields, "lastname")
+	}
+
+	dv, cv := reflect.ValueOf(d.UserBase), reflect.ValueOf(cur.UserBase)
+	t := dv.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if f.Name == "IsDisabled" || f.Name == "IsDeleted" || dv.Field(i).IsZero() {
+			continue
+		}
+		if !dv.Field(i).Equal(cv.Field(i)) {
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// key returns the normalized value of a user's match field
+func key(m Match, email string, b endpoints.UserBase) string {
+	switch m {
+	case MatchEmail:
+		return strings.ToLower(strings.TrimSpace(email))
+	case MatchUsername:
+		return strings.ToLower(strings.TrimSpace(b.UserName))
+	case MatchEmployeeNumber:
+		return strings.TrimSpace(b.EmployeeNumber)
+	}
+	return ""
+}
+
+func sorted(ids []int) []int {
+	if len(ids) == 0 {
+		return nil
+	}
+	ids = slices.Clone(ids)
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
@@ -0,0 +1,199 @@
+package provision_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmstorm/invgo/endpoints"
+	"github.com/tmstorm/invgo/invgotest"
+	"github.com/tmstorm/invgo/provision"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+func newSyncer(t *testing.T, srv *invgotest.Server, opts provision.Options) *provision.Syncer {
+	t.Helper()
+	opts.Interval = -1
+	c := srv.Client(t,
+		scopes.UsersGet,
+		scopes.UsersGroupsGet,
+		scopes.UserPost,
+		scopes.UserPut,
+		scopes.UserDisablePut,
+		scopes.UserEnablePut,
+		scopes.UserPasswordResetPost,
+	)
+	return provision.NewSyncer(c, opts)
+}
+
+func TestSync(t *testing.T) {
+	a := assert.New(t)
+
+	srv := invgotest.NewServer(t)
+	same := srv.AddUser(endpoints.UserGetResponse{Email: "same@example.com", Name: "Same", LastName: "User"})
+	moved := srv.AddUser(endpoints.UserGetResponse{
+		Email:    "moved@example.com",
+		Name:     "Moved",
+		LastName: "User",
+		UserBase: endpoints.UserBase{EmployeeNumber: "E2", Department: "Sales"},
+	})
+	back := srv.AddUser(endpoints.UserGetResponse{Email: "back@example.com", Name: "Back", LastName: "User", UserBase: endpoints.UserBase{IsDisabled: true}})
+	left := srv.AddUser(endpoints.UserGetResponse{Email: "left@example.com", Name: "Left", LastName: "User"})
+	admin := srv.AddUser(endpoints.UserGetResponse{Email: "admin@example.com", Name: "Admin", LastName: "User"})
+	sales := srv.AddHelpDesk(endpoints.HelpDesksGetResponse{Name: "Sales"}, moved)
+	support := srv.AddHelpDesk(endpoints.HelpDesksGetResponse{Name: "Support"})
+
+	desired := []provision.User{
+		{Email: "SAME@example.com"},
+		// matched on employee number
+		{Email: "mover@example.com", UserBase: endpoints.UserBase{EmployeeNumber: "E2", Department: "Support"}, Groups: []int{support}},
+		{Email: "back@example.com"},
+		{Email: "new@example.com", Name: "New", LastName: "User", Groups: []int{support}},
+	}
+
+	var groupCalls [][]int
+	s := newSyncer(t, srv, provision.Options{
+		DisableMissing: true,
+		Managed:        func(u endpoints.UserGetResponse) bool { return u.ID != admin },
+		NotifyNew:      true,
+		SetGroups: func(id int, add, remove []int) error {
+			groupCalls = append(groupCalls, []int{id, len(add), len(remove)})
+			return nil
+		},
+	})
+
+	plan, err := s.Plan(desired)
+	if !a.NoError(err) || !a.Len(plan.Changes, 5) {
+		return
+	}
+	a.Equal(provision.ActionUnchanged, plan.Changes[0].Action)
+	a.Equal(same, plan.Changes[0].UserID)
+
+	c := plan.Changes[1]
+	a.Equal(provision.ActionUpdate, c.Action)
+	a.Equal(moved, c.UserID)
+	a.Equal([]string{"email", "department"}, c.Fields)
+	a.Equal([]int{support}, c.AddGroups)
+	a.Equal([]int{sales}, c.RemoveGroups)
+
+	a.Equal(provision.ActionEnable, plan.Changes[2].Action)
+	a.Equal(provision.ActionCreate, plan.Changes[3].Action)
+	a.Equal(provision.ActionDisable, plan.Changes[4].Action)
+	a.Equal(left, plan.Changes[4].UserID)
+	a.Equal(provision.Result{Created: 1, Updated: 1, Enabled: 1, Disabled: 1, Unchanged: 1}, plan.Counts())
+
+	res, err := s.Apply(plan)
+	a.NoError(err)
+	a.Equal(plan.Counts(), res)
+
+	u, _ := srv.User(moved)
+	a.Equal("mover@example.com", u.Email)
+	a.Equal("Support", u.Department)
+	u, _ = srv.User(back)
+	a.False(u.IsDisabled)
+	u, _ = srv.User(left)
+	a.True(u.IsDisabled)
+	u, _ = srv.User(admin)
+	a.False(u.IsDisabled)
+	a.NotZero(plan.Changes[3].UserID)
+	a.Equal([][]int{{moved, 1, 1}, {plan.Changes[3].UserID, 1, 0}}, groupCalls)
+
+	// the fake SetGroups left the membership unchanged and the created user is no longer desired
+	s = newSyncer(t, srv, provision.Options{DisableMissing: true, Managed: func(u endpoints.UserGetResponse) bool { return u.ID != admin }})
+	res, err = s.Sync(desired[:3])
+	a.NoError(err)
+	a.Equal(provision.Result{Disabled: 1, Unchanged: 3}, res)
+}
+
+func TestApplyGroupsOnly(t *testing.T) {
+	a := assert.New(t)
+
+	srv := invgotest.NewServer(t)
+	id := srv.AddUser(endpoints.UserGetResponse{Email: "user@example.com", Name: "User", LastName: "One"})
+	desk := srv.AddHelpDesk(endpoints.HelpDesksGetResponse{Name: "Support"})
+	desired := []provision.User{{Email: "user@example.com", Groups: []int{desk}}}
+
+	// the group change is planned but not applied without SetGroups
+	s := newSyncer(t, srv, provision.Options{})
+	plan, err := s.Plan(desired)
+	if !a.NoError(err) || !a.Len(plan.Changes, 1) {
+		return
+	}
+	a.Equal(provision.ActionUpdate, plan.Changes[0].Action)
+	a.Equal([]int{desk}, plan.Changes[0].AddGroups)
+
+	requests := len(srv.Requests())
+	res, err := s.Apply(plan)
+	a.NoError(err)
+	a.Equal(provision.Result{Unchanged: 1}, res)
+	a.Len(srv.Requests(), requests)
+
+	dry := newSyncer(t, srv, provision.Options{DryRun: true})
+	res, err = dry.Apply(plan)
+	a.NoError(err)
+	a.Equal(provision.Result{Unchanged: 1}, res)
+
+	var calls []int
+	s = newSyncer(t, srv, provision.Options{SetGroups: func(userID int, add, remove []int) error {
+		calls = append(calls, userID)
+		return nil
+	}})
+	res, err = s.Apply(plan)
+	a.NoError(err)
+	a.Equal(provision.Result{Updated: 1}, res)
+	a.Equal([]int{id}, calls)
+}
+
+func TestDryRun(t *testing.T) {
+	a := assert.New(t)
+
+	srv := invgotest.NewServer(t)
+	id := srv.AddUser(endpoints.UserGetResponse{Email: "user@example.com", Name: "User", LastName: "One"})
+
+	s := newSyncer(t, srv, provision.Options{DryRun: true})
+	res, err := s.Sync([]provision.User{{Email: "user@example.com", Name: "Renamed"}})
+	a.NoError(err)
+	a.Equal(provision.Result{Updated: 1}, res)
+
+	u, _ := srv.User(id)
+	a.Equal("User", u.Name)
+}
+
+func TestPlanErrors(t *testing.T) {
+	a := assert.New(t)
+
+	srv := invgotest.NewServer(t)
+	srv.AddUser(endpoints.UserGetResponse{Email: "a@example.com", UserBase: endpoints.UserBase{UserName: "a"}})
+	s := newSyncer(t, srv, provision.Options{})
+
+	_, err := s.Plan([]provision.User{{Name: "No key"}})
+	a.Error(err)
+
+	_, err = s.Plan([]provision.User{{Email: "new@example.com"}})
+	a.ErrorContains(err, "can not be created")
+
+	_, err = s.Plan([]provision.User{{Email: "a@example.com"}, {Email: "A@example.com"}})
+	a.ErrorContains(err, "same email")
+
+	_, err = s.Plan([]provision.User{{Email: "a@example.com"}, {UserBase: endpoints.UserBase{UserName: "a"}}})
+	a.ErrorContains(err, "both match")
+}
+
+func TestApplyErrors(t *testing.T) {
+	a := assert.New(t)
+
+	srv := invgotest.NewServer(t)
+	s := newSyncer(t, srv, provision.Options{})
+
+	plan := &provision.Plan{Changes: []provision.Change{
+		{Action: provision.ActionDisable, UserID: 404},
+		{Action: provision.ActionUnchanged, UserID: 1},
+	}}
+	res, err := s.Apply(plan)
+	a.Equal(provision.Result{Unchanged: 1, Failed: 1}, res)
+
+	var ce *provision.ChangeError
+	if a.True(errors.As(err, &ce)) {
+		a.Equal(404, ce.Change.UserID)
+	}
+}
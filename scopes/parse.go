@@ -0,0 +1,190 @@
+package scopes
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// ErrUnknownScope is returned when a scope is well formed but is not known to Invgo
+var ErrUnknownScope = errors.New("unknown scope")
+
+// Scope is a parsed ScopeType split into its parts.
+//
+// For example api.v1.incident.attributes.status:get is parsed as:
+// Version = v1, Resource = incident.attributes.status, Method = get
+type Scope struct {
+	Version  string
+	Resource string
+	Method   string
+}
+
+// unregistered are scopes defined in scopes.go that do not yet have an implemented endpoint
+var unregistered = []ScopeType{
+	HelpDesksObserversGet,
+	HelpDesksObserversPost,
+	HelpDesksObserversDelete,
+	HelpDesksAndLevelsGet,
+}
+
+// Parse parses a scope string into its parts.
+// An error wrapping ErrUnknownScope is returned if the scope is well formed but not
+// defined in this package, this catches typos in scopes loaded from config files.
+func Parse(raw string) (Scope, error) {
+	s, err := parse(raw)
+	if err != nil {
+		return Scope{}, err
+	}
+	if !Known(s.ScopeType()) {
+		return Scope{}, fmt.Errorf("%w: %s", ErrUnknownScope, raw)
+	}
+	return s, nil
+}
+
+// parse splits raw into a Scope without checking if it is known
+func parse(raw string) (Scope, error) {
+	raw = strings.TrimSpace(raw)
+
+	rest, method, ok := strings.Cut(raw, ":")
+	if !ok || method == "" {
+		return Scope{}, fmt.Errorf("scope %q is missing a method", raw)
+	}
+
+	parts := strings.SplitN(rest, ".", 3)
+	if len(parts) != 3 || parts[0] != "api" || parts[1] == "" || parts[2] == "" {
+		return Scope{}, fmt.Errorf("scope %q must be in the format api.{version}.{resource}:{method}", raw)
+	}
+
+	return Scope{
+		Version:  parts[1],
+		Resource: parts[2],
+		Method:   strings.ToLower(method),
+	}, nil
+}
+
+// ScopeType returns the Scope as a ScopeType
+func (s Scope) ScopeType() ScopeType {
+	return ScopeType("api." + s.Version + "." + s.Resource + ":" + s.Method)
+}
+
+// String returns the Scope in the format api.{version}.{resource}:{method}
+func (s Scope) String() string { return string(s.ScopeType()) }
+
+// Path returns the endpoint path of the scope e.g. /incident.attributes.status
+func (s Scope) Path() string { return "/" + s.Resource }
+
+// Known returns true if s is defined in this package
+func Known(s ScopeType) bool {
+	if slices.Contains(unregistered, s) {
+		return true
+	}
+	for _, r := range registry {
+		if r.Scope == s {
+			return true
+		}
+	}
+	return false
+}
+
+// All returns every scope defined in this package
+func All() Set {
+	set := NewSet(unregistered...)
+	for _, r := range registry {
+		set.Add(r.Scope)
+	}
+	return set
+}
+
+/*
+Match returns every known scope matching pattern.
+
+The pattern matches the resource of a scope using path.Match syntax and may include a method.
+A leading slash or api.{version}. prefix is ignored.
+
+Example:
+
+	scopes.Match("incident.*")      // every scope for /incident.* endpoints
+	scopes.Match("/incidents*:get") // every GET scope for /incidents and /incidents.*
+*/
+func Match(pattern string) (Set, error) {
+	resource, method, _ := strings.Cut(strings.TrimSpace(pattern), ":")
+	resource = strings.TrimPrefix(resource, "/")
+	if strings.HasPrefix(resource, "api.") {
+		if parts := strings.SplitN(resource, ".", 3); len(parts) == 3 {
+			resource = parts[2]
+		}
+	}
+
+	if _, err := path.Match(resource, ""); err != nil {
+		return nil, fmt.Errorf("invalid scope pattern %q: %w", pattern, err)
+	}
+
+	set := Set{}
+	for s := range All() {
+		p, err := parse(string(s))
+		if err != nil {
+			continue
+		}
+		if method != "" && !strings.EqualFold(method, p.Method) {
+			continue
+		}
+		if ok, _ := path.Match(resource, p.Resource); ok {
+			set.Add(s)
+		}
+	}
+	return set, nil
+}
+
+/*
+Resolve converts human friendly scope names from configuration into scopes.
+
+Each name may be a scope (api.v1.incident:get), a bundle defined in Bundles (incident.read)
+or a pattern accepted by Match (incident.*:get). Duplicate scopes are only returned once.
+*/
+func Resolve(names ...string) ([]ScopeType, error) {
+	var scps []ScopeType
+	add := func(s ...ScopeType) {
+		for _, v := range s {
+			if !slices.Contains(scps, v) {
+				scps = append(scps, v)
+			}
+		}
+	}
+
+	var errs []error
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		if bundle, ok := Bundles[name]; ok {
+			add(bundle...)
+			continue
+		}
+
+		if strings.ContainsAny(name, "*?[") {
+			set, err := Match(name)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if len(set) == 0 {
+				errs = append(errs, fmt.Errorf("scope pattern %q did not match any scopes", name))
+				continue
+			}
+			add(set.Slice()...)
+			continue
+		}
+
+		s, err := Parse(name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		add(s.ScopeType())
+	}
+	return scps, errors.Join(errs...)
+}
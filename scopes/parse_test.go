@@ -0,0 +1,112 @@
+package scopes_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+func TestParse(t *testing.T) {
+	a := assert.New(t)
+
+	s, err := scopes.Parse("api.v1.incident.attributes.status:get")
+	a.NoError(err)
+	a.Equal(scopes.Scope{Version: "v1", Resource: "incident.attributes.status", Method: "get"}, s)
+	a.Equal(scopes.IncidentAttributesStatusGet, s.ScopeType())
+	a.Equal("/incident.attributes.status", s.Path())
+
+	s, err = scopes.Parse("api.v1.helpdesks.observers:delete")
+	a.NoError(err)
+	a.Equal(scopes.HelpDesksObserversDelete, s.ScopeType())
+
+	_, err = scopes.Parse("api.v1.incident.attributes.stauts:get")
+	a.ErrorIs(err, scopes.ErrUnknownScope)
+
+	for _, raw := range []string{"", "api.v1.incident", "incident:get", "api.v1:get", "api..incident:get"} {
+		_, err = scopes.Parse(raw)
+		a.Error(err, raw)
+		a.NotErrorIs(err, scopes.ErrUnknownScope, raw)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	a := assert.New(t)
+
+	set, err := scopes.Match("incident.attributes.*:get")
+	a.NoError(err)
+	a.True(set.Contains(scopes.IncidentAttributesStatusGet))
+	a.True(set.Contains(scopes.IncidentAttributesPriorityGet))
+	a.False(set.Contains(scopes.IncidentGet))
+
+	set, err = scopes.Match("/incident:post")
+	a.NoError(err)
+	a.Equal([]scopes.ScopeType{scopes.IncidentPost}, set.Slice())
+
+	set, err = scopes.Match("api.v1.incident.*")
+	a.NoError(err)
+	a.True(set.Contains(scopes.IncidentCommentPost))
+
+	_, err = scopes.Match("incident.[")
+	a.Error(err)
+}
+
+func TestSet(t *testing.T) {
+	a := assert.New(t)
+
+	s1 := scopes.NewSet(scopes.IncidentGet, scopes.IncidentPost)
+	s2 := scopes.NewSet(scopes.IncidentPost, scopes.UsersGet)
+
+	a.Equal([]scopes.ScopeType{scopes.IncidentGet, scopes.IncidentPost, scopes.UsersGet}, s1.Union(s2).Slice())
+	a.Equal([]scopes.ScopeType{scopes.IncidentGet}, s1.Diff(s2).Slice())
+	a.Equal([]scopes.ScopeType{scopes.IncidentPost}, s1.Intersect(s2).Slice())
+
+	a.True(s2.ContainsPrefix("users"))
+	a.True(s2.ContainsPrefix("api.v1.users"))
+	a.False(s1.ContainsPrefix("users"))
+}
+
+func TestBundles(t *testing.T) {
+	a := assert.New(t)
+
+	for name, scps := range scopes.Bundles {
+		a.NotEmpty(scps, name)
+		for _, s := range scps {
+			a.True(scopes.Known(s), "%s: %s", name, s)
+		}
+	}
+
+	read := scopes.NewSet(scopes.Bundles["incident.read"]...)
+	a.True(read.Contains(scopes.IncidentGet))
+	a.True(read.Contains(scopes.IncidentCommentGet))
+	a.False(read.Contains(scopes.IncidentPost))
+
+	admin := scopes.NewSet(scopes.Bundles["user.admin"]...)
+	a.True(admin.Contains(scopes.UsersGet))
+	a.True(admin.Contains(scopes.UserPost))
+}
+
+func TestResolve(t *testing.T) {
+	a := assert.New(t)
+
+	scps, err := scopes.Resolve("api.v1.incident:get", "incident.read", "users:get")
+	a.Error(err) // users:get is missing the api.{version} prefix
+	a.Contains(scps, scopes.IncidentGet)
+	a.Contains(scps, scopes.IncidentCommentGet)
+
+	scps, err = scopes.Resolve("incident:get", " ", "api.v1.incident:get")
+	a.Error(err)
+	a.Equal([]scopes.ScopeType{scopes.IncidentGet}, scps)
+
+	scps, err = scopes.Resolve("users*:get", "api.v1.users:get")
+	a.NoError(err)
+	a.Contains(scps, scopes.UsersGet)
+	seen := map[scopes.ScopeType]bool{}
+	for _, s := range scps {
+		a.False(seen[s], "duplicate scope %s", s)
+		seen[s] = true
+	}
+
+	_, err = scopes.Resolve("nothing.matches.*")
+	a.Error(err)
+}
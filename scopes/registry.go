@@ -0,0 +1,237 @@
+package scopes
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+type (
+	// Endpoint identifies a single method of an Invgate endpoint
+	Endpoint struct {
+		// Path of the endpoint relative to the API path e.g. /incident
+		Path string
+		// Method is the HTTP method e.g. GET
+		Method string
+	}
+
+	// Registration maps an implemented endpoint method to its scope and the
+	// endpoints method that calls it.
+	Registration struct {
+		Endpoint
+		// Accessor is the endpoints type and method that implement the endpoint e.g. IncidentMethods.Get
+		Accessor string
+		// Scope is the scope Invgate requires to call the endpoint
+		Scope ScopeType
+	}
+)
+
+// registry holds every endpoint implemented by Invgo along with its required scope.
+// It is the only place the scope of an endpoint is defined, the client looks up the scope
+// of every request here before it is sent.
+// When adding a new endpoint it must be registered here so its scope can be looked up
+// and the coverage script knows it has been implemented.
+var registry = []Registration{
+	{Endpoint{"/breakingnews", http.MethodPost}, "BreakingNewsMethods.Post", BreakingNewsPost},
+	{Endpoint{"/breakingnews", http.MethodPut}, "BreakingNewsMethods.Put", BreakingNewsPut},
+	{Endpoint{"/breakingnews", http.MethodGet}, "BreakingNewsMethods.Get", BreakingNewsGet},
+	{Endpoint{"/breakingnews.all", http.MethodGet}, "BreakingNewsAllMethods.Get", BreakingNewsAll},
+	{Endpoint{"/breakingnews.attributes.status", http.MethodGet}, "AttributesMethods.Get", BreakingNewsAttributesStatus},
+	{Endpoint{"/breakingnews.attributes.type", http.MethodGet}, "AttributesMethods.Get", BreakingNewsAttributesType},
+	{Endpoint{"/breakingnews.status", http.MethodPost}, "BreakingNewsStatusMethods.Post", BreakingNewsStatusPost},
+	{Endpoint{"/breakingnews.status", http.MethodGet}, "BreakingNewsStatusMethods.Get", BreakingNewsStatusGet},
+	{Endpoint{"/categories", http.MethodGet}, "CategoriesMethods.Get", CategoriesGet},
+	{Endpoint{"/cf.fields.by.category", http.MethodGet}, "CustomFieldsByCategoryMethods.Get", CustomFieldsByCategoryGet},
+	{Endpoint{"/helpdesks", http.MethodGet}, "HelpDesksMethods.Get", HelpDesksGet},
+	{Endpoint{"/incident", http.MethodPost}, "IncidentMethods.Post", IncidentPost},
+	{Endpoint{"/incident", http.MethodPut}, "IncidentMethods.Put", IncidentPut},
+	{Endpoint{"/incident", http.MethodGet}, "IncidentMethods.Get", IncidentGet},
+	{Endpoint{"/incident.approval", http.MethodGet}, "IncidentApprovalMethods.Get", IncidentApprovalGet},
+	{Endpoint{"/incident.approval.accept", http.MethodPut}, "IncidentApprovalAcceptMethods.Put", IncidentApprovalAcceptPut},
+	{Endpoint{"/incident.approval.add_voter", http.MethodPost}, "IncidentApprovalAddVoterMethods.Post", IncidentApprovalAddVoterPost},
+	{Endpoint{"/incident.approval.cancel", http.MethodPut}, "IncidentApprovalCancelMethods.Put", IncidentApprovalCancelPut},
+	{Endpoint{"/incident.approval.possible_voters", http.MethodGet}, "IncidentApprovalPossibleVotersMethods.Get", IncidentApprovalPossibleVotersGet},
+	{Endpoint{"/incident.approval.reject", http.MethodPut}, "IncidentApprovalRejectMethods.Put", IncidentApprovalRejectPut},
+	{Endpoint{"/incident.approval.status", http.MethodGet}, "IncidentApprovalStatusMethods.Get", IncidentApprovalStatusGet},
+	{Endpoint{"/incident.approval.type", http.MethodGet}, "IncidentApprovalTypeMethods.Get", IncidentApprovalTypeGet},
+	{Endpoint{"/incident.approval.vote_status", http.MethodGet}, "IncidentApprovalVoteStatusMethods.Get", IncidentApprovalVoteStatusGet},
+	{Endpoint{"/incident.attachment", http.MethodGet}, "IncidentAttachmentMethods.Get", IncidentAttachmentGet},
+	{Endpoint{"/incident.attributes.priority", http.MethodGet}, "AttributesMethods.Get", IncidentAttributesPriorityGet},
+	{Endpoint{"/incident.attributes.source", http.MethodGet}, "AttributesMethods.Get", IncidentAttributesSourceGet},
+	{Endpoint{"/incident.attributes.status", http.MethodGet}, "AttributesMethods.Get", IncidentAttributesStatusGet},
+	{Endpoint{"/incident.attributes.type", http.MethodGet}, "AttributesMethods.Get", IncidentAttributesTypeGet},
+	{Endpoint{"/incident.cancel", http.MethodPost}, "IncidentCancelMethods.Post", IncidentCancelPost},
+	{Endpoint{"/incident.collaborator", http.MethodPost}, "IncidentCollaboratorMethods.Post", IncidentCollaboratorPost},
+	{Endpoint{"/incident.collaborator", http.MethodGet}, "IncidentCollaboratorMethods.Get", IncidentCollaboratorGet},
+	{Endpoint{"/incident.comment", http.MethodPost}, "IncidentCommentMethods.Post", IncidentCommentPost},
+	{Endpoint{"/incident.comment", http.MethodGet}, "IncidentCommentMethods.Get", IncidentCommentGet},
+	{Endpoint{"/incident.custom_approval", http.MethodPost}, "IncidentCustomApprovalMethods.Post", IncidentCustomApprovalPost},
+	{Endpoint{"/incident.custom_approval", http.MethodGet}, "IncidentCustomApprovalMethods.Get", IncidentCustomApprovalGet},
+	{Endpoint{"/incident.external_entity", http.MethodPost}, "IncidentExternalEntityMethods.Post", IncidentExternalEntityPost},
+	{Endpoint{"/incident.external_entity", http.MethodGet}, "IncidentExternalEntityMethods.Get", IncidentExternalEntityGet},
+	{Endpoint{"/incident.link", http.MethodPost}, "IncidentLinkMethods.Post", IncidentLinkPost},
+	{Endpoint{"/incident.link", http.MethodGet}, "IncidentLinkMethods.Get", IncidentLinkGet},
+	{Endpoint{"/incident.linked_cis.counters.from", http.MethodGet}, "IncidentLinkedCIsCountersFromMethods.Get", IncidentLinkedCIsCountersFromGet},
+	{Endpoint{"/incident.observer", http.MethodPost}, "IncidentObserverMethods.Post", IncidentObserverPost},
+	{Endpoint{"/incident.observer", http.MethodGet}, "IncidentObserverMethods.Get", IncidentObserverGet},
+	{Endpoint{"/incident.reassign", http.MethodPost}, "IncidentReassignMethods.Post", IncidentReassignPost},
+	{Endpoint{"/incident.reject", http.MethodPost}, "IncidentRejectMethods.Post", IncidentRejectPost},
+	{Endpoint{"/incident.reopen", http.MethodPut}, "IncidentReopenMethods.Put", IncidentReopenPut},
+	{Endpoint{"/incident.solution.accept", http.MethodPut}, "IncidentSolutionAcceptMethods.Put", IncidentSolutionAcceptPut},
+	{Endpoint{"/incident.solution.reject", http.MethodPut}, "IncidentSolutionRejectMethods.Put", IncidentSolutionRejectPut},
+	{Endpoint{"/incident.spontaneous_approval", http.MethodPost}, "IncidentSpontaneousApprovalMethods.Post", IncidentSpontaneousApprovalPost},
+	{Endpoint{"/incident.tasks", http.MethodGet}, "IncidentTasksMethods.Get", IncidentTasksGet},
+	{Endpoint{"/incident.waitingfor.agent", http.MethodPost}, "IncidentWaitingForAgentMethods.Post", IncidentWaitingForAgentPost},
+	{Endpoint{"/incident.waitingfor.customer", http.MethodPost}, "IncidentWaitingForCustomerMethods.Post", IncidentWaitingForCustomerPost},
+	{Endpoint{"/incident.waitingfor.date", http.MethodPost}, "IncidentWaitingForDateMethods.Post", IncidentWaitingForDatePost},
+	{Endpoint{"/incident.waitingfor.external_entity", http.MethodPost}, "IncidentWaitingForExternalEntityMethods.Post", IncidentWaitingForExternalEntityPost},
+	{Endpoint{"/incident.waitingfor.incident", http.MethodPost}, "IncidentWaitingForIncidentMethods.Post", IncidentWaitingForIncidentPost},
+	{Endpoint{"/incidents", http.MethodGet}, "IncidentsMethods.Get", IncidentsGet},
+	{Endpoint{"/incidents.by.agent", http.MethodGet}, "IncidentsByAgentMethods.Get", IncidentsByAgentGet},
+	{Endpoint{"/incidents.by.cis", http.MethodGet}, "IncidentsByCIsMethods.Get", IncidentsByCIsGet},
+	{Endpoint{"/incidents.by.customer", http.MethodGet}, "IncidentsByCustomerMethods.Get", IncidentsByCustomerGet},
+	{Endpoint{"/incidents.by.helpdesk", http.MethodGet}, "IncidentsByHelpDeskMethods.Get", IncidentsByHelpDeskGet},
+	{Endpoint{"/incidents.by.sentiment", http.MethodGet}, "IncidentsBySentimentMethods.Get", IncidentsBySentimentGet},
+	{Endpoint{"/incidents.by.status", http.MethodGet}, "IncidentsByStatusMethods.Get", IncidentsByStatusGet},
+	{Endpoint{"/incidents.by.view", http.MethodGet}, "IncidentsByViewMethods.Get", IncidentsByViewGet},
+	{Endpoint{"/incidents.details.by.view", http.MethodGet}, "IncidentsDetailsByViewMethods.Get", IncidentsDetailsByViewGet},
+	{Endpoint{"/incidents.last.hour", http.MethodGet}, "IncidentsLastHourMethods.Get", IncidentsLastHourGet},
+	{Endpoint{"/sd.version", http.MethodGet}, "ServiceDeskVersionMethods.Get", ServiceDeskVersionGet},
+	{Endpoint{"/timetracking", http.MethodGet}, "TimeTrackingMethods.Get", TimeTrackingGet},
+	{Endpoint{"/timetracking", http.MethodPost}, "TimeTrackingMethods.Post", TimeTrackingPost},
+	{Endpoint{"/timetracking", http.MethodDelete}, "TimeTrackingMethods.Delete", TimeTrackingDelete},
+	{Endpoint{"/timetracking.attributes.category", http.MethodGet}, "TimeTrackingAttributesCategoryMethods.Get", TimeTrackingAttributesCategoryGet},
+	{Endpoint{"/triggers", http.MethodGet}, "TriggersMethods.Get", TriggersGet},
+	{Endpoint{"/triggers.executions", http.MethodGet}, "TriggersExecutionsMethods.Get", TriggersExecutionsGet},
+	{Endpoint{"/user", http.MethodGet}, "UserMethods.Get", UserGet},
+	{Endpoint{"/user", http.MethodPost}, "UserMethods.Post", UserPost},
+	{Endpoint{"/user", http.MethodPut}, "UserMethods.Put", UserPut},
+	{Endpoint{"/user", http.MethodDelete}, "UserMethods.Delete", UserDelete},
+	{Endpoint{"/user.by", http.MethodGet}, "UserByMethods.Get", UserByGet},
+	{Endpoint{"/user.convert", http.MethodPost}, "UserConvertMethods.Post", UserConvertPost},
+	{Endpoint{"/user.disable", http.MethodPut}, "UserDisableMethods.Put", UserDisablePut},
+	{Endpoint{"/user.enable", http.MethodPut}, "UserEnableMethods.Put", UserEnablePut},
+	{Endpoint{"/user.password", http.MethodPut}, "UserPasswordMethods.Put", UserPasswordPut},
+	{Endpoint{"/user.password.reset", http.MethodPost}, "UserPasswordResetMethods.Post", UserPasswordResetPost},
+	{Endpoint{"/user.token", http.MethodPost}, "UserTokenMethods.Post", UserTokenPost},
+	{Endpoint{"/users", http.MethodGet}, "UsersMethods.Get", UsersGet},
+	{Endpoint{"/users.by", http.MethodGet}, "UsersByMethods.Get", UsersByGet},
+	{Endpoint{"/users.groups", http.MethodGet}, "UsersGroupsMethods.Get", UsersGroupsGet},
+	{Endpoint{"/wf.deploy", http.MethodPut}, "WorkflowDeployMethods.Put", WorkflowDeployPut},
+	{Endpoint{"/wf.initialfields.by.category", http.MethodGet}, "WorkflowInitialFieldsByCategoryMethods.Get", WorkflowInitialFieldsByCategoryGet},
+}
+
+// Registrations returns a copy of every registered endpoint
+func Registrations() []Registration {
+	return slices.Clone(registry)
+}
+
+// Lookup returns the scope required to call path with method
+func Lookup(path, method string) (ScopeType, bool) {
+	for _, r := range registry {
+		if r.Path == path && strings.EqualFold(r.Method, method) {
+			return r.Scope, true
+		}
+	}
+	return "", false
+}
+
+// ForEndpoints returns the scopes required to call every endpoint provided.
+// Duplicate scopes are only returned once.
+func ForEndpoints(eps ...Endpoint) ([]ScopeType, error) {
+	var scps []ScopeType
+	var errs []error
+	for _, ep := range eps {
+		s, ok := Lookup(ep.Path, ep.Method)
+		if !ok {
+			errs = append(errs, fmt.Errorf("no scope registered for %s %s", ep.Method, ep.Path))
+			continue
+		}
+		if !slices.Contains(scps, s) {
+			scps = append(scps, s)
+		}
+	}
+	return scps, errors.Join(errs...)
+}
+
+/*
+For returns the scopes required to call every endpoint method provided.
+Methods are passed as method values from the Invgo client or as method expressions.
+
+Example:
+
+	scps, err := scopes.For(client.Incident().Get, client.Users().Get)
+	scps, err = scopes.For((*endpoints.IncidentMethods).Get, (*endpoints.UsersMethods).Get)
+
+NOTE: Attribute endpoints all share endpoints.AttributesMethods.Get so their scope can not
+be inferred from the method alone. Use ForEndpoints for attribute endpoints.
+*/
+func For(fns ...any) ([]ScopeType, error) {
+	var scps []ScopeType
+	var errs []error
+	for _, fn := range fns {
+		accessor, err := accessorName(fn)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		var matches []Registration
+		for _, r := range registry {
+			if r.Accessor == accessor {
+				matches = append(matches, r)
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			errs = append(errs, fmt.Errorf("no scope registered for %s", accessor))
+		case 1:
+			if !slices.Contains(scps, matches[0].Scope) {
+				scps = append(scps, matches[0].Scope)
+			}
+		default:
+			var paths []string
+			for _, m := range matches {
+				paths = append(paths, m.Path)
+			}
+			errs = append(errs, fmt.Errorf("%s is shared by %s, use ForEndpoints instead", accessor, strings.Join(paths, ", ")))
+		}
+	}
+	return scps, errors.Join(errs...)
+}
+
+// Implemented returns each registered endpoint path with its implemented HTTP methods
+func Implemented() map[string][]string {
+	impl := map[string][]string{}
+	for _, r := range registry {
+		impl[r.Path] = append(impl[r.Path], r.Method)
+	}
+	return impl
+}
+
+// accessorName returns the registry accessor for a method value
+// e.g. github.com/tmstorm/invgo/endpoints.(*IncidentMethods).Get-fm -> IncidentMethods.Get
+func accessorName(fn any) (string, error) {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return "", fmt.Errorf("%T is not a method", fn)
+	}
+
+	f := runtime.FuncForPC(v.Pointer())
+	if f == nil {
+		return "", fmt.Errorf("unable to resolve method %T", fn)
+	}
+
+	name := strings.TrimSuffix(f.Name(), "-fm")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	_, name, _ = strings.Cut(name, ".")
+	name = strings.NewReplacer("(", "", ")", "", "*", "").Replace(name)
+	return name, nil
+}
@@ -0,0 +1,162 @@
+package scopes_test
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/endpoints"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+func TestLookup(t *testing.T) {
+	a := assert.New(t)
+
+	s, ok := scopes.Lookup("/incident", http.MethodPost)
+	a.True(ok)
+	a.Equal(scopes.IncidentPost, s)
+
+	s, ok = scopes.Lookup("/incident.attributes.status", "get")
+	a.True(ok)
+	a.Equal(scopes.IncidentAttributesStatusGet, s)
+
+	_, ok = scopes.Lookup("/incident", http.MethodDelete)
+	a.False(ok)
+}
+
+func TestForEndpoints(t *testing.T) {
+	a := assert.New(t)
+
+	scps, err := scopes.ForEndpoints(
+		scopes.Endpoint{Path: "/incident", Method: http.MethodGet},
+		scopes.Endpoint{Path: "/users", Method: http.MethodGet},
+		scopes.Endpoint{Path: "/incident", Method: http.MethodGet},
+	)
+	a.NoError(err)
+	a.Equal([]scopes.ScopeType{scopes.IncidentGet, scopes.UsersGet}, scps)
+
+	_, err = scopes.ForEndpoints(scopes.Endpoint{Path: "/not.real", Method: http.MethodGet})
+	a.Error(err)
+}
+
+func TestFor(t *testing.T) {
+	a := assert.New(t)
+
+	u, err := url.Parse("https://test.com/api/v1")
+	a.NoError(err)
+	c := &invgo.Client{APIURL: u}
+
+	scps, err := scopes.For(c.Incident().Get, c.Incident().Post, c.Users().Get, c.TimeTracking().Delete)
+	a.NoError(err)
+	a.Equal([]scopes.ScopeType{scopes.IncidentGet, scopes.IncidentPost, scopes.UsersGet, scopes.TimeTrackingDelete}, scps)
+
+	scps, err = scopes.For((*endpoints.UsersMethods).Get)
+	a.NoError(err)
+	a.Equal([]scopes.ScopeType{scopes.UsersGet}, scps)
+
+	// attribute endpoints share a Get method
+	_, err = scopes.For(c.IncidentAttributesStatus().Get)
+	a.Error(err)
+
+	_, err = scopes.For("not a method")
+	a.Error(err)
+}
+
+func TestImplemented(t *testing.T) {
+	a := assert.New(t)
+
+	impl := scopes.Implemented()
+	a.ElementsMatch([]string{http.MethodGet, http.MethodPost, http.MethodPut}, impl["/incident"])
+
+	for _, r := range scopes.Registrations() {
+		a.Contains(impl[r.Path], r.Method)
+		a.NotEmpty(r.Scope)
+		a.NotEmpty(r.Accessor)
+	}
+}
+
+// TestRegistryMatchesEndpoints checks that the registry has exactly one registration for every
+// endpoint method and that it has the path given in endpoint_methods.go and the HTTP method it sends
+func TestRegistryMatchesEndpoints(t *testing.T) {
+	a := assert.New(t)
+	fset := token.NewFileSet()
+
+	// endpoint paths by the endpoints type returned from the client
+	paths := map[string][]string{}
+	f, err := parser.ParseFile(fset, filepath.Join("..", "endpoint_methods.go"), nil, 0)
+	a.NoError(err)
+	ast.Inspect(f, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 2 {
+			return true
+		}
+		idx, ok := call.Fun.(*ast.IndexExpr)
+		if !ok || fmt.Sprint(idx.X) != "newPublicMethod" {
+			return true
+		}
+		typ, ok := idx.Index.(*ast.SelectorExpr)
+		lit, isLit := call.Args[1].(*ast.BasicLit)
+		if !ok || !isLit {
+			return true
+		}
+		path, err := strconv.Unquote(lit.Value)
+		a.NoError(err)
+		paths[typ.Sel.Name] = append(paths[typ.Sel.Name], path)
+		return true
+	})
+	a.NotEmpty(paths)
+
+	var want []string
+	files, err := filepath.Glob(filepath.Join("..", "endpoints", "*.go"))
+	a.NoError(err)
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		a.NoError(err)
+
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || !fn.Name.IsExported() {
+				continue
+			}
+			star, ok := fn.Recv.List[0].Type.(*ast.StarExpr)
+			if !ok {
+				continue
+			}
+			recv := fmt.Sprint(star.X)
+
+			var methods []string
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				if sel, ok := n.(*ast.SelectorExpr); ok && strings.HasPrefix(sel.Sel.Name, "Remote") {
+					methods = append(methods, strings.ToUpper(strings.TrimPrefix(sel.Sel.Name, "Remote")))
+				}
+				return true
+			})
+			if len(methods) == 0 {
+				continue
+			}
+			a.Len(methods, 1, "%s.%s sends more than one request", recv, fn.Name.Name)
+			a.NotEmpty(paths[recv], "%s is not returned by the client", recv)
+			for _, path := range paths[recv] {
+				want = append(want, fmt.Sprintf("%s %s %s.%s", methods[0], path, recv, fn.Name.Name))
+			}
+		}
+	}
+
+	var got []string
+	for _, r := range scopes.Registrations() {
+		got = append(got, fmt.Sprintf("%s %s %s", r.Method, r.Path, r.Accessor))
+	}
+	a.ElementsMatch(want, got)
+}
@@ -75,6 +75,12 @@ var (
 	CategoriesGet ScopeType = ScopeType(base + categories + methods.Get)
 )
 
+// Custom Fields
+var (
+	customFields                        = ".cf"
+	CustomFieldsByCategoryGet ScopeType = ScopeType(base + customFields + ".fields.by.category" + methods.Get)
+)
+
 // Help Desks
 var (
 	helpdesks                          = ".helpdesks"
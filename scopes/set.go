@@ -0,0 +1,124 @@
+package scopes
+
+import (
+	"slices"
+	"strings"
+)
+
+// Set is an unordered collection of unique scopes
+type Set map[ScopeType]struct{}
+
+// Bundles are predefined groups of scopes that can be referenced by name in configuration
+// and passed to Resolve.
+var Bundles = map[string][]ScopeType{
+	// incident.read allows reading incidents and everything related to them
+	"incident.read": mustMatch("incident.*:get", "incident:get", "incidents*:get"),
+	// incident.write allows creating and changing incidents
+	"incident.write": mustMatch("incident.*:post", "incident.*:put", "incident:post", "incident:put"),
+	// user.read allows reading users and their groups
+	"user.read": mustMatch("user:get", "user.*:get", "users*:get"),
+	// user.admin allows full user administration
+	"user.admin": mustMatch("user*", "users*"),
+	// breakingnews.read allows reading breaking news
+	"breakingnews.read": mustMatch("breakingnews*:get"),
+	// breakingnews.write allows creating and updating breaking news
+	"breakingnews.write": mustMatch("breakingnews*:post", "breakingnews*:put"),
+	// timetracking.read allows reading time tracking records and categories
+	"timetracking.read": mustMatch("timetracking*:get"),
+	// timetracking.write allows logging and deleting time tracking records
+	"timetracking.write": mustMatch("timetracking:post", "timetracking:delete"),
+	// catalog.read allows reading every attribute, category and help desk, see invgo.Catalog
+	"catalog.read": mustMatch("*.attributes.*:get", "categories:get", "helpdesks:get"),
+}
+
+// mustMatch returns the sorted union of every pattern and panics if a pattern is invalid
+func mustMatch(patterns ...string) []ScopeType {
+	set := Set{}
+	for _, p := range patterns {
+		m, err := Match(p)
+		if err != nil {
+			panic(err)
+		}
+		set = set.Union(m)
+	}
+	return set.Slice()
+}
+
+// NewSet creates a Set containing scps
+func NewSet(scps ...ScopeType) Set {
+	s := Set{}
+	s.Add(scps...)
+	return s
+}
+
+// Add adds scps to the Set
+func (s Set) Add(scps ...ScopeType) {
+	for _, v := range scps {
+		s[v] = struct{}{}
+	}
+}
+
+// Contains returns true if scp is in the Set
+func (s Set) Contains(scp ScopeType) bool {
+	_, ok := s[scp]
+	return ok
+}
+
+// ContainsPrefix returns true if any scope in the Set starts with prefix.
+// The api.{version}. part of the scope can be left out e.g. "incident.attributes".
+func (s Set) ContainsPrefix(prefix string) bool {
+	for v := range s {
+		str := string(v)
+		if strings.HasPrefix(str, prefix) {
+			return true
+		}
+		if p, err := parse(str); err == nil && strings.HasPrefix(p.Resource, strings.TrimPrefix(prefix, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// Union returns a new Set with the scopes in s and o
+func (s Set) Union(o Set) Set {
+	u := make(Set, len(s)+len(o))
+	for v := range s {
+		u[v] = struct{}{}
+	}
+	for v := range o {
+		u[v] = struct{}{}
+	}
+	return u
+}
+
+// Diff returns a new Set with the scopes in s that are not in o
+func (s Set) Diff(o Set) Set {
+	d := Set{}
+	for v := range s {
+		if !o.Contains(v) {
+			d[v] = struct{}{}
+		}
+	}
+	return d
+}
+
+// Intersect returns a new Set with the scopes in both s and o
+func (s Set) Intersect(o Set) Set {
+	i := Set{}
+	for v := range s {
+		if o.Contains(v) {
+			i[v] = struct{}{}
+		}
+	}
+	return i
+}
+
+// Slice returns the scopes in the Set sorted alphabetically
+func (s Set) Slice() []ScopeType {
+	scps := make([]ScopeType, 0, len(s))
+	for v := range s {
+		scps = append(scps, v)
+	}
+	slices.Sort(scps)
+	return scps
+}
@@ -0,0 +1,23 @@
+// genapi generates the endpoint interfaces, invgo.API and the invgomock fakes.
+// It must be run from the module root, this is done by go generate.
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/tmstorm/invgo/internal/apigen"
+)
+
+func main() {
+	files, err := apigen.Generate(".")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for path, src := range files {
+		if err := os.WriteFile(path, src, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package invgo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+type (
+	// SecretSource provides the client secret used to authenticate with Invgate.
+	// It allows the secret to be loaded when the client is created instead of being stored in Invgate.ClientSecret.
+	SecretSource interface {
+		Secret() (string, error)
+	}
+
+	// FileSecret reads the secret from the file at the given path.
+	// Leading and trailing whitespace is removed.
+	FileSecret string
+
+	// EnvSecret reads the secret from the environment variable with the given name
+	EnvSecret string
+
+	// CommandSecret runs the command and uses its output as the secret.
+	// The first element is the command and the rest are its arguments.
+	// Leading and trailing whitespace is removed from the output.
+	//
+	// Example:
+	//
+	//	invgo.CommandSecret{"vault", "kv", "get", "-field=secret", "secret/invgate"}
+	CommandSecret []string
+)
+
+// Secret returns the contents of the file
+func (f FileSecret) Secret() (string, error) {
+	b, err := os.ReadFile(string(f))
+	if err != nil {
+		return "", fmt.Errorf("unable to read client secret file: %w", err)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// Secret returns the value of the environment variable
+func (e EnvSecret) Secret() (string, error) {
+	v, ok := os.LookupEnv(string(e))
+	if !ok {
+		return "", fmt.Errorf("client secret environment variable %s is not set", string(e))
+	}
+	return v, nil
+}
+
+// Secret runs the command and returns its output
+func (c CommandSecret) Secret() (string, error) {
+	if len(c) == 0 {
+		return "", errors.New("no client secret command was provided")
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(c[0], c[1:]...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("client secret command %s failed: %w: %s", c[0], err, msg)
+		}
+		return "", fmt.Errorf("client secret command %s failed: %w", c[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// clientSecret returns cfg.ClientSecret or loads it from cfg.ClientSecretSource if it is empty
+func clientSecret(cfg *Invgate) (string, error) {
+	if cfg.ClientSecret != "" || cfg.ClientSecretSource == nil {
+		return cfg.ClientSecret, nil
+	}
+
+	s, err := cfg.ClientSecretSource.Secret()
+	if err != nil {
+		return "", err
+	}
+	if s == "" {
+		return "", errors.New("client secret source returned an empty secret")
+	}
+	return s, nil
+}
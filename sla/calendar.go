@@ -0,0 +1,144 @@
+package sla
+
+import "time"
+
+// maxCalendarDays limits how far AddWorkingTime searches for working time so a calendar
+// without working days does not loop forever
+const maxCalendarDays = 10 * 366
+
+type (
+	// Hours is the working time of a day as offsets from midnight e.g. 9h to 17h
+	Hours struct {
+		Start time.Duration
+		End   time.Duration
+	}
+
+	// Calendar is the business hours SLA time is counted in.
+	// A nil Calendar counts every hour of every day.
+	Calendar struct {
+		// Location is the time zone the hours and holidays are in. If nil UTC is used.
+		Location *time.Location
+		// Hours are the working hours of each weekday. Days without hours are not worked.
+		Hours map[time.Weekday]Hours
+		// Holidays are days that are not worked. Only the date in Location is used.
+		Holidays []time.Time
+	}
+)
+
+// NewBusinessCalendar returns a Calendar working from start to end on each of days.
+// If no days are provided Monday to Friday is used.
+func NewBusinessCalendar(loc *time.Location, start, end time.Duration, days ...time.Weekday) *Calendar {
+	if len(days) == 0 {
+		days = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	}
+	c := &Calendar{Location: loc, Hours: map[time.Weekday]Hours{}}
+	for _, d := range days {
+		c.Hours[d] = Hours{Start: start, End: end}
+	}
+	return c
+}
+
+// WorkingTime returns the working time between from and to
+func (c *Calendar) WorkingTime(from, to time.Time) time.Duration {
+	if !to.After(from) {
+		return 0
+	}
+	if c == nil {
+		return to.Sub(from)
+	}
+
+	var total time.Duration
+	for day := c.midnight(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+		start, end, ok := c.window(day)
+		if !ok {
+			continue
+		}
+		start, end = later(start, from), earlier(end, to)
+		if end.After(start) {
+			total += end.Sub(start)
+		}
+	}
+	return total
+}
+
+// AddWorkingTime returns the time d of working time after from.
+// The zero time is returned if the calendar has no working time within ten years.
+func (c *Calendar) AddWorkingTime(from time.Time, d time.Duration) time.Time {
+	if d <= 0 {
+		return from
+	}
+	if c == nil {
+		return from.Add(d)
+	}
+
+	day := c.midnight(from)
+	for range maxCalendarDays {
+		if start, end, ok := c.window(day); ok {
+			start = later(start, from)
+			if end.After(start) {
+				avail := end.Sub(start)
+				if d <= avail {
+					return start.Add(d)
+				}
+				d -= avail
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return time.Time{}
+}
+
+// window returns the working hours of the day starting at midnight
+func (c *Calendar) window(midnight time.Time) (time.Time, time.Time, bool) {
+	h, ok := c.Hours[midnight.Weekday()]
+	if !ok || h.End <= h.Start || c.holiday(midnight) {
+		return time.Time{}, time.Time{}, false
+	}
+	return c.at(midnight, h.Start), c.at(midnight, h.End), true
+}
+
+// at returns the wall clock time offset from midnight in the calendar's location.
+// Adding the offset to midnight would be off by an hour on days the clocks change.
+func (c *Calendar) at(midnight time.Time, offset time.Duration) time.Time {
+	y, m, d := midnight.Date()
+	hh, mm := int(offset/time.Hour), int(offset%time.Hour/time.Minute)
+	ss, ns := int(offset%time.Minute/time.Second), int(offset%time.Second)
+	return time.Date(y, m, d, hh, mm, ss, ns, c.location())
+}
+
+func (c *Calendar) holiday(midnight time.Time) bool {
+	y, m, d := midnight.Date()
+	for _, h := range c.Holidays {
+		hy, hm, hd := h.In(c.location()).Date()
+		if hy == y && hm == m && hd == d {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Calendar) midnight(t time.Time) time.Time {
+	y, m, d := t.In(c.location()).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, c.location())
+}
+
+func (c *Calendar) location() *time.Location {
+	if c.Location == nil {
+		return time.UTC
+	}
+	return c.Location
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earlier(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
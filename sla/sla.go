@@ -0,0 +1,312 @@
+/*
+Package sla computes the SLA state of incidents.
+
+Invgate returns the SLA of an incident as the SLAIncidentFirstReply and SLAIncidentResolution
+strings. Parse reads them and a Policy combines them with the incident's CreatedAt, SolvedAt,
+ClosedAt and comments, the periods it was paused while waiting and a business hours Calendar
+to report the elapsed and remaining time of each SLA and whether it was breached.
+
+	policy := sla.Policy{
+		FirstReply: 4 * time.Hour,
+		Resolution: 16 * time.Hour,
+		Calendar:   sla.NewBusinessCalendar(loc, 9*time.Hour, 17*time.Hour),
+	}
+	report := policy.Evaluate(inc, nil, time.Now())
+
+	// incidents that will breach in the next 30 minutes
+	atRisk := policy.PredictBreaches(incs, nil, time.Now(), 30*time.Minute)
+*/
+package sla
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tmstorm/invgo/endpoints"
+)
+
+// Metric is an SLA measured on an incident
+type Metric string
+
+const (
+	// FirstReply runs from creation to the first comment by someone other than the customer
+	FirstReply Metric = "first_reply"
+	// Resolution runs from creation until the incident is solved or closed
+	Resolution Metric = "resolution"
+)
+
+// State is the SLA state reported by Invgate
+type State string
+
+const (
+	StateUnknown  State = ""
+	StateOnTime   State = "on_time"
+	StateBreached State = "breached"
+	StatePaused   State = "paused"
+)
+
+// stateWords are the values of the SLA fields that map to a State
+var stateWords = map[string]State{
+	"ok":          StateOnTime,
+	"on_time":     StateOnTime,
+	"in_time":     StateOnTime,
+	"fulfilled":   StateOnTime,
+	"met":         StateOnTime,
+	"late":        StateBreached,
+	"expired":     StateBreached,
+	"overdue":     StateBreached,
+	"breached":    StateBreached,
+	"out_of_time": StateBreached,
+	"not_met":     StateBreached,
+	"paused":      StatePaused,
+	"stopped":     StatePaused,
+}
+
+// dateLayouts are the date formats accepted by Parse
+var dateLayouts = []string{time.RFC3339, time.DateTime, time.DateOnly}
+
+type (
+	// Reported is the SLA state parsed from an incident field.
+	// Invgate does not document the format so a state, a deadline or both may be empty.
+	Reported struct {
+		State    State
+		Deadline time.Time
+	}
+
+	// Period is a time the SLA was paused e.g. while waiting for the customer.
+	// A zero To means the period has not ended.
+	Period struct {
+		From time.Time
+		To   time.Time
+	}
+
+	// Policy is the SLA targets of incidents. The targets are working time in Calendar.
+	// If a target is 0 the deadline reported by Invgate is used instead.
+	Policy struct {
+		FirstReply time.Duration
+		Resolution time.Duration
+		Calendar   *Calendar
+	}
+
+	// Status is the state of a single SLA metric of an incident
+	Status struct {
+		Metric Metric
+		Target time.Duration
+		Start  time.Time
+		// Deadline is when the SLA breaches. It is zero if it is unknown or the SLA is paused.
+		Deadline time.Time
+		// MetAt is when the SLA stopped running. It is zero while it is running.
+		MetAt time.Time
+		// Elapsed and Remaining are working time excluding paused periods
+		Elapsed   time.Duration
+		Remaining time.Duration
+		Breached  bool
+		Paused    bool
+		Reported  Reported
+		// ReportedErr is set if the SLA field of the incident could not be parsed.
+		// Reported is empty when it is set.
+		ReportedErr error
+	}
+
+	// Report is the SLA state of an incident
+	Report struct {
+		IncidentID int
+		FirstReply Status
+		Resolution Status
+	}
+)
+
+// Parse reads an SLA field of an incident. It accepts a state such as "on time" or "expired",
+// a unix timestamp or a date. An empty string returns an empty Reported.
+func Parse(s string) (Reported, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Reported{}, nil
+	}
+
+	word := strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(s))
+	if st, ok := stateWords[word]; ok {
+		return Reported{State: st}, nil
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return Reported{Deadline: time.Unix(n, 0)}, nil
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return Reported{Deadline: t}, nil
+		}
+	}
+	return Reported{}, fmt.Errorf("unknown SLA value %q", s)
+}
+
+// Evaluate returns the SLA state of inc at now. paused are the periods the incident was
+// waiting and its SLA did not run.
+func (p Policy) Evaluate(inc endpoints.Incident, paused []Period, now time.Time) Report {
+	start := time.Unix(int64(inc.CreatedAt), 0)
+	paused = mergePeriods(paused)
+
+	resolved := inc.SolvedAt
+	if resolved == 0 {
+		resolved = inc.ClosedAt
+	}
+
+	return Report{
+		IncidentID: inc.ID,
+		FirstReply: p.status(FirstReply, p.FirstReply, inc.SLAIncidentFirstReply, start, firstReply(inc), paused, now),
+		Resolution: p.status(Resolution, p.Resolution, inc.SLAIncidentResolution, start, unix(resolved), paused, now),
+	}
+}
+
+// PredictBreaches returns the reports of incidents with an SLA that is running and will
+// breach within the provided duration of now. Incidents that have already breached are not
+// returned. paused maps incident IDs to their paused periods.
+func (p Policy) PredictBreaches(incs []endpoints.Incident, paused map[int][]Period, now time.Time, within time.Duration) []Report {
+	limit := now.Add(within)
+	atRisk := func(s Status) bool {
+		return s.MetAt.IsZero() && !s.Breached && !s.Deadline.IsZero() && !s.Deadline.After(limit)
+	}
+
+	var reports []Report
+	for _, inc := range incs {
+		r := p.Evaluate(inc, paused[inc.ID], now)
+		if atRisk(r.FirstReply) || atRisk(r.Resolution) {
+			reports = append(reports, r)
+		}
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].nextDeadline().Before(reports[j].nextDeadline())
+	})
+	return reports
+}
+
+func (p Policy) status(m Metric, target time.Duration, field string, start, metAt time.Time, paused []Period, now time.Time) Status {
+	reported, err := Parse(field)
+	s := Status{
+		Metric:      m,
+		Target:      target,
+		Start:       start,
+		MetAt:       metAt,
+		Reported:    reported,
+		ReportedErr: err,
+	}
+
+	end := now
+	if !metAt.IsZero() {
+		end = metAt
+	} else if n := len(paused); n > 0 && paused[n-1].To.IsZero() && !paused[n-1].From.After(now) {
+		s.Paused = true
+	}
+	s.Elapsed = p.elapsed(start, end, paused)
+
+	switch {
+	case target > 0:
+		s.Deadline = p.deadline(start, target, paused)
+		s.Remaining = max(target-s.Elapsed, 0)
+		s.Breached = s.Elapsed > target
+	case !reported.Deadline.IsZero():
+		s.Deadline = reported.Deadline
+		if metAt.IsZero() {
+			s.Remaining = p.Calendar.WorkingTime(now, s.Deadline)
+		}
+		s.Breached = end.After(s.Deadline)
+	}
+	if reported.State == StateBreached {
+		s.Breached = true
+	}
+	if !s.MetAt.IsZero() {
+		s.Remaining = 0
+	}
+	if s.Paused {
+		s.Deadline = time.Time{}
+	}
+	return s
+}
+
+// elapsed returns the working time from start to end excluding paused periods
+func (p Policy) elapsed(start, end time.Time, paused []Period) time.Duration {
+	total := p.Calendar.WorkingTime(start, end)
+	for _, pp := range paused {
+		to := pp.To
+		if to.IsZero() || to.After(end) {
+			to = end
+		}
+		total -= p.Calendar.WorkingTime(later(pp.From, start), to)
+	}
+	return max(total, 0)
+}
+
+// deadline returns when target working time has run from start skipping paused periods.
+// It is zero if the target is reached during a period that has not ended.
+func (p Policy) deadline(start time.Time, target time.Duration, paused []Period) time.Time {
+	t, remaining := start, target
+	for _, pp := range paused {
+		if !pp.To.IsZero() && !pp.To.After(t) {
+			continue
+		}
+		before := p.Calendar.WorkingTime(t, pp.From)
+		if remaining <= before {
+			return p.Calendar.AddWorkingTime(t, remaining)
+		}
+		remaining -= before
+		if pp.To.IsZero() {
+			return time.Time{}
+		}
+		t = later(pp.To, t)
+	}
+	return p.Calendar.AddWorkingTime(t, remaining)
+}
+
+// nextDeadline returns the earliest deadline of a running SLA
+func (r Report) nextDeadline() time.Time {
+	var next time.Time
+	for _, s := range []Status{r.FirstReply, r.Resolution} {
+		if s.MetAt.IsZero() && !s.Deadline.IsZero() && (next.IsZero() || s.Deadline.Before(next)) {
+			next = s.Deadline
+		}
+	}
+	return next
+}
+
+// firstReply returns when someone other than the customer first commented or the
+// incident was solved if that was earlier
+func firstReply(inc endpoints.Incident) time.Time {
+	first := inc.SolvedAt
+	for _, c := range inc.Comments {
+		if c.AuthorID != inc.UserID && c.CreatedAt > 0 && (first == 0 || c.CreatedAt < first) {
+			first = c.CreatedAt
+		}
+	}
+	return unix(first)
+}
+
+// mergePeriods sorts periods and merges those that overlap
+func mergePeriods(periods []Period) []Period {
+	periods = slices.Clone(periods)
+	sort.Slice(periods, func(i, j int) bool { return periods[i].From.Before(periods[j].From) })
+
+	var merged []Period
+	for _, pp := range periods {
+		n := len(merged)
+		if n > 0 && (merged[n-1].To.IsZero() || !pp.From.After(merged[n-1].To)) {
+			if merged[n-1].To.IsZero() || pp.To.IsZero() {
+				merged[n-1].To = time.Time{}
+			} else {
+				merged[n-1].To = later(merged[n-1].To, pp.To)
+			}
+			continue
+		}
+		merged = append(merged, pp)
+	}
+	return merged
+}
+
+func unix(sec int) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(sec), 0)
+}
@@ -0,0 +1,135 @@
+package sla_test
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmstorm/invgo/endpoints"
+	"github.com/tmstorm/invgo/sla"
+)
+
+func TestCalendar(t *testing.T) {
+	a := assert.New(t)
+
+	c := sla.NewBusinessCalendar(time.UTC, 9*time.Hour, 17*time.Hour)
+	friday := time.Date(2025, time.January, 3, 16, 0, 0, 0, time.UTC)
+	monday := time.Date(2025, time.January, 6, 10, 0, 0, 0, time.UTC)
+
+	a.Equal(2*time.Hour, c.WorkingTime(friday, monday))
+	a.Equal(monday, c.AddWorkingTime(friday, 2*time.Hour))
+
+	c.Holidays = []time.Time{monday}
+	a.Equal(time.Hour, c.WorkingTime(friday, monday))
+	a.Equal(monday.AddDate(0, 0, 1), c.AddWorkingTime(friday, 2*time.Hour))
+
+	// working hours are wall clock times on the day the clocks change
+	ny, err := time.LoadLocation("America/New_York")
+	a.NoError(err)
+	dst := &sla.Calendar{Location: ny, Hours: map[time.Weekday]sla.Hours{
+		time.Sunday: {Start: 9 * time.Hour, End: 17 * time.Hour},
+	}}
+	changed := time.Date(2025, time.March, 9, 0, 0, 0, 0, ny)
+	a.Equal(time.Date(2025, time.March, 9, 10, 0, 0, 0, ny), dst.AddWorkingTime(changed, time.Hour))
+	a.Equal(8*time.Hour, dst.WorkingTime(changed, changed.AddDate(0, 0, 1)))
+
+	var always *sla.Calendar
+	a.Equal(monday.Sub(friday), always.WorkingTime(friday, monday))
+	a.True((&sla.Calendar{}).AddWorkingTime(friday, time.Hour).IsZero())
+}
+
+func TestParse(t *testing.T) {
+	a := assert.New(t)
+
+	r, err := sla.Parse("Expired")
+	a.NoError(err)
+	a.Equal(sla.StateBreached, r.State)
+
+	r, err = sla.Parse("on time")
+	a.NoError(err)
+	a.Equal(sla.StateOnTime, r.State)
+
+	r, err = sla.Parse("1735812000")
+	a.NoError(err)
+	a.True(time.Unix(1735812000, 0).Equal(r.Deadline))
+
+	r, err = sla.Parse("2025-01-02 10:00:00")
+	a.NoError(err)
+	a.Equal(time.Date(2025, time.January, 2, 10, 0, 0, 0, time.UTC), r.Deadline)
+
+	_, err = sla.Parse("soon")
+	a.Error(err)
+}
+
+func TestPolicyEvaluate(t *testing.T) {
+	a := assert.New(t)
+
+	created := time.Date(2025, time.January, 2, 8, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) int { return int(created.Add(d).Unix()) }
+	inc := endpoints.Incident{
+		ID:        1,
+		UserID:    10,
+		CreatedAt: at(0),
+		Comments: []endpoints.IncidentCommentResponse{
+			{AuthorID: 10, CreatedAt: at(10 * time.Minute)},
+			{AuthorID: 20, CreatedAt: at(90 * time.Minute)},
+		},
+	}
+	paused := []sla.Period{{From: created.Add(time.Hour), To: created.Add(2 * time.Hour)}}
+	p := sla.Policy{FirstReply: time.Hour, Resolution: 4 * time.Hour}
+
+	r := p.Evaluate(inc, paused, created.Add(4*time.Hour))
+	// the customer's comment is not a reply and the pause stopped the clock
+	a.True(created.Add(90 * time.Minute).Equal(r.FirstReply.MetAt))
+	a.Equal(time.Hour, r.FirstReply.Elapsed)
+	a.False(r.FirstReply.Breached)
+
+	a.Equal(3*time.Hour, r.Resolution.Elapsed)
+	a.Equal(time.Hour, r.Resolution.Remaining)
+	a.True(created.Add(5 * time.Hour).Equal(r.Resolution.Deadline))
+	a.False(r.Resolution.Breached)
+
+	r = p.Evaluate(inc, paused, created.Add(6*time.Hour))
+	a.True(r.Resolution.Breached)
+	a.Zero(r.Resolution.Remaining)
+
+	// an ongoing pause has no deadline
+	r = p.Evaluate(inc, append(paused, sla.Period{From: created.Add(3 * time.Hour)}), created.Add(4*time.Hour))
+	a.True(r.Resolution.Paused)
+	a.Equal(2*time.Hour, r.Resolution.Elapsed)
+	a.True(r.Resolution.Deadline.IsZero())
+
+	// Invgate's deadline is used without a target
+	inc.SLAIncidentResolution = created.Add(2 * time.Hour).Format(time.RFC3339)
+	r = sla.Policy{}.Evaluate(inc, nil, created.Add(3*time.Hour))
+	a.True(r.Resolution.Breached)
+	a.NoError(r.Resolution.ReportedErr)
+
+	// fields that can not be parsed are reported instead of ignored
+	inc.SLAIncidentResolution = "sometime soon"
+	r = sla.Policy{}.Evaluate(inc, nil, created.Add(3*time.Hour))
+	a.Error(r.Resolution.ReportedErr)
+	a.False(r.Resolution.Breached)
+}
+
+func TestPredictBreaches(t *testing.T) {
+	a := assert.New(t)
+
+	now := time.Date(2025, time.January, 2, 12, 0, 0, 0, time.UTC)
+	incs := []endpoints.Incident{
+		{ID: 1, CreatedAt: int(now.Add(-3*time.Hour - 45*time.Minute).Unix())},
+		{ID: 2, CreatedAt: int(now.Add(-time.Hour).Unix())},
+		{ID: 3, CreatedAt: int(now.Add(-3*time.Hour - 50*time.Minute).Unix())},
+		{ID: 4, CreatedAt: int(now.Add(-5 * time.Hour).Unix())},
+		{ID: 5, CreatedAt: int(now.Add(-3*time.Hour - 55*time.Minute).Unix()), SolvedAt: int(now.Unix())},
+	}
+	p := sla.Policy{Resolution: 4 * time.Hour}
+
+	var ids []int
+	for _, r := range p.PredictBreaches(incs, nil, now, 30*time.Minute) {
+		ids = append(ids, r.IncidentID)
+	}
+	// 2 is not due, 4 has already breached and 5 is solved
+	a.Equal([]int{3, 1}, ids)
+}
@@ -0,0 +1,212 @@
+package timetracking
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Dimension is a field Timesheet groups entries by
+type Dimension int
+
+const (
+	// ByWeek groups entries by the Monday of the week they started in
+	ByWeek Dimension = iota
+	// ByUser groups entries by the agent that logged them
+	ByUser
+	// ByIncident groups entries by incident
+	ByIncident
+	// ByCategory groups entries by time tracking category
+	ByCategory
+)
+
+// IssueKind is the type of problem found by Check
+type IssueKind string
+
+const (
+	// Overlap is time logged by a user that overlaps time they already logged
+	Overlap IssueKind = "overlap"
+	// Gap is time between two entries of a user on the same day longer than the allowed gap
+	Gap IssueKind = "gap"
+)
+
+type (
+	// Row is the total of the entries in a group. Fields that were not grouped by are empty.
+	Row struct {
+		Week       time.Time     `json:"week,omitzero"`
+		UserID     int           `json:"user_id,omitempty"`
+		IncidentID int           `json:"incident_id,omitempty"`
+		CategoryID int           `json:"category_id,omitempty"`
+		Category   string        `json:"category,omitempty"`
+		Duration   time.Duration `json:"duration"`
+		Cost       float64       `json:"cost"`
+		Entries    int           `json:"entries"`
+	}
+
+	// Issue is an overlap or gap between two entries of the same user
+	Issue struct {
+		Kind   IssueKind `json:"kind"`
+		UserID int       `json:"user_id"`
+		First  Entry     `json:"first"`
+		Second Entry     `json:"second"`
+		// Duration is the length of the overlap or gap
+		Duration time.Duration `json:"duration"`
+	}
+)
+
+// Timesheet totals entries grouped by the provided dimensions.
+// Rows are sorted by week, user, incident and category.
+func Timesheet(entries []Entry, by ...Dimension) []Row {
+	group := map[Dimension]bool{}
+	for _, d := range by {
+		group[d] = true
+	}
+
+	rows := map[Row]*Row{}
+	for _, e := range entries {
+		var k Row
+		if group[ByWeek] {
+			k.Week = weekStart(e.From)
+		}
+		if group[ByUser] {
+			k.UserID = e.UserID
+		}
+		if group[ByIncident] {
+			k.IncidentID = e.IncidentID
+		}
+		if group[ByCategory] {
+			k.CategoryID = e.CategoryID
+			k.Category = e.Category
+		}
+
+		r, ok := rows[k]
+		if !ok {
+			r = &Row{Week: k.Week, UserID: k.UserID, IncidentID: k.IncidentID, CategoryID: k.CategoryID, Category: k.Category}
+			rows[k] = r
+		}
+		r.Duration += e.Duration
+		r.Cost += e.Cost
+		r.Entries++
+	}
+
+	sheet := make([]Row, 0, len(rows))
+	for _, r := range rows {
+		sheet = append(sheet, *r)
+	}
+	sort.Slice(sheet, func(i, j int) bool {
+		a, b := sheet[i], sheet[j]
+		switch {
+		case !a.Week.Equal(b.Week):
+			return a.Week.Before(b.Week)
+		case a.UserID != b.UserID:
+			return a.UserID < b.UserID
+		case a.IncidentID != b.IncidentID:
+			return a.IncidentID < b.IncidentID
+		default:
+			return a.CategoryID < b.CategoryID
+		}
+	})
+	return sheet
+}
+
+// Check returns the overlapping entries of each user and, if maxGap is more than 0,
+// the gaps longer than maxGap between entries of a user on the same day
+func Check(entries []Entry, maxGap time.Duration) []Issue {
+	var issues []Issue
+	for user, es := range users(entries) {
+		if len(es) == 0 {
+			continue
+		}
+		// prev is the entry that ends last so far so entries nested in a longer entry are
+		// compared with it rather than the entry before them
+		prev := es[0]
+		for _, cur := range es[1:] {
+			switch {
+			case cur.From.Before(prev.To):
+				issues = append(issues, Issue{Kind: Overlap, UserID: user, First: prev, Second: cur, Duration: earliest(prev.To, cur.To).Sub(cur.From)})
+			case maxGap > 0 && sameDay(prev.To, cur.From) && cur.From.Sub(prev.To) > maxGap:
+				issues = append(issues, Issue{Kind: Gap, UserID: user, First: prev, Second: cur, Duration: cur.From.Sub(prev.To)})
+			}
+			if cur.To.After(prev.To) {
+				prev = cur
+			}
+		}
+	}
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].UserID != issues[j].UserID {
+			return issues[i].UserID < issues[j].UserID
+		}
+		return issues[i].Second.From.Before(issues[j].Second.From)
+	})
+	return issues
+}
+
+// WriteCSV writes rows as CSV with a header. Duration is written in hours.
+func WriteCSV(w io.Writer, rows []Row) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"week", "user_id", "incident_id", "category_id", "category", "hours", "cost", "entries"}); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		week := ""
+		if !r.Week.IsZero() {
+			week = r.Week.Format(time.DateOnly)
+		}
+		err := cw.Write([]string{
+			week,
+			itoa(r.UserID),
+			itoa(r.IncidentID),
+			itoa(r.CategoryID),
+			r.Category,
+			strconv.FormatFloat(r.Duration.Hours(), 'f', 2, 64),
+			strconv.FormatFloat(r.Cost, 'f', 2, 64),
+			strconv.Itoa(r.Entries),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes rows as a JSON array. Duration is written in nanoseconds.
+func WriteJSON(w io.Writer, rows []Row) error {
+	if rows == nil {
+		rows = []Row{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rows)
+}
+
+// weekStart returns midnight of the Monday of the week t is in
+func weekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	y, m, d := t.AddDate(0, 0, -offset).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// itoa returns an empty string for 0
+func itoa(i int) string {
+	if i == 0 {
+		return ""
+	}
+	return strconv.Itoa(i)
+}
@@ -0,0 +1,187 @@
+/*
+Package timetracking builds timesheets from the time logged on incidents.
+
+A Reporter requests /timetracking over a date range in chunks, resolves each entry's category
+and cost per hour and returns the entries. Timesheet groups them by week, agent, incident and
+category and Check finds overlapping or missing time logged by the same agent.
+
+	r := timetracking.NewReporter(client, timetracking.Options{})
+	entries, err := r.Entries(from, to)
+	if err != nil {
+		return err
+	}
+
+	rows := timetracking.Timesheet(entries, timetracking.ByWeek, timetracking.ByUser)
+	err = timetracking.WriteCSV(os.Stdout, rows)
+*/
+package timetracking
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/endpoints"
+)
+
+// DefaultChunk is the length of the date range requested at once when Options.Chunk is not set
+var DefaultChunk = 7 * 24 * time.Hour
+
+// dateLayouts are the formats /timetracking may return dates in
+var dateLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02 15:04"}
+
+type (
+	// Entry is a period of time logged on an incident
+	Entry struct {
+		ID         int           `json:"id"`
+		IncidentID int           `json:"incident_id"`
+		UserID     int           `json:"user_id"`
+		CategoryID int           `json:"category_id,omitempty"`
+		Category   string        `json:"category,omitempty"`
+		From       time.Time     `json:"from"`
+		To         time.Time     `json:"to"`
+		Duration   time.Duration `json:"duration"`
+		// Cost is the duration in hours multiplied by the category's cost per hour
+		Cost    float64 `json:"cost"`
+		Comment string  `json:"comment,omitempty"`
+	}
+
+	// Options is used to configure a Reporter
+	Options struct {
+		// Chunk is the length of each date range requested. If 0 DefaultChunk is used.
+		Chunk time.Duration
+		// Location is used for dates returned without a time zone. If nil UTC is used.
+		Location *time.Location
+	}
+
+	// Reporter requests time tracking entries. Create it with NewReporter.
+	//
+	// Requires scopes: TimeTrackingGet, TimeTrackingAttributesCategoryGet
+	Reporter struct {
+		client *invgo.Client
+		opts   Options
+	}
+)
+
+// NewReporter creates a Reporter for client
+func NewReporter(client *invgo.Client, opts Options) *Reporter {
+	if opts.Chunk <= 0 {
+		opts.Chunk = DefaultChunk
+	}
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	return &Reporter{client: client, opts: opts}
+}
+
+// Entries returns the entries starting from from until to. The range is requested in chunks
+// and entries returned by more than one chunk or deleted are only returned once or not at all.
+// Entries are sorted by their start.
+func (r *Reporter) Entries(from, to time.Time) ([]Entry, error) {
+	if !to.After(from) {
+		return nil, fmt.Errorf("invalid range %s to %s", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+
+	cats, err := r.client.TimeTrackingAttributesCategory().Get(endpoints.TimeTrackingAttributesCategoryGetParams{})
+	if err != nil {
+		return nil, err
+	}
+	categories := map[int]endpoints.TimeTrackingAttributesCategoryGetResponse{}
+	for _, c := range cats {
+		categories[c.ID] = c
+	}
+
+	seen := map[int]bool{}
+	var entries []Entry
+	for start := from; start.Before(to); start = start.Add(r.opts.Chunk) {
+		end := start.Add(r.opts.Chunk)
+		if end.After(to) {
+			end = to
+		}
+
+		resp, err := r.client.TimeTracking().Get(endpoints.TimeTrackingGetParams{
+			From:       start.UTC().Format(time.RFC3339),
+			To:         end.UTC().Format(time.RFC3339),
+			DateFormat: "iso8601",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to get time tracking from %s: %w", start.Format(time.RFC3339), err)
+		}
+
+		for _, tt := range resp {
+			// Status is 0 for deleted entries
+			if seen[tt.TimetrackingID] || tt.Status == 0 {
+				continue
+			}
+			seen[tt.TimetrackingID] = true
+
+			e, err := r.entry(tt, categories)
+			if err != nil {
+				return nil, err
+			}
+			if !e.From.Before(from) && e.From.Before(to) {
+				entries = append(entries, e)
+			}
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].From.Before(entries[j].From) })
+	return entries, nil
+}
+
+func (r *Reporter) entry(tt endpoints.TimeTrackingGetResponse, categories map[int]endpoints.TimeTrackingAttributesCategoryGetResponse) (Entry, error) {
+	from, err := r.parse(tt.From)
+	if err != nil {
+		return Entry{}, fmt.Errorf("time tracking %d: %w", tt.TimetrackingID, err)
+	}
+	to, err := r.parse(tt.To)
+	if err != nil {
+		return Entry{}, fmt.Errorf("time tracking %d: %w", tt.TimetrackingID, err)
+	}
+
+	d := time.Duration(tt.Total) * time.Second
+	if d == 0 {
+		d = to.Sub(from)
+	}
+	cat := categories[tt.TimetrackingCategoryID]
+	return Entry{
+		ID:         tt.TimetrackingID,
+		IncidentID: tt.Incident,
+		UserID:     tt.UserID,
+		CategoryID: tt.TimetrackingCategoryID,
+		Category:   cat.Name,
+		From:       from,
+		To:         to,
+		Duration:   d,
+		Cost:       d.Hours() * cat.CostPerHour,
+		Comment:    tt.Comment,
+	}, nil
+}
+
+// parse reads an epoch or a date in one of dateLayouts
+func (r *Reporter) parse(v string) (time.Time, error) {
+	if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return time.Unix(n, 0).In(r.opts.Location), nil
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, v, r.opts.Location); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", v)
+}
+
+// users returns the entries grouped by user sorted by their start
+func users(entries []Entry) map[int][]Entry {
+	byUser := map[int][]Entry{}
+	for _, e := range entries {
+		byUser[e.UserID] = append(byUser[e.UserID], e)
+	}
+	for _, es := range byUser {
+		slices.SortStableFunc(es, func(a, b Entry) int { return a.From.Compare(b.From) })
+	}
+	return byUser
+}
@@ -0,0 +1,146 @@
+package timetracking_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmstorm/invgo/endpoints"
+	"github.com/tmstorm/invgo/invgotest"
+	"github.com/tmstorm/invgo/scopes"
+	"github.com/tmstorm/invgo/timetracking"
+)
+
+func TestReporterEntries(t *testing.T) {
+	a := assert.New(t)
+
+	srv := invgotest.NewServer(t)
+	cat := srv.AddTimeTrackingCategory(endpoints.TimeTrackingAttributesCategoryGetResponse{Name: "Support", CostPerHour: 50})
+
+	// Monday 6 January 2025
+	monday := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	log := func(user, inc int, from time.Time, d time.Duration) int {
+		return srv.AddTimeTracking(endpoints.TimeTrackingPostParams{
+			UserID:     user,
+			RequestID:  inc,
+			CategoryID: cat,
+			From:       int(from.Unix()),
+			To:         int(from.Add(d).Unix()),
+		})
+	}
+	first := log(1, 10, monday, 2*time.Hour)
+	// spans the boundary of the first two chunks
+	log(1, 11, monday.AddDate(0, 0, 1).Add(-time.Hour), 2*time.Hour)
+	log(2, 10, monday.AddDate(0, 0, 7), 30*time.Minute)
+	log(2, 10, monday.AddDate(0, 0, 30), time.Hour)
+
+	r := timetracking.NewReporter(
+		srv.Client(t, scopes.TimeTrackingGet, scopes.TimeTrackingAttributesCategoryGet),
+		timetracking.Options{Chunk: 24 * time.Hour},
+	)
+	entries, err := r.Entries(monday, monday.AddDate(0, 0, 14))
+	a.NoError(err)
+	if !a.Len(entries, 3) {
+		return
+	}
+
+	e := entries[0]
+	a.Equal(first, e.ID)
+	a.Equal("Support", e.Category)
+	a.True(monday.Equal(e.From))
+	a.Equal(2*time.Hour, e.Duration)
+	a.InDelta(100.0, e.Cost, 0.001)
+
+	_, err = r.Entries(monday, monday)
+	a.Error(err)
+}
+
+func TestTimesheet(t *testing.T) {
+	a := assert.New(t)
+
+	monday := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	entries := []timetracking.Entry{
+		{ID: 1, UserID: 1, IncidentID: 10, From: monday, Duration: time.Hour, Cost: 50},
+		{ID: 2, UserID: 1, IncidentID: 11, From: monday.AddDate(0, 0, 4), Duration: 2 * time.Hour, Cost: 100},
+		{ID: 3, UserID: 2, IncidentID: 10, From: monday.AddDate(0, 0, 6), Duration: time.Hour, Cost: 50},
+		{ID: 4, UserID: 1, IncidentID: 10, From: monday.AddDate(0, 0, 7), Duration: time.Hour, Cost: 50},
+	}
+
+	rows := timetracking.Timesheet(entries, timetracking.ByWeek, timetracking.ByUser)
+	if !a.Len(rows, 3) {
+		return
+	}
+	a.Equal(time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC), rows[0].Week)
+	a.Equal(1, rows[0].UserID)
+	a.Equal(3*time.Hour, rows[0].Duration)
+	a.Equal(150.0, rows[0].Cost)
+	a.Equal(2, rows[0].Entries)
+	a.Equal(2, rows[1].UserID)
+	a.Equal(time.Date(2025, time.January, 13, 0, 0, 0, 0, time.UTC), rows[2].Week)
+
+	rows = timetracking.Timesheet(entries, timetracking.ByIncident)
+	a.Len(rows, 2)
+	a.Equal(10, rows[0].IncidentID)
+	a.Equal(3*time.Hour, rows[0].Duration)
+	a.Zero(rows[0].UserID)
+
+	var buf bytes.Buffer
+	a.NoError(timetracking.WriteCSV(&buf, rows))
+	a.Equal("week,user_id,incident_id,category_id,category,hours,cost,entries\n"+
+		",,10,,,3.00,150.00,3\n"+
+		",,11,,,2.00,100.00,1\n", buf.String())
+
+	buf.Reset()
+	a.NoError(timetracking.WriteJSON(&buf, rows))
+	var decoded []map[string]any
+	a.NoError(json.Unmarshal(buf.Bytes(), &decoded))
+	a.Len(decoded, 2)
+	a.NotContains(decoded[0], "week")
+}
+
+func TestCheck(t *testing.T) {
+	a := assert.New(t)
+
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2025, time.January, day, hour, min, 0, 0, time.UTC)
+	}
+	entries := []timetracking.Entry{
+		{ID: 1, UserID: 1, From: at(6, 9, 0), To: at(6, 10, 0)},
+		{ID: 2, UserID: 1, From: at(6, 9, 30), To: at(6, 11, 0)},
+		{ID: 3, UserID: 1, From: at(6, 14, 0), To: at(6, 15, 0)},
+		// the gap overnight is not reported
+		{ID: 4, UserID: 1, From: at(7, 9, 0), To: at(7, 10, 0)},
+		{ID: 5, UserID: 2, From: at(6, 9, 0), To: at(6, 10, 0)},
+	}
+
+	issues := timetracking.Check(entries, time.Hour)
+	if !a.Len(issues, 2) {
+		return
+	}
+	a.Equal(timetracking.Overlap, issues[0].Kind)
+	a.Equal(30*time.Minute, issues[0].Duration)
+	a.Equal(2, issues[0].Second.ID)
+	a.Equal(timetracking.Gap, issues[1].Kind)
+	a.Equal(3*time.Hour, issues[1].Duration)
+
+	a.Len(timetracking.Check(entries, 0), 1)
+
+	// entries nested in a longer entry overlap it and leave no gap
+	nested := []timetracking.Entry{
+		{ID: 1, UserID: 1, From: at(6, 9, 0), To: at(6, 17, 0)},
+		{ID: 2, UserID: 1, From: at(6, 10, 0), To: at(6, 11, 0)},
+		{ID: 3, UserID: 1, From: at(6, 12, 0), To: at(6, 13, 0)},
+	}
+	issues = timetracking.Check(nested, time.Hour)
+	if !a.Len(issues, 2) {
+		return
+	}
+	for i, id := range []int{2, 3} {
+		a.Equal(timetracking.Overlap, issues[i].Kind)
+		a.Equal(1, issues[i].First.ID)
+		a.Equal(id, issues[i].Second.ID)
+		a.Equal(time.Hour, issues[i].Duration)
+	}
+}
@@ -0,0 +1,233 @@
+package invgo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/tmstorm/invgo/scopes"
+	"golang.org/x/oauth2"
+)
+
+// DefaultTokenRefreshWindow is how long before a cached token expires it is refreshed
+// when Invgate.TokenRefreshWindow is not set
+var DefaultTokenRefreshWindow = time.Minute
+
+type (
+	// TokenCache persists OAuth2 tokens so they can be reused by later clients and processes.
+	// Load must return a nil token and nil error if no token is cached for key.
+	TokenCache interface {
+		Load(key string) (*oauth2.Token, error)
+		Store(key string, tok *oauth2.Token) error
+	}
+
+	// FileTokenCache stores each token as a file in Dir readable only by the current user.
+	// If Dir is empty the invgo directory in os.UserCacheDir is used.
+	FileTokenCache struct {
+		Dir string
+	}
+
+	// MemoryTokenCache stores tokens in memory. It can be shared by clients in the same process.
+	MemoryTokenCache struct {
+		mu     sync.Mutex
+		tokens map[string]*oauth2.Token
+	}
+
+	// cachedToken is the format of a token stored by FileTokenCache.
+	// The granted scope is stored separately because oauth2.Token does not encode its extra fields.
+	cachedToken struct {
+		*oauth2.Token
+		Scope string `json:"scope,omitempty"`
+	}
+
+	// cachingTokenSource returns tokens from cache until they are within window of expiring
+	cachingTokenSource struct {
+		key    string
+		cache  TokenCache
+		src    oauth2.TokenSource
+		window time.Duration
+
+		mu  sync.Mutex
+		tok *oauth2.Token
+	}
+
+	// tokenSourceFunc allows a function to be used as an oauth2.TokenSource
+	tokenSourceFunc func() (*oauth2.Token, error)
+)
+
+// TokenCacheKey returns the key used to cache tokens for cfg.
+// It is derived from the BaseURL, TokenURL, ClientID and requested scopes so clients with
+// different scopes or token endpoints never share a token.
+func TokenCacheKey(cfg *Invgate) string {
+	scps := scopes.CreateScopes(cfg.Scopes)
+	slices.Sort(scps)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%s\n", cfg.BaseURL, cfg.TokenURL, cfg.ClientID)
+	for _, s := range scps {
+		fmt.Fprintf(h, "%s\n", s)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Load reads the token stored for key
+func (c *FileTokenCache) Load(key string) (*oauth2.Token, error) {
+	dir, err := c.dir()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, key+".json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ct cachedToken
+	if err := json.Unmarshal(b, &ct); err != nil {
+		return nil, fmt.Errorf("invalid cached token: %w", err)
+	}
+	if ct.Token == nil {
+		return nil, nil
+	}
+	if ct.Scope != "" {
+		return ct.WithExtra(map[string]any{"scope": ct.Scope}), nil
+	}
+	return ct.Token, nil
+}
+
+// Store writes tok for key. The file is written to a temporary file first and renamed
+// so other processes never read a partially written token.
+func (c *FileTokenCache) Store(key string, tok *oauth2.Token) error {
+	dir, err := c.dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	ct := cachedToken{Token: tok}
+	if s, ok := tok.Extra("scope").(string); ok {
+		ct.Scope = s
+	}
+	b, err := json.Marshal(ct)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err := f.Chmod(0o600); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(dir, key+".json"))
+}
+
+func (c *FileTokenCache) dir() (string, error) {
+	if c.Dir != "" {
+		return c.Dir, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to find token cache directory: %w", err)
+	}
+	return filepath.Join(dir, "invgo"), nil
+}
+
+// Load returns the token stored for key
+func (c *MemoryTokenCache) Load(key string) (*oauth2.Token, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tokens[key], nil
+}
+
+// Store stores tok for key
+func (c *MemoryTokenCache) Store(key string, tok *oauth2.Token) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tokens == nil {
+		c.tokens = map[string]*oauth2.Token{}
+	}
+	c.tokens[key] = tok
+	return nil
+}
+
+// newCachingTokenSource wraps src so tokens are loaded from and stored in cache.
+// src must fetch a new token on every call.
+func newCachingTokenSource(cfg *Invgate, src oauth2.TokenSource) oauth2.TokenSource {
+	window := cfg.TokenRefreshWindow
+	if window <= 0 {
+		window = DefaultTokenRefreshWindow
+	}
+	return &cachingTokenSource{
+		key:    TokenCacheKey(cfg),
+		cache:  cfg.TokenCache,
+		src:    src,
+		window: window,
+	}
+}
+
+// Token returns the current token, a cached token or a new token in that order.
+// A token is only reused if it does not expire within the refresh window.
+func (s *cachingTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.fresh(s.tok) {
+		return s.tok, nil
+	}
+
+	tok, err := s.cache.Load(s.key)
+	if err != nil {
+		log.Printf("[INVGO] WARNING: unable to load cached token: %s", err)
+	}
+	if s.fresh(tok) {
+		s.tok = tok
+		return tok, nil
+	}
+
+	tok, err = s.src.Token()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cache.Store(s.key, tok); err != nil {
+		log.Printf("[INVGO] WARNING: unable to cache token: %s", err)
+	}
+	s.tok = tok
+	return tok, nil
+}
+
+// fresh returns true if tok is set and does not expire within the refresh window
+func (s *cachingTokenSource) fresh(tok *oauth2.Token) bool {
+	if tok == nil || tok.AccessToken == "" {
+		return false
+	}
+	return tok.Expiry.IsZero() || time.Until(tok.Expiry) > s.window
+}
+
+// Token calls f
+func (f tokenSourceFunc) Token() (*oauth2.Token, error) { return f() }
@@ -0,0 +1,112 @@
+package invgo_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+// newCountingTokenServer returns a server issuing tokens that expire in expiresIn seconds
+// and the amount of tokens it has issued
+func newCountingTokenServer(t *testing.T, expiresIn int) (*httptest.Server, *atomic.Int32) {
+	var issued atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/oauth/token":
+			n := issued.Add(1)
+			fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":%d,"scope":"%s"}`, n, expiresIn, scopes.ServiceDeskVersionGet)
+		case invgo.InvgateAPIPath + "/sd.version":
+			w.Write([]byte(`{"version":"` + r.Header.Get("Authorization") + `"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server, &issued
+}
+
+func TestFileTokenCache(t *testing.T) {
+	a := assert.New(t)
+
+	server, issued := newCountingTokenServer(t, 3600)
+	defer server.Close()
+
+	dir := filepath.Join(t.TempDir(), "tokens")
+	newClient := func() *invgo.Client {
+		cfg := newInstanceConfig(server, scopes.ServiceDeskVersionGet)
+		cfg.TokenCache = &invgo.FileTokenCache{Dir: dir}
+		cfg.ValidateScopes = true
+		cfg.StrictScopes = true
+
+		c, err := invgo.New(cfg)
+		a.NoError(err)
+		return c
+	}
+
+	// every client shares the first token including its granted scopes
+	for range 3 {
+		v, err := newClient().ServiceDeskVersion().Get()
+		a.NoError(err)
+		a.Equal("Bearer token-1", v)
+	}
+	a.Equal(int32(1), issued.Load())
+
+	key := invgo.TokenCacheKey(newInstanceConfig(server, scopes.ServiceDeskVersionGet))
+	info, err := os.Stat(filepath.Join(dir, key+".json"))
+	a.NoError(err)
+	a.Equal(os.FileMode(0o600), info.Mode().Perm())
+
+	info, err = os.Stat(dir)
+	a.NoError(err)
+	a.Equal(os.FileMode(0o700), info.Mode().Perm())
+
+	// a different scope set uses a different key
+	a.NotEqual(key, invgo.TokenCacheKey(newInstanceConfig(server, scopes.ServiceDeskVersionGet, scopes.IncidentGet)))
+
+	// so does a different token endpoint
+	other := newInstanceConfig(server, scopes.ServiceDeskVersionGet)
+	other.TokenURL += "/other"
+	a.NotEqual(key, invgo.TokenCacheKey(other))
+}
+
+func TestTokenCacheRefreshWindow(t *testing.T) {
+	a := assert.New(t)
+
+	// tokens expire in 30 seconds which is inside the refresh window
+	server, issued := newCountingTokenServer(t, 30)
+	defer server.Close()
+
+	cfg := newInstanceConfig(server, scopes.ServiceDeskVersionGet)
+	cfg.TokenCache = &invgo.MemoryTokenCache{}
+	cfg.TokenRefreshWindow = time.Minute
+
+	c, err := invgo.New(cfg)
+	a.NoError(err)
+
+	for i := range 2 {
+		v, err := c.ServiceDeskVersion().Get()
+		a.NoError(err)
+		a.Equal(fmt.Sprintf("Bearer token-%d", i+1), v)
+	}
+	a.Equal(int32(2), issued.Load())
+
+	// outside the refresh window the token is reused
+	cfg.TokenRefreshWindow = 10 * time.Second
+	c, err = invgo.New(cfg)
+	a.NoError(err)
+	for range 2 {
+		v, err := c.ServiceDeskVersion().Get()
+		a.NoError(err)
+		a.Equal("Bearer token-2", v)
+	}
+	a.Equal(int32(2), issued.Load())
+}
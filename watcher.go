@@ -0,0 +1,507 @@
+package invgo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tmstorm/invgo/endpoints"
+)
+
+// DefaultWatchInterval is how often a Watcher polls when WatcherOptions.Interval is not set
+var DefaultWatchInterval = time.Minute
+
+// DefaultWatchRetention is how long a Watcher keeps the state of an incident that has not
+// changed when WatcherOptions.Retention is not set
+var DefaultWatchRetention = 7 * 24 * time.Hour
+
+// DefaultWatchBatchSize is the max amount of incidents requested at once when comments are
+// watched and WatcherOptions.BatchSize is not set
+var DefaultWatchBatchSize = 100
+
+// watchWindow is how far back /incidents.last.hour returns changes. Changes are missed
+// if the time between two polls is longer than this.
+const watchWindow = time.Hour
+
+// EventType is the type of change a Watcher found
+type EventType string
+
+const (
+	// EventCreated is emitted for an incident created since the last poll
+	EventCreated EventType = "created"
+	// EventStatusChanged is emitted when the status of an incident changes
+	EventStatusChanged EventType = "status_changed"
+	// EventReassigned is emitted when the agent or help desk of an incident changes
+	EventReassigned EventType = "reassigned"
+	// EventCommentAdded is emitted for every new comment when WatcherOptions.Comments is set
+	EventCommentAdded EventType = "comment_added"
+	// EventSolved is emitted when an incident is solved
+	EventSolved EventType = "solved"
+	// EventClosed is emitted when the closed date of an incident is set. Invgate may not set a
+	// closed date when an incident is cancelled or rejected, use EventStatusChanged to detect them.
+	EventClosed EventType = "closed"
+)
+
+type (
+	// Event is a change to an incident found by a Watcher
+	Event struct {
+		Type EventType `json:"type"`
+		// Incident is the incident after the change
+		Incident endpoints.Incident `json:"incident"`
+		// Previous is the last state seen before the change. It is empty for EventCreated and
+		// for incidents the Watcher had not seen before, which emit EventStatusChanged and
+		// EventReassigned for their current status and assignment.
+		Previous IncidentState `json:"previous"`
+		// Comment is the comment added for EventCommentAdded
+		Comment *endpoints.IncidentCommentResponse `json:"comment,omitempty"`
+	}
+
+	// IncidentState is the part of an incident a Watcher compares to find changes
+	IncidentState struct {
+		LastUpdate      int `json:"last_update,omitempty"`
+		StatusID        int `json:"status_id,omitempty"`
+		AssignedID      int `json:"assigned_id,omitempty"`
+		AssignedGroupID int `json:"assigned_group_id,omitempty"`
+		SolvedAt        int `json:"solved_at,omitempty"`
+		ClosedAt        int `json:"closed_at,omitempty"`
+		// LastCommentID is the highest comment ID seen. It is only set when comments are watched.
+		LastCommentID int `json:"last_comment_id,omitempty"`
+		// SeenAt is when the state last changed
+		SeenAt time.Time `json:"seen_at"`
+	}
+
+	// Checkpoint is the state a Watcher resumes from
+	Checkpoint struct {
+		// PolledAt is when the last poll completed
+		PolledAt time.Time `json:"polled_at"`
+		// Incidents is the last state seen for each incident by ID
+		Incidents map[int]IncidentState `json:"incidents"`
+	}
+
+	// CheckpointStore persists Watcher checkpoints so a Watcher can resume after a restart.
+	// Load must return a nil Checkpoint and nil error if nothing is stored for key.
+	CheckpointStore interface {
+		Load(key string) (*Checkpoint, error)
+		Save(key string, cp *Checkpoint) error
+	}
+
+	// FileCheckpointStore stores each checkpoint as a JSON file in Dir.
+	// If Dir is empty the invgo directory in os.UserCacheDir is used.
+	FileCheckpointStore struct {
+		Dir string
+	}
+
+	// MemoryCheckpointStore stores checkpoints in memory
+	MemoryCheckpointStore struct {
+		mu          sync.Mutex
+		checkpoints map[string]*Checkpoint
+	}
+
+	// WatcherOptions is used to configure a Watcher
+	WatcherOptions struct {
+		// Interval is the time between polls. If 0 DefaultWatchInterval is used.
+		// It should be well under an hour so no changes are missed.
+		Interval time.Duration
+		// Store persists the checkpoint after every poll. If nil a MemoryCheckpointStore is used.
+		Store CheckpointStore
+		// Key identifies the checkpoint in Store. If empty "incidents" is used.
+		Key string
+		// Comments requests the comments of changed incidents to emit EventCommentAdded.
+		Comments bool
+		// BatchSize is the max amount of incidents requested at once when Comments is set.
+		// If 0 DefaultWatchBatchSize is used.
+		BatchSize int
+		// Retention is how long the state of an unchanged incident is kept.
+		// If 0 DefaultWatchRetention is used.
+		Retention time.Duration
+		// Buffer is the size of the Events channel
+		Buffer int
+		// OnError is called when a poll fails. If nil the error is logged.
+		// The Watcher keeps polling after an error.
+		OnError func(error)
+	}
+
+	// Watcher polls Invgate for incidents changed in the last hour and emits an Event for
+	// every change it finds compared to the last state it saw. Create it with NewWatcher.
+	//
+	// Requires scopes: IncidentsLastHourGet and IncidentsGet if comments are watched
+	Watcher struct {
+		client *Client
+		opts   WatcherOptions
+		events chan Event
+
+		mu sync.Mutex
+		cp *Checkpoint
+	}
+)
+
+// NewWatcher creates a Watcher for client. Nothing is requested until Poll or Run is called.
+func NewWatcher(client *Client, opts WatcherOptions) *Watcher {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultWatchInterval
+	}
+	if opts.Store == nil {
+		opts.Store = &MemoryCheckpointStore{}
+	}
+	if opts.Key == "" {
+		opts.Key = "incidents"
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultWatchBatchSize
+	}
+	if opts.Retention <= 0 {
+		opts.Retention = DefaultWatchRetention
+	}
+	if opts.OnError == nil {
+		opts.OnError = func(err error) {
+			log.Printf("[INVGO] WARNING: incident watcher poll failed: %s", err)
+		}
+	}
+	return &Watcher{
+		client: client,
+		opts:   opts,
+		events: make(chan Event, opts.Buffer),
+	}
+}
+
+// Events returns the channel events are sent on by Run. It is closed when Run returns.
+func (w *Watcher) Events() <-chan Event {
+	return w.events
+}
+
+// Run polls every interval and sends the events found on Events until ctx is done.
+// The checkpoint is only saved once every event from a poll has been sent so events
+// are delivered at least once across restarts.
+func (w *Watcher) Run(ctx context.Context) error {
+	defer close(w.events)
+
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		events, cp, err := w.poll()
+		if err != nil {
+			w.opts.OnError(err)
+		}
+		for _, e := range events {
+			select {
+			case w.events <- e:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if cp != nil {
+			if err := w.commit(cp); err != nil {
+				w.opts.OnError(err)
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Poll checks for changes once, saves the checkpoint and returns the events found.
+// It can be used instead of Run when polling is scheduled elsewhere.
+//
+// The first poll without a stored checkpoint records the incidents changed in the last
+// hour without returning events for them.
+func (w *Watcher) Poll() ([]Event, error) {
+	events, cp, err := w.poll()
+	if err != nil {
+		return nil, err
+	}
+	return events, w.commit(cp)
+}
+
+// poll returns the events since the current checkpoint and the checkpoint to save once
+// they have been handled
+func (w *Watcher) poll() ([]Event, *Checkpoint, error) {
+	prev, err := w.checkpoint()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	if !prev.PolledAt.IsZero() && now.Sub(prev.PolledAt) > watchWindow {
+		log.Printf("[INVGO] WARNING: incident watcher last polled at %s, changes made before %s were missed",
+			prev.PolledAt.Format(time.RFC3339), now.Add(-watchWindow).Format(time.RFC3339))
+	}
+
+	recent, err := w.client.IncidentsLastHour().Get(endpoints.IncidentsLastHourGetParams{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var changed []int
+	for _, r := range recent {
+		s, seen := prev.Incidents[r.ID]
+		if !seen || s.state(r.Incident) != s {
+			changed = append(changed, r.ID)
+		}
+	}
+	incs, err := w.incidents(recent, changed)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	next := &Checkpoint{PolledAt: now, Incidents: make(map[int]IncidentState, len(prev.Incidents))}
+	for id, s := range prev.Incidents {
+		if now.Sub(s.SeenAt) < w.opts.Retention {
+			next.Incidents[id] = s
+		}
+	}
+
+	var events []Event
+	for _, id := range changed {
+		inc := incs[id]
+		s, seen := prev.Incidents[id]
+		cur := s.state(inc)
+		cur.SeenAt = now
+		if w.opts.Comments {
+			cur.LastCommentID = lastCommentID(inc.Comments)
+		}
+		next.Incidents[id] = cur
+
+		if !seen {
+			switch {
+			case prev.PolledAt.IsZero():
+				// the first poll only records the state of the incidents
+			case int64(inc.CreatedAt) >= prev.PolledAt.Unix():
+				events = append(events, Event{Type: EventCreated, Incident: inc})
+			default:
+				// an incident created before the last poll that changed since
+				events = append(events, diffUnseen(inc, prev.PolledAt, w.opts.Comments)...)
+			}
+			continue
+		}
+		events = append(events, diff(s, inc, w.opts.Comments)...)
+	}
+
+	return events, next, nil
+}
+
+// incidents returns the incidents in recent by ID. If comments are watched the changed
+// incidents are requested again with their comments.
+func (w *Watcher) incidents(recent []endpoints.IncidentsLastHourGetResponse, changed []int) (map[int]endpoints.Incident, error) {
+	incs := make(map[int]endpoints.Incident, len(recent))
+	for _, r := range recent {
+		incs[r.ID] = r.Incident
+	}
+	if !w.opts.Comments {
+		return incs, nil
+	}
+
+	for batch := range slices.Chunk(changed, w.opts.BatchSize) {
+		resp, err := w.client.Incidents().Get(endpoints.IncidentsGetParams{IDs: batch, IncludeComments: true})
+		if err != nil {
+			return nil, err
+		}
+		for _, inc := range resp {
+			incs[inc.ID] = inc
+		}
+	}
+	return incs, nil
+}
+
+// checkpoint returns the current checkpoint loading it from the store on first use
+func (w *Watcher) checkpoint() (*Checkpoint, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cp != nil {
+		return w.cp, nil
+	}
+	cp, err := w.opts.Store.Load(w.opts.Key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load checkpoint: %w", err)
+	}
+	if cp == nil {
+		cp = &Checkpoint{}
+	}
+	if cp.Incidents == nil {
+		cp.Incidents = map[int]IncidentState{}
+	}
+	w.cp = cp
+	return cp, nil
+}
+
+// commit saves cp to the store and uses it for the next poll
+func (w *Watcher) commit(cp *Checkpoint) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.cp = cp
+	if err := w.opts.Store.Save(w.opts.Key, cp); err != nil {
+		return fmt.Errorf("unable to save checkpoint: %w", err)
+	}
+	return nil
+}
+
+// state returns s updated with the fields of inc
+func (s IncidentState) state(inc endpoints.Incident) IncidentState {
+	s.LastUpdate = inc.LastUpdate
+	s.StatusID = inc.StatusID
+	s.AssignedID = inc.AssignedID
+	s.AssignedGroupID = inc.AssignedGroupID
+	s.SolvedAt = inc.SolvedAt
+	s.ClosedAt = inc.ClosedAt
+	return s
+}
+
+// diff returns the events between the previous state and inc.
+// EventStatusChanged is emitted alongside EventSolved and EventClosed.
+func diff(prev IncidentState, inc endpoints.Incident, comments bool) []Event {
+	var events []Event
+	add := func(t EventType) {
+		events = append(events, Event{Type: t, Incident: inc, Previous: prev})
+	}
+
+	if inc.StatusID != prev.StatusID {
+		add(EventStatusChanged)
+	}
+	if inc.AssignedID != prev.AssignedID || inc.AssignedGroupID != prev.AssignedGroupID {
+		add(EventReassigned)
+	}
+	if comments {
+		cs := slices.Clone(inc.Comments)
+		sort.Slice(cs, func(i, j int) bool { return cs[i].ID < cs[j].ID })
+		for _, c := range cs {
+			if c.ID > prev.LastCommentID {
+				events = append(events, Event{Type: EventCommentAdded, Incident: inc, Previous: prev, Comment: &c})
+			}
+		}
+	}
+	if inc.SolvedAt != 0 && inc.SolvedAt != prev.SolvedAt {
+		add(EventSolved)
+	}
+	if inc.ClosedAt != 0 && inc.ClosedAt != prev.ClosedAt {
+		add(EventClosed)
+	}
+	return events
+}
+
+// diffUnseen returns the events of an incident that changed since the last poll but was not
+// seen before. Its previous state is unknown so it is compared to an empty state, leaving out
+// the comments, solve and close that happened before since.
+func diffUnseen(inc endpoints.Incident, since time.Time, comments bool) []Event {
+	var events []Event
+	for _, e := range diff(IncidentState{}, inc, comments) {
+		at := since.Unix()
+		switch e.Type {
+		case EventCommentAdded:
+			at = int64(e.Comment.CreatedAt)
+		case EventSolved:
+			at = int64(inc.SolvedAt)
+		case EventClosed:
+			at = int64(inc.ClosedAt)
+		}
+		if at >= since.Unix() {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+func lastCommentID(comments []endpoints.IncidentCommentResponse) int {
+	id := 0
+	for _, c := range comments {
+		id = max(id, c.ID)
+	}
+	return id
+}
+
+// Load reads the checkpoint stored for key
+func (s *FileCheckpointStore) Load(key string) (*Checkpoint, error) {
+	dir, err := s.dir()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, key+".checkpoint.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var cp Checkpoint
+	if err := json.Unmarshal(b, &cp); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint: %w", err)
+	}
+	return &cp, nil
+}
+
+// Save writes cp for key. The file is written to a temporary file first and renamed
+// so a crash never leaves a partially written checkpoint.
+func (s *FileCheckpointStore) Save(key string, cp *Checkpoint) error {
+	dir, err := s.dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(dir, key+".checkpoint.json"))
+}
+
+func (s *FileCheckpointStore) dir() (string, error) {
+	if s.Dir != "" {
+		return s.Dir, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to find checkpoint directory: %w", err)
+	}
+	return filepath.Join(dir, "invgo"), nil
+}
+
+// Load returns the checkpoint stored for key
+func (s *MemoryCheckpointStore) Load(key string) (*Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.checkpoints[key], nil
+}
+
+// Save stores cp for key
+func (s *MemoryCheckpointStore) Save(key string, cp *Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.checkpoints == nil {
+		s.checkpoints = map[string]*Checkpoint{}
+	}
+	s.checkpoints[key] = cp
+	return nil
+}
@@ -0,0 +1,176 @@
+package invgo_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/endpoints"
+	"github.com/tmstorm/invgo/invgotest"
+	"github.com/tmstorm/invgo/scopes"
+)
+
+func eventTypes(events []invgo.Event) []invgo.EventType {
+	var types []invgo.EventType
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+func TestWatcherPoll(t *testing.T) {
+	a := assert.New(t)
+
+	srv := invgotest.NewServer(t)
+	existing := srv.AddIncident(endpoints.Incident{Title: "Printer on fire"})
+	desk := srv.AddHelpDesk(endpoints.HelpDesksGetResponse{Name: "Service Desk"})
+
+	c := srv.Client(t, scopes.IncidentsLastHourGet, scopes.IncidentsGet, scopes.IncidentPost,
+		scopes.IncidentCommentPost, scopes.IncidentReassignPost, scopes.IncidentCancelPost)
+	w := invgo.NewWatcher(c, invgo.WatcherOptions{Comments: true})
+
+	// the first poll records existing incidents without events
+	events, err := w.Poll()
+	a.NoError(err)
+	a.Empty(events)
+
+	resp, err := c.Incident().Post(endpoints.IncidentPostParams{Title: "Monitor missing", CustomerID: 1, CreatorID: 1, TypeID: 1, PriorityID: 1})
+	a.NoError(err)
+	events, err = w.Poll()
+	a.NoError(err)
+	a.Equal([]invgo.EventType{invgo.EventCreated}, eventTypes(events))
+	a.Equal("Monitor missing", events[0].Incident.Title)
+	created, err := strconv.Atoi(resp.RequestID)
+	a.NoError(err)
+
+	// nothing changed
+	events, err = w.Poll()
+	a.NoError(err)
+	a.Empty(events)
+
+	_, err = c.IncidentReassign().Post(endpoints.IncidentReassignPostParams{RequestID: existing, AuthorID: 1, GroupID: desk, AgentID: 2})
+	a.NoError(err)
+	_, err = c.IncidentComment().Post(endpoints.IncidentCommentPostParams{RequestID: existing, AuthorID: 2, Comment: "Put it out", IsSolution: true})
+	a.NoError(err)
+	events, err = w.Poll()
+	a.NoError(err)
+	a.Equal([]invgo.EventType{invgo.EventStatusChanged, invgo.EventReassigned, invgo.EventCommentAdded, invgo.EventSolved}, eventTypes(events))
+	a.Equal(invgotest.StatusNew, events[0].Previous.StatusID)
+	a.Equal(invgotest.StatusSolved, events[0].Incident.StatusID)
+	a.Equal("Put it out", events[2].Comment.Message)
+
+	_, err = c.IncidentCancel().Post(endpoints.IncidentCancelPostParams{RequestID: created, AuthorID: 1})
+	a.NoError(err)
+	events, err = w.Poll()
+	a.NoError(err)
+	a.Equal([]invgo.EventType{invgo.EventStatusChanged, invgo.EventClosed}, eventTypes(events))
+}
+
+func TestWatcherUnseenIncidents(t *testing.T) {
+	a := assert.New(t)
+
+	srv := invgotest.NewServer(t)
+	desk := srv.AddHelpDesk(endpoints.HelpDesksGetResponse{Name: "Service Desk"})
+	// created before the watcher started and not changed in the last hour
+	old := int(srv.Now().Add(-2 * time.Hour).Unix())
+	reassigned := srv.AddIncident(endpoints.Incident{Title: "Printer on fire", CreatedAt: old, LastUpdate: old})
+	solved := srv.AddIncident(endpoints.Incident{Title: "Monitor missing", CreatedAt: old, LastUpdate: old})
+	srv.AddComment(endpoints.IncidentCommentGetResponse{IncidentID: solved, AuthorID: 1, Message: "Old comment", CreatedAt: old})
+
+	c := srv.Client(t, scopes.IncidentsLastHourGet, scopes.IncidentsGet, scopes.IncidentReassignPost, scopes.IncidentCommentPost)
+	w := invgo.NewWatcher(c, invgo.WatcherOptions{Comments: true})
+
+	events, err := w.Poll()
+	a.NoError(err)
+	a.Empty(events)
+
+	_, err = c.IncidentReassign().Post(endpoints.IncidentReassignPostParams{RequestID: reassigned, AuthorID: 1, GroupID: desk, AgentID: 2})
+	a.NoError(err)
+	_, err = c.IncidentComment().Post(endpoints.IncidentCommentPostParams{RequestID: solved, AuthorID: 2, Comment: "Found it", IsSolution: true})
+	a.NoError(err)
+
+	events, err = w.Poll()
+	a.NoError(err)
+	byIncident := map[int][]invgo.Event{}
+	for _, e := range events {
+		byIncident[e.Incident.ID] = append(byIncident[e.Incident.ID], e)
+	}
+	a.Equal([]invgo.EventType{invgo.EventStatusChanged, invgo.EventReassigned}, eventTypes(byIncident[reassigned]))
+	a.Equal([]invgo.EventType{invgo.EventStatusChanged, invgo.EventCommentAdded, invgo.EventSolved}, eventTypes(byIncident[solved]))
+	for _, e := range byIncident[solved] {
+		a.Zero(e.Previous.StatusID)
+		if e.Type == invgo.EventCommentAdded {
+			a.Equal("Found it", e.Comment.Message)
+		}
+	}
+
+	// both incidents are known now
+	events, err = w.Poll()
+	a.NoError(err)
+	a.Empty(events)
+}
+
+func TestWatcherBatchSize(t *testing.T) {
+	a := assert.New(t)
+
+	srv := invgotest.NewServer(t)
+	c := srv.Client(t, scopes.IncidentsLastHourGet, scopes.IncidentsGet)
+	w := invgo.NewWatcher(c, invgo.WatcherOptions{Comments: true, BatchSize: 2})
+
+	_, err := w.Poll()
+	a.NoError(err)
+	for range 5 {
+		srv.AddIncident(endpoints.Incident{Title: "Batched"})
+	}
+	events, err := w.Poll()
+	a.NoError(err)
+	a.Len(events, 5)
+
+	batches := 0
+	for _, r := range srv.Requests() {
+		if r.Path == "/incidents" {
+			batches++
+		}
+	}
+	a.Equal(3, batches)
+}
+
+func TestWatcherResume(t *testing.T) {
+	a := assert.New(t)
+
+	srv := invgotest.NewServer(t)
+	id := srv.AddIncident(endpoints.Incident{Title: "Printer on fire"})
+	desk := srv.AddHelpDesk(endpoints.HelpDesksGetResponse{Name: "Service Desk"})
+	c := srv.Client(t, scopes.IncidentsLastHourGet, scopes.IncidentReassignPost)
+
+	store := &invgo.FileCheckpointStore{Dir: t.TempDir()}
+	_, err := invgo.NewWatcher(c, invgo.WatcherOptions{Store: store}).Poll()
+	a.NoError(err)
+
+	cp, err := store.Load("incidents")
+	a.NoError(err)
+	a.Contains(cp.Incidents, id)
+
+	_, err = c.IncidentReassign().Post(endpoints.IncidentReassignPostParams{RequestID: id, AuthorID: 1, GroupID: desk})
+	a.NoError(err)
+
+	// a new watcher resumes from the stored checkpoint and sends events on Events
+	w := invgo.NewWatcher(c, invgo.WatcherOptions{Store: store, Interval: time.Hour})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- w.Run(ctx) }()
+
+	var got []invgo.EventType
+	for e := range w.Events() {
+		got = append(got, e.Type)
+		if len(got) == 2 {
+			cancel()
+		}
+	}
+	a.ErrorIs(<-done, context.Canceled)
+	a.Equal([]invgo.EventType{invgo.EventStatusChanged, invgo.EventReassigned}, got)
+}
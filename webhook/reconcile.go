@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"sync"
+	"time"
+
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/endpoints"
+)
+
+// DefaultDeliveryRetention is how long a MemoryDeliveryStore keeps deliveries when
+// Retention is not set
+var DefaultDeliveryRetention = 7 * 24 * time.Hour
+
+type (
+	// Delivery is a trigger call that was received and handled
+	Delivery struct {
+		TriggerID   int       `json:"trigger_id"`
+		IncidentID  int       `json:"incident_id"`
+		ExecutionID int       `json:"execution_id,omitempty"`
+		ReceivedAt  time.Time `json:"received_at"`
+	}
+
+	// DeliveryStore records deliveries so they can be reconciled with trigger executions
+	DeliveryStore interface {
+		Record(d Delivery) error
+		// Deliveries returns the deliveries received at or after since
+		Deliveries(since time.Time) ([]Delivery, error)
+	}
+
+	// MemoryDeliveryStore stores deliveries in memory
+	MemoryDeliveryStore struct {
+		// Retention is how long deliveries are kept. If 0 DefaultDeliveryRetention is used.
+		Retention time.Duration
+
+		mu         sync.Mutex
+		deliveries []Delivery
+	}
+)
+
+// Reconcile returns the executions of the trigger with triggerID since the provided time
+// that were never delivered. If triggerID is 0 every trigger is checked.
+//
+// Requires scope: TriggersExecutionsGet
+func (r *Receiver) Reconcile(client *invgo.Client, triggerID int, since time.Time) ([]endpoints.TriggersExecutionsGetResponse, error) {
+	execs, err := client.TriggersExecutions().Get(endpoints.TriggersGetParams{TriggerID: triggerID})
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := r.opts.Deliveries.Deliveries(since)
+	if err != nil {
+		return nil, err
+	}
+
+	var recent []endpoints.TriggersExecutionsGetResponse
+	for _, e := range execs {
+		if int64(e.ExecutedAt) >= since.Unix() {
+			recent = append(recent, e)
+		}
+	}
+	return Missed(recent, deliveries), nil
+}
+
+// Missed returns the executions without a matching delivery.
+// An execution matches a delivery with its ID. Deliveries without an execution ID match
+// one execution of the same trigger for the same incident (RequestedID).
+func Missed(execs []endpoints.TriggersExecutionsGetResponse, deliveries []Delivery) []endpoints.TriggersExecutionsGetResponse {
+	type key struct{ trigger, incident int }
+
+	byExecution := map[int]bool{}
+	unmatched := map[key]int{}
+	for _, d := range deliveries {
+		if d.ExecutionID != 0 {
+			byExecution[d.ExecutionID] = true
+		} else {
+			unmatched[key{d.TriggerID, d.IncidentID}]++
+		}
+	}
+
+	var missed []endpoints.TriggersExecutionsGetResponse
+	for _, e := range execs {
+		if byExecution[e.ID] {
+			continue
+		}
+		k := key{e.TriggerID, e.RequestedID}
+		if unmatched[k] > 0 {
+			unmatched[k]--
+			continue
+		}
+		missed = append(missed, e)
+	}
+	return missed
+}
+
+// Record stores d and removes deliveries older than the retention
+func (s *MemoryDeliveryStore) Record(d Delivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	retention := s.Retention
+	if retention <= 0 {
+		retention = DefaultDeliveryRetention
+	}
+	cutoff := time.Now().Add(-retention)
+
+	kept := s.deliveries[:0]
+	for _, old := range s.deliveries {
+		if old.ReceivedAt.After(cutoff) {
+			kept = append(kept, old)
+		}
+	}
+	s.deliveries = append(kept, d)
+	return nil
+}
+
+// Deliveries returns the deliveries received at or after since
+func (s *MemoryDeliveryStore) Deliveries(since time.Time) ([]Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var ds []Delivery
+	for _, d := range s.deliveries {
+		if !d.ReceivedAt.Before(since) {
+			ds = append(ds, d)
+		}
+	}
+	return ds, nil
+}
@@ -0,0 +1,326 @@
+/*
+Package webhook receives the HTTP calls made by Invgate triggers.
+
+A Receiver is an http.Handler that checks each call carries the shared secret or a valid
+HMAC signature, decodes the payload into an Event and calls the handler registered for the
+trigger. Triggers should be configured to POST a JSON body with the trigger and incident:
+
+	{"trigger_id": 12, "trigger_name": "Incident solved", "incident_id": {{request.id}}}
+
+The full incident may be sent in "incident" instead of "incident_id". If only the ID is sent
+and Options.Client is set the incident is requested from Invgate.
+
+	rcv, err := webhook.NewReceiver(webhook.Options{Secret: os.Getenv("INVGATE_WEBHOOK_SECRET")})
+	if err != nil {
+		return err
+	}
+	rcv.HandleTriggerName("Incident solved", func(ctx context.Context, e webhook.Event) error {
+		return notify(e.Incident)
+	})
+	http.Handle("/invgate", rcv)
+
+Deliveries are recorded so Reconcile can compare them with /triggers.executions to find
+webhooks that never arrived.
+*/
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tmstorm/invgo"
+	"github.com/tmstorm/invgo/endpoints"
+)
+
+const (
+	// DefaultSecretHeader is the header checked for Options.Secret when SecretHeader is not set
+	DefaultSecretHeader = "X-Invgo-Secret"
+	// DefaultSignatureHeader is the header checked for the hex encoded HMAC-SHA256 of the body
+	// when SignatureHeader is not set. It may be prefixed with sha256=.
+	DefaultSignatureHeader = "X-Invgo-Signature"
+	// DefaultMaxBodyBytes is the largest body accepted when Options.MaxBodyBytes is not set
+	DefaultMaxBodyBytes = 1 << 20
+)
+
+// ErrUnauthorized is returned when a request has no valid secret or signature
+var ErrUnauthorized = errors.New("webhook: invalid secret or signature")
+
+type (
+	// Event is a decoded trigger call
+	Event struct {
+		TriggerID   int
+		TriggerName string
+		// ExecutionID is the ID of the trigger execution if the payload included it
+		ExecutionID int
+		// Incident is the incident the trigger ran for. Only ID is set if the payload
+		// only contained incident_id and Options.Client is not set.
+		Incident   endpoints.Incident
+		ReceivedAt time.Time
+		// Payload is the raw body so fields added to the trigger can be decoded
+		Payload json.RawMessage
+	}
+
+	// HandlerFunc handles an Event. Returning an error responds with a 500 so the
+	// delivery is not recorded.
+	HandlerFunc func(ctx context.Context, e Event) error
+
+	// Options is used to configure a Receiver. Secret or HMACKey must be set.
+	Options struct {
+		// Secret is compared with SecretHeader
+		Secret string
+		// AllowQuerySecret also accepts Secret in the secret query parameter for triggers that
+		// can not set headers. It is off by default since the query ends up in access logs.
+		AllowQuerySecret bool
+		// HMACKey is used to check the signature in SignatureHeader
+		HMACKey []byte
+		// SecretHeader defaults to DefaultSecretHeader
+		SecretHeader string
+		// SignatureHeader defaults to DefaultSignatureHeader
+		SignatureHeader string
+		// MaxBodyBytes defaults to DefaultMaxBodyBytes
+		MaxBodyBytes int64
+		// Client is used to request the incident when the payload only contains its ID.
+		// Requires scope: IncidentGet
+		Client *invgo.Client
+		// Deliveries records handled events for Reconcile. If nil a MemoryDeliveryStore is used.
+		Deliveries DeliveryStore
+		// OnError is called when a request is rejected or a handler fails. If nil the error is logged.
+		OnError func(error)
+	}
+
+	// Receiver is an http.Handler for Invgate trigger calls. Create it with NewReceiver.
+	Receiver struct {
+		opts Options
+
+		mu       sync.RWMutex
+		byID     map[int]HandlerFunc
+		byName   map[string]HandlerFunc
+		fallback HandlerFunc
+	}
+
+	// payload is the JSON body sent by a trigger
+	payload struct {
+		TriggerID   flexInt             `json:"trigger_id"`
+		TriggerName string              `json:"trigger_name"`
+		ExecutionID flexInt             `json:"execution_id"`
+		IncidentID  flexInt             `json:"incident_id"`
+		Incident    *endpoints.Incident `json:"incident"`
+	}
+
+	// flexInt decodes a JSON number or a string containing a number since trigger
+	// templates are often quoted
+	flexInt int
+)
+
+// NewReceiver creates a Receiver. An error is returned if neither Secret nor HMACKey is set.
+func NewReceiver(opts Options) (*Receiver, error) {
+	if opts.Secret == "" && len(opts.HMACKey) == 0 {
+		return nil, errors.New("webhook: Secret or HMACKey is required")
+	}
+	if opts.SecretHeader == "" {
+		opts.SecretHeader = DefaultSecretHeader
+	}
+	if opts.SignatureHeader == "" {
+		opts.SignatureHeader = DefaultSignatureHeader
+	}
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	if opts.Deliveries == nil {
+		opts.Deliveries = &MemoryDeliveryStore{}
+	}
+	if opts.OnError == nil {
+		opts.OnError = func(err error) {
+			log.Printf("[INVGO] WARNING: webhook: %s", err)
+		}
+	}
+	return &Receiver{
+		opts:   opts,
+		byID:   map[int]HandlerFunc{},
+		byName: map[string]HandlerFunc{},
+	}, nil
+}
+
+// HandleTrigger registers h for the trigger with id
+func (r *Receiver) HandleTrigger(id int, h HandlerFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byID[id] = h
+}
+
+// HandleTriggerName registers h for the trigger with name. A handler registered
+// by ID takes precedence.
+func (r *Receiver) HandleTriggerName(name string, h HandlerFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byName[name] = h
+}
+
+// HandleDefault registers h for triggers without a handler.
+// Events without a handler are still recorded as delivered.
+func (r *Receiver) HandleDefault(h HandlerFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fallback = h
+}
+
+// ServeHTTP validates, decodes and routes a trigger call
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, r.opts.MaxBodyBytes))
+	if err != nil {
+		r.reject(w, http.StatusRequestEntityTooLarge, fmt.Errorf("unable to read body: %w", err))
+		return
+	}
+	if !r.authorized(req, body) {
+		r.reject(w, http.StatusUnauthorized, ErrUnauthorized)
+		return
+	}
+
+	e, full, err := r.decode(body)
+	if err != nil {
+		r.reject(w, http.StatusBadRequest, err)
+		return
+	}
+	if !full && e.Incident.ID != 0 && r.opts.Client != nil {
+		if e.Incident, err = r.incident(e.Incident.ID); err != nil {
+			r.reject(w, http.StatusBadGateway, err)
+			return
+		}
+	}
+
+	if h := r.handler(e); h != nil {
+		if err := h(req.Context(), e); err != nil {
+			r.reject(w, http.StatusInternalServerError, fmt.Errorf("trigger %d handler failed: %w", e.TriggerID, err))
+			return
+		}
+	}
+
+	err = r.opts.Deliveries.Record(Delivery{
+		TriggerID:   e.TriggerID,
+		IncidentID:  e.Incident.ID,
+		ExecutionID: e.ExecutionID,
+		ReceivedAt:  e.ReceivedAt,
+	})
+	if err != nil {
+		r.opts.OnError(fmt.Errorf("unable to record delivery: %w", err))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authorized returns true if the request has the secret or a valid signature
+func (r *Receiver) authorized(req *http.Request, body []byte) bool {
+	if r.opts.Secret != "" {
+		got := req.Header.Get(r.opts.SecretHeader)
+		if got == "" && r.opts.AllowQuerySecret {
+			got = req.URL.Query().Get("secret")
+		}
+		if got != "" && subtle.ConstantTimeCompare([]byte(got), []byte(r.opts.Secret)) == 1 {
+			return true
+		}
+	}
+	if len(r.opts.HMACKey) > 0 {
+		sig, err := hex.DecodeString(strings.TrimPrefix(req.Header.Get(r.opts.SignatureHeader), "sha256="))
+		if err != nil || len(sig) == 0 {
+			return false
+		}
+		return hmac.Equal(sig, Sign(r.opts.HMACKey, body))
+	}
+	return false
+}
+
+// decode returns the event in body and true if it contained the full incident
+func (r *Receiver) decode(body []byte) (Event, bool, error) {
+	var p payload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return Event{}, false, fmt.Errorf("invalid payload: %w", err)
+	}
+	if p.TriggerID == 0 && p.TriggerName == "" {
+		return Event{}, false, errors.New("invalid payload: trigger_id or trigger_name is required")
+	}
+
+	e := Event{
+		TriggerID:   int(p.TriggerID),
+		TriggerName: p.TriggerName,
+		ExecutionID: int(p.ExecutionID),
+		ReceivedAt:  time.Now(),
+		Payload:     json.RawMessage(body),
+	}
+	if p.Incident != nil {
+		e.Incident = *p.Incident
+	}
+	if e.Incident.ID == 0 {
+		e.Incident.ID = int(p.IncidentID)
+	}
+	return e, p.Incident != nil, nil
+}
+
+func (r *Receiver) incident(id int) (endpoints.Incident, error) {
+	incs, err := r.opts.Client.Incident().Get(endpoints.IncidentGetParams{ID: id})
+	if err != nil {
+		return endpoints.Incident{}, fmt.Errorf("unable to get incident %d: %w", id, err)
+	}
+	if len(incs) == 0 {
+		return endpoints.Incident{}, fmt.Errorf("incident %d not found", id)
+	}
+	return incs[0], nil
+}
+
+// handler returns the handler for e by trigger ID, then name, then the default
+func (r *Receiver) handler(e Event) HandlerFunc {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if h, ok := r.byID[e.TriggerID]; ok {
+		return h
+	}
+	if h, ok := r.byName[e.TriggerName]; ok {
+		return h
+	}
+	return r.fallback
+}
+
+func (r *Receiver) reject(w http.ResponseWriter, status int, err error) {
+	r.opts.OnError(err)
+	http.Error(w, http.StatusText(status), status)
+}
+
+// Sign returns the HMAC-SHA256 of body with key as expected in the signature header
+func Sign(key, body []byte) []byte {
+	m := hmac.New(sha256.New, key)
+	m.Write(body)
+	return m.Sum(nil)
+}
+
+// UnmarshalJSON accepts a number, a quoted number or an empty string
+func (i *flexInt) UnmarshalJSON(b []byte) error {
+	b = bytes.Trim(b, `"`)
+	if len(b) == 0 || string(b) == "null" {
+		*i = 0
+		return nil
+	}
+	n, err := strconv.Atoi(string(b))
+	if err != nil {
+		return fmt.Errorf("invalid number %s", b)
+	}
+	*i = flexInt(n)
+	return nil
+}
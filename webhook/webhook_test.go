@@ -0,0 +1,151 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmstorm/invgo/endpoints"
+	"github.com/tmstorm/invgo/invgotest"
+	"github.com/tmstorm/invgo/scopes"
+	"github.com/tmstorm/invgo/webhook"
+)
+
+func post(rcv http.Handler, target, body string, header http.Header) int {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	w := httptest.NewRecorder()
+	rcv.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestReceiverSecret(t *testing.T) {
+	a := assert.New(t)
+
+	_, err := webhook.NewReceiver(webhook.Options{})
+	a.Error(err)
+
+	rcv, err := webhook.NewReceiver(webhook.Options{Secret: "s3cret", OnError: func(error) {}})
+	a.NoError(err)
+
+	var got []webhook.Event
+	rcv.HandleTrigger(12, func(_ context.Context, e webhook.Event) error {
+		got = append(got, e)
+		return nil
+	})
+	rcv.HandleTriggerName("Incident solved", func(context.Context, webhook.Event) error {
+		return errors.New("unavailable")
+	})
+
+	body := `{"trigger_id": "12", "trigger_name": "Incident created", "incident_id": 42}`
+	secret := http.Header{"X-Invgo-Secret": {"s3cret"}}
+	a.Equal(http.StatusUnauthorized, post(rcv, "/", body, nil))
+	a.Equal(http.StatusUnauthorized, post(rcv, "/", body, http.Header{"X-Invgo-Secret": {"wrong"}}))
+	// the query parameter is not accepted unless AllowQuerySecret is set
+	a.Equal(http.StatusUnauthorized, post(rcv, "/?secret=s3cret", body, nil))
+	a.Equal(http.StatusNoContent, post(rcv, "/", body, secret))
+	a.Equal(http.StatusBadRequest, post(rcv, "/", `{"incident_id": 42}`, secret))
+	a.Equal(http.StatusInternalServerError, post(rcv, "/", `{"trigger_name": "Incident solved", "incident_id": 42}`, secret))
+
+	a.Len(got, 1)
+	a.Equal(12, got[0].TriggerID)
+	a.Equal(42, got[0].Incident.ID)
+
+	query, err := webhook.NewReceiver(webhook.Options{Secret: "s3cret", AllowQuerySecret: true, OnError: func(error) {}})
+	a.NoError(err)
+	query.HandleDefault(func(context.Context, webhook.Event) error { return nil })
+	a.Equal(http.StatusUnauthorized, post(query, "/?secret=wrong", body, nil))
+	a.Equal(http.StatusNoContent, post(query, "/?secret=s3cret", body, nil))
+}
+
+func TestReceiverHMAC(t *testing.T) {
+	a := assert.New(t)
+
+	srv := invgotest.NewServer(t)
+	id := srv.AddIncident(endpoints.Incident{Title: "Printer on fire"})
+
+	key := []byte("hmac-key")
+	rcv, err := webhook.NewReceiver(webhook.Options{
+		HMACKey: key,
+		Client:  srv.Client(t, scopes.IncidentGet),
+		OnError: func(error) {},
+	})
+	a.NoError(err)
+
+	var got webhook.Event
+	rcv.HandleDefault(func(_ context.Context, e webhook.Event) error {
+		got = e
+		return nil
+	})
+
+	body := `{"trigger_id": 3, "incident_id": ` + strconv.Itoa(id) + `}`
+	sig := "sha256=" + hex.EncodeToString(webhook.Sign(key, []byte(body)))
+	a.Equal(http.StatusUnauthorized, post(rcv, "/", body, http.Header{"X-Invgo-Signature": {"sha256=00"}}))
+	a.Equal(http.StatusNoContent, post(rcv, "/", body, http.Header{"X-Invgo-Signature": {sig}}))
+
+	// the incident is requested since only its ID was sent
+	a.Equal("Printer on fire", got.Incident.Title)
+}
+
+func TestMissed(t *testing.T) {
+	a := assert.New(t)
+
+	store := &webhook.MemoryDeliveryStore{}
+	a.NoError(store.Record(webhook.Delivery{TriggerID: 1, IncidentID: 10, ReceivedAt: time.Now()}))
+	a.NoError(store.Record(webhook.Delivery{TriggerID: 1, IncidentID: 11, ExecutionID: 102, ReceivedAt: time.Now()}))
+	deliveries, err := store.Deliveries(time.Now().Add(-time.Minute))
+	a.NoError(err)
+
+	execs := []endpoints.TriggersExecutionsGetResponse{
+		{ID: 101, TriggerID: 1, RequestedID: 10},
+		{ID: 102, TriggerID: 1, RequestedID: 11},
+		{ID: 103, TriggerID: 1, RequestedID: 10},
+		{ID: 104, TriggerID: 2, RequestedID: 11},
+	}
+	missed := webhook.Missed(execs, deliveries)
+	a.Equal([]endpoints.TriggersExecutionsGetResponse{execs[2], execs[3]}, missed)
+}
+
+func TestReconcile(t *testing.T) {
+	a := assert.New(t)
+
+	srv := invgotest.NewServer(t)
+	now := srv.Now()
+	delivered := srv.AddTriggerExecution(endpoints.TriggersExecutionsGetResponse{TriggerID: 12, RequestedID: 42})
+	missed := srv.AddTriggerExecution(endpoints.TriggersExecutionsGetResponse{TriggerID: 12, RequestedID: 43})
+	srv.AddTriggerExecution(endpoints.TriggersExecutionsGetResponse{TriggerID: 12, RequestedID: 44, ExecutedAt: int(now.Add(-48 * time.Hour).Unix())})
+	other := srv.AddTriggerExecution(endpoints.TriggersExecutionsGetResponse{TriggerID: 13, RequestedID: 42})
+
+	rcv, err := webhook.NewReceiver(webhook.Options{Secret: "s3cret", OnError: func(error) {}})
+	a.NoError(err)
+	body := `{"trigger_id": 12, "execution_id": ` + strconv.Itoa(delivered) + `, "incident_id": 42}`
+	a.Equal(http.StatusNoContent, post(rcv, "/", body, http.Header{"X-Invgo-Secret": {"s3cret"}}))
+
+	// executions that were delivered or ran before since are skipped
+	c := srv.Client(t, scopes.TriggersExecutionsGet)
+	since := now.Add(-time.Hour)
+	execs, err := rcv.Reconcile(c, 12, since)
+	a.NoError(err)
+	if a.Len(execs, 1) {
+		a.Equal(missed, execs[0].ID)
+		a.Equal(43, execs[0].RequestedID)
+	}
+
+	// every trigger is checked when triggerID is 0
+	execs, err = rcv.Reconcile(c, 0, since)
+	a.NoError(err)
+	var ids []int
+	for _, e := range execs {
+		ids = append(ids, e.ID)
+	}
+	a.ElementsMatch([]int{missed, other}, ids)
+}